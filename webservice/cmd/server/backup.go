@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"player-character/internal/backup"
+)
+
+// runBackup writes a backup archive of the configured database to a file,
+// or to stdout when the file is "-"
+func runBackup(args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	output := flags.String("o", "", "archive file to write, or - for stdout (default backup-<time>.tar.gz)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	storage, err := openStorage()
+	if err != nil {
+		return err
+	}
+	defer storage.close()
+
+	now := time.Now()
+	path := *output
+	if path == "" {
+		path = backup.FileName(now)
+	}
+
+	var w io.Writer = os.Stdout
+	var file *os.File
+	if path != "-" {
+		file, err = os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	manifest, err := backup.Write(w, storage.backupStores(), now)
+	if err == nil && file != nil {
+		err = file.Sync()
+	}
+	if err != nil {
+		if file != nil {
+			os.Remove(path)
+		}
+		return err
+	}
+
+	for _, c := range manifest.Collections {
+		fmt.Fprintf(os.Stderr, "%-12s %6d records  sha256:%s\n", c.Name, c.Records, c.SHA256)
+	}
+	if file != nil {
+		fmt.Fprintln(os.Stderr, "Wrote", path)
+	}
+	return nil
+}
+
+// runRestore restores a backup archive from a file, or from stdin when the
+// file is "-", into the configured database
+func runRestore(args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: server restore <archive>")
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+
+	var r io.Reader = os.Stdin
+	if path := flags.Arg(0); path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	// Verify the archive before connecting, so a bad file fails fast
+	archive, err := backup.Read(r)
+	if err != nil {
+		return err
+	}
+
+	storage, err := openStorage()
+	if err != nil {
+		return err
+	}
+	defer storage.close()
+
+	result, err := archive.Restore(storage.backupStores())
+	if result != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(result)
+	}
+	return err
+}
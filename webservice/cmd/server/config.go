@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"player-character/internal/backup"
+	"player-character/pkg/database"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// mongoConfig names the MongoDB server, database and collections
+type mongoConfig struct {
+	uri               string
+	database          string
+	characters        string
+	campaigns         string
+	users             string
+	apiKeys           string
+	events            string
+	webhooks          string
+	webhookDeliveries string
+	idempotency       string
+	migrations        string
+	// cacheInvalidations carries character cache invalidations between replicas
+	cacheInvalidations string
+}
+
+// mongoConfigFromEnv reads the MongoDB settings from environment variables
+func mongoConfigFromEnv() mongoConfig {
+	return mongoConfig{
+		uri:                getEnv("MONGODB_URI", "mongodb://localhost:27017"),
+		database:           getEnv("MONGODB_DATABASE", "playercharacter"),
+		characters:         getEnv("MONGODB_COLLECTION", "playercharacters"),
+		campaigns:          getEnv("MONGODB_CAMPAIGN_COLLECTION", "campaigns"),
+		users:              getEnv("MONGODB_USER_COLLECTION", "users"),
+		apiKeys:            getEnv("MONGODB_APIKEY_COLLECTION", "apikeys"),
+		events:             getEnv("MONGODB_EVENT_COLLECTION", "events"),
+		webhooks:           getEnv("MONGODB_WEBHOOK_COLLECTION", "webhooks"),
+		webhookDeliveries:  getEnv("MONGODB_WEBHOOK_DELIVERY_COLLECTION", "webhook_deliveries"),
+		idempotency:        getEnv("MONGODB_IDEMPOTENCY_COLLECTION", "idempotency_keys"),
+		migrations:         getEnv("MONGODB_MIGRATION_COLLECTION", "migrations"),
+		cacheInvalidations: getEnv("MONGODB_CACHE_INVALIDATION_COLLECTION", "cache_invalidations"),
+	}
+}
+
+// migrator returns the schema migrator for the configured collections
+func (c mongoConfig) migrator(db *mongo.Database) *database.MongoMigrator {
+	return database.NewMongoMigrator(db, database.MongoCollections{
+		Characters:        c.characters,
+		Campaigns:         c.campaigns,
+		Users:             c.users,
+		APIKeys:           c.apiKeys,
+		Webhooks:          c.webhooks,
+		WebhookDeliveries: c.webhookDeliveries,
+	}, c.migrations)
+}
+
+// storage holds the stores the server runs on
+type storage struct {
+	characters  database.CharacterStore
+	campaigns   database.CampaignStore
+	users       database.UserStore
+	apiKeys     database.APIKeyStore
+	webhooks    database.WebhookStore
+	idempotency database.IdempotencyStore
+
+	mongo   *mongo.Database             // the MongoDB database, for the mongo event broker; nil for other backends
+	history *database.EventSourcedStore // the characters' change history with STORE=events; nil otherwise
+	close   func()
+}
+
+// openStorage opens the stores of the backend named by the STORE environment
+// variable: "mongo" (the default), "bolt", a single file for single-node
+// deployments without MongoDB, "events", a bolt file keeping every change
+// to a character as an event, or "postgres", characters in PostgreSQL
+func openStorage() (*storage, error) {
+	switch backend := getEnv("STORE", "mongo"); backend {
+	case "mongo":
+		return openMongoStorage(mongoConfigFromEnv())
+	case "bolt":
+		return openBoltStorage(getEnv("BOLT_PATH", "playercharacter.db"), 0)
+	case "events":
+		interval := database.DefaultSnapshotInterval
+		if value := os.Getenv("EVENT_SNAPSHOT_INTERVAL"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 1 {
+				return nil, fmt.Errorf("invalid EVENT_SNAPSHOT_INTERVAL %q", value)
+			}
+			interval = parsed
+		}
+		return openBoltStorage(getEnv("BOLT_PATH", "playercharacter.db"), interval)
+	case "postgres":
+		return openPostgresStorage(os.Getenv("POSTGRES_DSN"), getEnv("BOLT_PATH", "playercharacter.db"))
+	default:
+		return nil, fmt.Errorf("unknown STORE %q (expected mongo, bolt, events or postgres)", backend)
+	}
+}
+
+// openMongoStorage connects to MongoDB
+func openMongoStorage(config mongoConfig) (*storage, error) {
+	store, err := database.NewMongoStore(config.uri, config.database, config.characters)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to MongoDB: %w", err)
+	}
+
+	idempotency, err := database.NewMongoIdempotencyStore(store.Database(), config.idempotency)
+	if err != nil {
+		store.Disconnect(context.Background())
+		return nil, fmt.Errorf("initializing idempotency key store: %w", err)
+	}
+
+	return &storage{
+		characters:  store,
+		campaigns:   database.NewMongoCampaignStore(store.Database(), config.campaigns),
+		users:       database.NewMongoUserStore(store.Database(), config.users),
+		apiKeys:     database.NewMongoAPIKeyStore(store.Database(), config.apiKeys),
+		webhooks:    database.NewMongoWebhookStore(store.Database(), config.webhooks, config.webhookDeliveries),
+		idempotency: idempotency,
+		mongo:       store.Database(),
+		close:       func() { store.Disconnect(context.Background()) },
+	}, nil
+}
+
+// openBoltStorage opens, or creates, the bbolt database file at path. With a
+// snapshot interval, characters are event-sourced, snapshotted every that
+// many events. Idempotency-Key responses are kept in memory only, so retries
+// are not recognized across restarts.
+func openBoltStorage(path string, snapshotInterval int) (*storage, error) {
+	var history *database.EventSourcedStore
+	storage, err := openBoltFile(path, func(db *bolt.DB) (database.CharacterStore, error) {
+		if snapshotInterval > 0 {
+			var err error
+			history, err = database.NewEventSourcedStore(db, snapshotInterval)
+			return history, err
+		}
+		return database.NewBoltStore(db)
+	})
+	if err != nil {
+		return nil, err
+	}
+	storage.history = history
+	return storage, nil
+}
+
+// openPostgresStorage keeps characters in PostgreSQL, migrating its schema on
+// connection. There are no PostgreSQL stores for the rest yet, so campaigns,
+// users, API keys and webhooks are kept in the bbolt database file at
+// boltPath, and Idempotency-Key responses in memory, as with STORE=bolt.
+func openPostgresStorage(dsn, boltPath string) (*storage, error) {
+	if dsn == "" {
+		return nil, errors.New("STORE=postgres requires POSTGRES_DSN")
+	}
+	characters, err := database.NewPostgresStore(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to PostgreSQL: %w", err)
+	}
+
+	storage, err := openBoltFile(boltPath, func(*bolt.DB) (database.CharacterStore, error) {
+		return characters, nil
+	})
+	if err != nil {
+		characters.Close()
+		return nil, err
+	}
+	closeBolt := storage.close
+	storage.close = func() {
+		closeBolt()
+		characters.Close()
+	}
+	return storage, nil
+}
+
+// openBoltFile opens, or creates, the bbolt database file at path, keeping
+// every store but the character store that characters opens in it
+func openBoltFile(path string, characters func(db *bolt.DB) (database.CharacterStore, error)) (*storage, error) {
+	db, err := database.OpenBolt(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+
+	fail := func(err error) (*storage, error) {
+		db.Close()
+		return nil, fmt.Errorf("loading %s: %w", path, err)
+	}
+
+	characterStore, err := characters(db)
+	if err != nil {
+		return fail(err)
+	}
+	campaigns, err := database.NewBoltCampaignStore(db)
+	if err != nil {
+		return fail(err)
+	}
+	users, err := database.NewBoltUserStore(db)
+	if err != nil {
+		return fail(err)
+	}
+	apiKeys, err := database.NewBoltAPIKeyStore(db)
+	if err != nil {
+		return fail(err)
+	}
+	webhooks, err := database.NewBoltWebhookStore(db)
+	if err != nil {
+		return fail(err)
+	}
+
+	return &storage{
+		characters:  characterStore,
+		campaigns:   campaigns,
+		users:       users,
+		apiKeys:     apiKeys,
+		webhooks:    webhooks,
+		idempotency: database.NewMemoryIdempotencyStore(),
+		close:       func() { db.Close() },
+	}, nil
+}
+
+// backupStores returns the stores backups are written from and restored into
+func (s *storage) backupStores() backup.Stores {
+	return backup.Stores{
+		Characters: s.characters,
+		Campaigns:  s.campaigns,
+		Users:      s.users,
+		APIKeys:    s.apiKeys,
+		Webhooks:   s.webhooks,
+	}
+}
+
+// cacheConfigFromEnv reads the character cache settings. The cache is off
+// unless CACHE_SIZE is set.
+func cacheConfigFromEnv() (database.CacheConfig, bool, error) {
+	config := database.DefaultCacheConfig()
+	size := os.Getenv("CACHE_SIZE")
+	if size == "" || size == "0" {
+		return config, false, nil
+	}
+
+	var err error
+	if config.Size, err = strconv.Atoi(size); err != nil || config.Size < 0 {
+		return config, false, fmt.Errorf("invalid CACHE_SIZE %q", size)
+	}
+	if value := os.Getenv("CACHE_LIST_SIZE"); value != "" {
+		if config.ListSize, err = strconv.Atoi(value); err != nil || config.ListSize < 0 {
+			return config, false, fmt.Errorf("invalid CACHE_LIST_SIZE %q", value)
+		}
+	}
+	for name, ttl := range map[string]*time.Duration{"CACHE_TTL": &config.TTL, "CACHE_LIST_TTL": &config.ListTTL} {
+		if value := os.Getenv(name); value != "" {
+			if *ttl, err = time.ParseDuration(value); err != nil || *ttl < 0 {
+				return config, false, fmt.Errorf("invalid %s %q", name, value)
+			}
+		}
+	}
+	return config, true, nil
+}
+
+// getEnv returns an environment variable, or fallback when it is unset
+func getEnv(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
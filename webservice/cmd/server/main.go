@@ -2,18 +2,31 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"log"
+	"net"
 	"os"
+	"strconv"
+	"time"
 
 	_ "player-character/docs"
 	"player-character/internal/api"
+	"player-character/internal/auth"
+	"player-character/internal/authz"
+	"player-character/internal/events"
+	"player-character/internal/graph"
+	"player-character/internal/models"
+	"player-character/internal/rpc"
+	"player-character/internal/webhooks"
 	"player-character/pkg/database"
 	"player-character/pkg/logging"
+	characterv1 "player-character/pkg/pb/characterv1"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"google.golang.org/grpc"
 )
 
 // @title Player Character API
@@ -31,15 +44,47 @@ import (
 // @host localhost:8765
 // @BasePath /
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Type "Bearer" followed by a space and either the token from /api/auth/login or an API key
+
 // @externalDocs.description OpenAPI
 // @externalDocs.url https://swagger.io/resources/open-api/
 func main() {
+	// Maintenance commands share the server's configuration
+	if len(os.Args) > 1 {
+		var err error
+		switch os.Args[1] {
+		case "backup":
+			err = runBackup(os.Args[2:])
+		case "restore":
+			err = runRestore(os.Args[2:])
+		case "migrate":
+			err = runMigrate(os.Args[2:])
+		case "user":
+			err = runUser(os.Args[2:])
+		default:
+			log.Fatalf("Unknown command %q (expected backup, restore, migrate or user)", os.Args[1])
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Get port from environment variable, default to 8765
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8765"
 	}
 
+	// Get gRPC port from environment variable, default to 9090
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+
 	// Get log level from environment variable, default to info
 	logLevel := os.Getenv("LOG_LEVEL")
 	if logLevel == "" {
@@ -52,20 +97,51 @@ func main() {
 		logFormat = "json"
 	}
 
-	// Get MongoDB connection details from environment variables
-	mongoURI := os.Getenv("MONGODB_URI")
-	if mongoURI == "" {
-		mongoURI = "mongodb://localhost:27017"
+	// Get event broker from environment variable: "memory" for a single replica,
+	// "mongo" to share events between replicas through change streams
+	eventBroker := os.Getenv("EVENT_BROKER")
+	if eventBroker == "" {
+		eventBroker = "memory"
 	}
 
-	mongoDatabase := os.Getenv("MONGODB_DATABASE")
-	if mongoDatabase == "" {
-		mongoDatabase = "playercharacter"
+	// Get how long responses are kept for Idempotency-Key retries, default to 24h
+	idempotencyTTL := 24 * time.Hour
+	if ttl := os.Getenv("IDEMPOTENCY_TTL"); ttl != "" {
+		parsed, err := time.ParseDuration(ttl)
+		if err != nil || parsed <= 0 {
+			log.Fatal("Invalid IDEMPOTENCY_TTL:", ttl)
+		}
+		idempotencyTTL = parsed
 	}
 
-	mongoCollection := os.Getenv("MONGODB_COLLECTION")
-	if mongoCollection == "" {
-		mongoCollection = "playercharacters"
+	webhookConfig := webhooks.DefaultConfig()
+	if attempts := os.Getenv("WEBHOOK_MAX_ATTEMPTS"); attempts != "" {
+		parsed, err := strconv.Atoi(attempts)
+		if err != nil || parsed < 1 {
+			log.Fatal("Invalid WEBHOOK_MAX_ATTEMPTS:", attempts)
+		}
+		webhookConfig.MaxAttempts = parsed
+	}
+	webhookConfig.AllowPrivateAddresses = os.Getenv("WEBHOOK_ALLOW_PRIVATE_ADDRESSES") == "true"
+
+	// Get token signing settings from environment variables
+	jwtSecret := []byte(os.Getenv("JWT_SECRET"))
+	if len(jwtSecret) == 0 {
+		// Without a configured secret, tokens are only valid until the process restarts
+		jwtSecret = make([]byte, 32)
+		if _, err := rand.Read(jwtSecret); err != nil {
+			log.Fatal("Failed to generate token secret:", err)
+		}
+		log.Printf("JWT_SECRET not set; using a random secret, sessions will not survive restarts")
+	}
+
+	tokenTTL := 24 * time.Hour
+	if ttl := os.Getenv("TOKEN_TTL"); ttl != "" {
+		parsed, err := time.ParseDuration(ttl)
+		if err != nil {
+			log.Fatal("Invalid TOKEN_TTL:", err)
+		}
+		tokenTTL = parsed
 	}
 
 	// Initialize logger
@@ -81,14 +157,118 @@ func main() {
 	logger := logging.NewLogger(loggerConfig)
 
 	// Initialize database
-	store, err := database.NewMongoStore(mongoURI, mongoDatabase, mongoCollection)
+	storage, err := openStorage()
 	if err != nil {
-		log.Fatal("Failed to connect to MongoDB:", err)
+		log.Fatal("Failed to open storage:", err)
+	}
+	defer storage.close()
+
+	// Bring the MongoDB schema up to date unless migrations are run by hand
+	if storage.mongo != nil && os.Getenv("MONGODB_AUTO_MIGRATE") != "false" {
+		steps, err := mongoConfigFromEnv().migrator(storage.mongo).Migrate(context.Background(), database.LatestMigration, false)
+		for _, step := range steps {
+			logger.Info("Applied migration", "version", step.Version, "description", step.Description)
+		}
+		if err != nil {
+			log.Fatal("Failed to migrate database:", err)
+		}
+	}
+
+	// Optionally cache character reads; every store below goes through the cache
+	cacheConfig, cacheEnabled, err := cacheConfigFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	var cache *database.CachedStore
+	if cacheEnabled {
+		cache = database.NewCachedStore(storage.characters, cacheConfig)
+		storage.characters = cache
+	}
+
+	store := storage.characters
+	campaignStore := storage.campaigns
+	userStore := storage.users
+	apiKeyStore := storage.apiKeys
+	webhookStore := storage.webhooks
+	idempotencyStore := storage.idempotency
+
+	tokens, err := auth.NewTokenManager(jwtSecret, tokenTTL)
+	if err != nil {
+		log.Fatal("Failed to initialize token manager:", err)
+	}
+
+	policy := authz.NewPolicy(store, campaignStore)
+
+	brokerCtx, stopBroker := context.WithCancel(context.Background())
+	defer stopBroker()
+
+	var broker events.Broker
+	switch eventBroker {
+	case "memory":
+		broker = events.NewMemoryBroker()
+	case "mongo":
+		if storage.mongo == nil {
+			log.Fatal("EVENT_BROKER=mongo requires STORE=mongo")
+		}
+		broker, err = events.NewMongoBroker(brokerCtx, storage.mongo, mongoConfigFromEnv().events, func(err error) {
+			logger.Error("Event change stream failed", "error", err)
+		})
+		if err != nil {
+			log.Fatal("Failed to initialize event broker:", err)
+		}
+	default:
+		log.Fatalf("Unknown EVENT_BROKER %q (expected memory or mongo)", eventBroker)
 	}
-	defer store.Disconnect(context.Background())
+
+	// With several replicas, their writes reach each other's caches through a
+	// MongoDB change stream. The other stores cannot be shared between replicas.
+	switch invalidation := getEnv("CACHE_INVALIDATION", "local"); invalidation {
+	case "local":
+	case "mongo":
+		if cache == nil {
+			break
+		}
+		if storage.mongo == nil {
+			log.Fatal("CACHE_INVALIDATION=mongo requires STORE=mongo")
+		}
+		_, err := database.NewMongoCacheInvalidations(brokerCtx, storage.mongo, mongoConfigFromEnv().cacheInvalidations, cache, func(err error) {
+			logger.Error("Cache invalidation failed", "error", err)
+		})
+		if err != nil {
+			log.Fatal("Failed to initialize cache invalidation:", err)
+		}
+	default:
+		log.Fatalf("Unknown CACHE_INVALIDATION %q (expected local or mongo)", invalidation)
+	}
+
+	// Webhook deliveries are queued when events are published and sent in the background
+	dispatcher := webhooks.NewDispatcher(webhookStore, webhookConfig, logger)
+	go dispatcher.Run(brokerCtx)
 
 	// Initialize handlers
-	characterHandler := api.NewCharacterHandler(store, logger)
+	characterHandler := api.NewCharacterHandler(store, logger,
+		api.WithCampaignStore(campaignStore),
+		api.WithPolicy(policy),
+		api.WithEvents(events.Publishers(broker, dispatcher)))
+	campaignHandler := api.NewCampaignHandler(campaignStore, store, policy, logger)
+	authHandler := api.NewAuthHandler(userStore, tokens, logger)
+	apiKeyHandler := api.NewAPIKeyHandler(apiKeyStore, campaignStore, logger)
+	eventHandler := api.NewEventHandler(broker, store, campaignStore, policy, logger)
+	webhookHandler := api.NewWebhookHandler(webhookStore, dispatcher, campaignStore, policy, logger)
+	backupHandler := api.NewBackupHandler(storage.backupStores(), logger)
+	cacheHandler := api.NewCacheHandler(cache)
+	historyHandler := api.NewHistoryHandler(storage.history, logger)
+
+	schema, err := graph.NewSchema(characterHandler.Service(), campaignHandler.Service())
+	if err != nil {
+		log.Fatal("Failed to build GraphQL schema:", err)
+	}
+	graphqlHandler := api.NewGraphQLHandler(schema, logger)
+
+	// API keys need a matching scope; user sessions are unscoped
+	canRead := auth.RequireScope(models.ScopeRead, models.ScopeWrite)
+	canRoll := auth.RequireScope(models.ScopeRoll, models.ScopeWrite)
+	canWrite := auth.RequireScope(models.ScopeWrite)
 
 	// Initialize Gin router
 	r := gin.New() // Use gin.New() instead of gin.Default() to avoid default logging
@@ -96,8 +276,13 @@ func main() {
 	// Add custom logging middleware
 	r.Use(logger.Middleware())
 
-	// CORS middleware
-	r.Use(cors.Default())
+	// CORS middleware, allowing the Authorization header for bearer tokens and
+	// Idempotency-Key for safe retries
+	corsConfig := cors.DefaultConfig()
+	corsConfig.AllowAllOrigins = true
+	corsConfig.AddAllowHeaders("Authorization", api.IdempotencyKeyHeader)
+	corsConfig.AddExposeHeaders(api.IdempotentReplayedHeader)
+	r.Use(cors.New(corsConfig))
 
 	// Health check endpoint
 	r.GET("/health", func(c *gin.Context) {
@@ -107,21 +292,97 @@ func main() {
 	})
 
 	// API v1 routes
+	authRoutes := r.Group("/api/auth")
+	{
+		authRoutes.POST("/register", authHandler.Register)
+		authRoutes.POST("/login", authHandler.Login)
+		authRoutes.GET("/me", auth.Middleware(tokens, apiKeyStore), authHandler.Me)
+	}
+
 	v1 := r.Group("/api")
+	v1.Use(auth.Middleware(tokens, apiKeyStore), api.Idempotency(idempotencyStore, idempotencyTTL, logger,
+		api.WithBodyLimit("/api/admin/restore", api.MaxRestoreSize)))
 	{
 		characters := v1.Group("/characters")
 		{
-			characters.POST("", characterHandler.CreateCharacter)
-			characters.GET("", characterHandler.ListCharacters)
-			characters.GET("/:id", characterHandler.GetCharacter)
-			characters.PUT("/:id", characterHandler.UpdateCharacter)
-			characters.DELETE("/:id", characterHandler.DeleteCharacter)
+			characters.POST("", canWrite, characterHandler.CreateCharacter)
+			characters.GET("", canRead, characterHandler.ListCharacters)
+			characters.GET("/search", canRead, characterHandler.SearchCharacters)
+			characters.POST("/bulk", canWrite, characterHandler.BulkCharacters)
+			characters.POST("/import", canWrite, characterHandler.ImportCharacter)
+			characters.GET("/:id", canRead, characterHandler.GetCharacter)
+			characters.GET("/:id/export", canRead, characterHandler.ExportCharacter)
+			characters.GET("/:id/sheet.pdf", canRead, characterHandler.CharacterSheet)
+			characters.GET("/:id/render", canRead, characterHandler.RenderCharacter)
+			characters.PUT("/:id", canWrite, characterHandler.UpdateCharacter)
+			characters.DELETE("/:id", canWrite, characterHandler.DeleteCharacter)
+			characters.POST("/:id/roll", canRoll, characterHandler.RollCharacter)
+			characters.GET("/:id/events", canRead, eventHandler.StreamCharacterEvents)
+		}
+
+		campaigns := v1.Group("/campaigns")
+		{
+			campaigns.POST("", canWrite, campaignHandler.CreateCampaign)
+			campaigns.GET("", canRead, campaignHandler.ListCampaigns)
+			campaigns.GET("/:id", canRead, campaignHandler.GetCampaign)
+			campaigns.PUT("/:id", canWrite, campaignHandler.UpdateCampaign)
+			campaigns.DELETE("/:id", canWrite, campaignHandler.DeleteCampaign)
+			campaigns.GET("/:id/characters", canRead, campaignHandler.ListCampaignCharacters)
+			campaigns.GET("/:id/roster", canRead, campaignHandler.RenderRoster)
+			campaigns.GET("/:id/events", canRead, eventHandler.StreamCampaignEvents)
+		}
+
+		// Keys can only be managed by a logged-in user, never by another key
+		apiKeys := v1.Group("/apikeys", auth.RequireSession())
+		{
+			apiKeys.POST("", apiKeyHandler.CreateAPIKey)
+			apiKeys.GET("", apiKeyHandler.ListAPIKeys)
+			apiKeys.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
+		}
+
+		webhookRoutes := v1.Group("/webhooks", auth.RequireSession())
+		{
+			webhookRoutes.POST("", webhookHandler.CreateWebhook)
+			webhookRoutes.GET("", webhookHandler.ListWebhooks)
+			webhookRoutes.DELETE("/:id", webhookHandler.DeleteWebhook)
+			webhookRoutes.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+			webhookRoutes.POST("/:id/deliveries/:deliveryId/redeliver", webhookHandler.RedeliverDelivery)
+		}
+
+		admin := v1.Group("/admin", auth.RequireAdmin())
+		{
+			admin.GET("/backup", backupHandler.GetBackup)
+			admin.POST("/restore", backupHandler.RestoreBackup)
+			admin.GET("/cache", cacheHandler.GetCacheStats)
+			admin.GET("/characters/:id/history", historyHandler.GetCharacterHistory)
 		}
 	}
 
+	// GraphQL shares the REST services; mutations additionally require the write scope
+	r.POST("/graphql", auth.Middleware(tokens, apiKeyStore), canRead, graphqlHandler.Query)
+
 	// Swagger documentation
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// gRPC shares the REST services and credentials on its own port
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(rpc.UnaryAuth(tokens, apiKeyStore)),
+		grpc.StreamInterceptor(rpc.StreamAuth(tokens, apiKeyStore)),
+	)
+	characterv1.RegisterCharacterServiceServer(grpcServer,
+		rpc.NewCharacterServer(characterHandler.Service(), campaignHandler.Service(), broker, logger))
+
+	listener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatal("Failed to listen for gRPC:", err)
+	}
+	go func() {
+		log.Printf("gRPC server starting on :%s", grpcPort)
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatal("Failed to start gRPC server:", err)
+		}
+	}()
+
 	log.Printf("Server starting on :%s", port)
 	log.Printf("Swagger documentation available at: http://localhost:%s/swagger/index.html", port)
 
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"player-character/pkg/database"
+)
+
+// runMigrate applies MongoDB schema migrations, or with "status" lists them
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: server migrate [-dry-run] [-to version] [status]")
+		flags.PrintDefaults()
+	}
+	dryRun := flags.Bool("dry-run", false, "print the migrations that would run without running them")
+	target := flags.Int("to", database.LatestMigration, "version to migrate to; lower versions roll back, 0 rolls back everything (default the newest)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 || (flags.NArg() == 1 && flags.Arg(0) != "status") {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+
+	switch backend := getEnv("STORE", "mongo"); backend {
+	case "mongo":
+	case "postgres":
+		return errors.New("the PostgreSQL schema is migrated whenever the server or a command connects to it")
+	default:
+		return fmt.Errorf("migrations apply to MongoDB only, not STORE=%s", backend)
+	}
+	config := mongoConfigFromEnv()
+	store, err := database.NewMongoStore(config.uri, config.database, config.characters)
+	if err != nil {
+		return fmt.Errorf("connecting to MongoDB: %w", err)
+	}
+	defer store.Disconnect(context.Background())
+	migrator := config.migrator(store.Database())
+
+	if flags.Arg(0) == "status" {
+		statuses, err := migrator.Status(context.Background())
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%4d  %-19s  %s\n", status.Version, applied, status.Description)
+		}
+		return nil
+	}
+
+	steps, err := migrator.Migrate(context.Background(), *target, *dryRun)
+	for _, step := range steps {
+		fmt.Println(step)
+	}
+	if err == nil && len(steps) == 0 {
+		fmt.Fprintln(os.Stderr, "Database is up to date")
+	} else if err == nil && *dryRun {
+		fmt.Fprintln(os.Stderr, "Dry run; nothing was changed")
+	}
+	return err
+}
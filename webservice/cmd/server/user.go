@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"player-character/internal/models"
+)
+
+// runUser changes a user account's role: "promote" makes it an admin and
+// "demote" a regular user again. This is how the first admin is made, as
+// accounts registered through the API are never admins.
+func runUser(args []string) error {
+	flags := flag.NewFlagSet("user", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: server user promote|demote <username>")
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	roles := map[string]string{"promote": models.RoleAdmin, "demote": models.RoleUser}
+	role, ok := roles[flags.Arg(0)]
+	if flags.NArg() != 2 || !ok {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+	username := flags.Arg(1)
+
+	storage, err := openStorage()
+	if err != nil {
+		return err
+	}
+	defer storage.close()
+
+	user, err := storage.users.GetByUsername(username)
+	if err != nil {
+		return fmt.Errorf("finding user %q: %w", username, err)
+	}
+	if user.Role == role {
+		fmt.Fprintf(os.Stderr, "%s is already %s %s\n", user.Username, article(role), role)
+		return nil
+	}
+	if err := storage.users.SetRole(user.ID, role); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "%s is now %s %s; sessions issued before now keep the old role until they expire\n", user.Username, article(role), role)
+	return nil
+}
+
+// article returns the indefinite article for a role
+func article(role string) string {
+	if role == models.RoleAdmin {
+		return "an"
+	}
+	return "a"
+}
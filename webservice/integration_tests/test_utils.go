@@ -23,6 +23,7 @@ type TestClient struct {
 	MongoClient *mongo.Client
 	Database    *mongo.Database
 	Collection  *mongo.Collection
+	Token       string
 }
 
 // NewTestClient creates a new test client for integration testing
@@ -49,13 +50,65 @@ func NewTestClient(baseURL, mongoURI, database, collection string) (*TestClient,
 	db := mongoClient.Database(database)
 	coll := db.Collection(collection)
 
-	return &TestClient{
+	tc := &TestClient{
 		BaseURL:     baseURL,
 		HTTPClient:  httpClient,
 		MongoClient: mongoClient,
 		Database:    db,
 		Collection:  coll,
-	}, nil
+	}
+
+	// Character routes require a session, so register a throwaway account
+	if err := tc.authenticate(); err != nil {
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	return tc, nil
+}
+
+// authenticate registers a unique test user and stores its bearer token
+func (tc *TestClient) authenticate() error {
+	credentials := fmt.Sprintf(`{"username":"it%d","password":"integration-password"}`, time.Now().UnixNano())
+
+	resp, err := tc.HTTPClient.Post(tc.BaseURL+"/api/auth/register", "application/json", strings.NewReader(credentials))
+	if err != nil {
+		return fmt.Errorf("failed to register: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected register status code: %d", resp.StatusCode)
+	}
+
+	resp, err = tc.HTTPClient.Post(tc.BaseURL+"/api/auth/login", "application/json", strings.NewReader(credentials))
+	if err != nil {
+		return fmt.Errorf("failed to log in: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected login status code: %d", resp.StatusCode)
+	}
+
+	var login struct {
+		Data struct {
+			Token string `json:"token"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&login); err != nil {
+		return fmt.Errorf("failed to decode login response: %w", err)
+	}
+
+	tc.Token = login.Data.Token
+	return nil
+}
+
+// newRequest builds an authenticated request against the API
+func (tc *TestClient) newRequest(method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, tc.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+tc.Token)
+	return req, nil
 }
 
 // Close closes the test client connections
@@ -79,7 +132,7 @@ func (tc *TestClient) CreateCharacter(character *models.Character) (*models.Char
 		return nil, fmt.Errorf("failed to marshal character: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", tc.BaseURL+"/api/characters", strings.NewReader(string(jsonData)))
+	req, err := tc.newRequest("POST", "/api/characters", strings.NewReader(string(jsonData)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -106,8 +159,8 @@ func (tc *TestClient) CreateCharacter(character *models.Character) (*models.Char
 
 // GetCharacters sends a GET request to retrieve all characters
 func (tc *TestClient) GetCharacters(page, limit int) (*models.PaginationResponse, error) {
-	url := fmt.Sprintf("%s/api/characters?page=%d&limit=%d", tc.BaseURL, page, limit)
-	req, err := http.NewRequest("GET", url, nil)
+	path := fmt.Sprintf("/api/characters?page=%d&limit=%d", page, limit)
+	req, err := tc.newRequest("GET", path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -133,7 +186,7 @@ func (tc *TestClient) GetCharacters(page, limit int) (*models.PaginationResponse
 
 // GetCharacter sends a GET request to retrieve a character by ID
 func (tc *TestClient) GetCharacter(id string) (*models.Character, error) {
-	req, err := http.NewRequest("GET", tc.BaseURL+"/api/characters/"+id, nil)
+	req, err := tc.newRequest("GET", "/api/characters/"+id, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -168,7 +221,7 @@ func (tc *TestClient) UpdateCharacter(id string, character *models.Character) (*
 		return nil, fmt.Errorf("failed to marshal character: %w", err)
 	}
 
-	req, err := http.NewRequest("PUT", tc.BaseURL+"/api/characters/"+id, strings.NewReader(string(jsonData)))
+	req, err := tc.newRequest("PUT", "/api/characters/"+id, strings.NewReader(string(jsonData)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -195,7 +248,7 @@ func (tc *TestClient) UpdateCharacter(id string, character *models.Character) (*
 
 // DeleteCharacter sends a DELETE request to remove a character
 func (tc *TestClient) DeleteCharacter(id string) error {
-	req, err := http.NewRequest("DELETE", tc.BaseURL+"/api/characters/"+id, nil)
+	req, err := tc.newRequest("DELETE", "/api/characters/"+id, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
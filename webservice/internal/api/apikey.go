@@ -0,0 +1,184 @@
+package api
+
+import (
+	"net/http"
+
+	"player-character/internal/auth"
+	"player-character/internal/models"
+	"player-character/internal/validation"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler handles minting, listing and revoking API keys
+type APIKeyHandler struct {
+	store     database.APIKeyStore
+	campaigns database.CampaignStore
+	logger    *logging.Logger
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(store database.APIKeyStore, campaigns database.CampaignStore, logger *logging.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		store:     store,
+		campaigns: campaigns,
+		logger:    logger,
+	}
+}
+
+// CreateAPIKey handles POST /api/apikeys
+// @Summary Mint an API key
+// @Description Create an API key for bots and integrations, scoped to permissions and optionally to campaigns. The key is only shown once.
+// @Tags apikeys
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param apikey body models.CreateAPIKeyRequest true "Key name, scopes and campaigns"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
+// @Success 201 {object} models.CreateAPIKeyResponse
+// @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/apikeys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	principal := auth.CurrentPrincipal(c)
+	if principal == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var request models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+		return
+	}
+
+	if validationErrors := validation.ValidateAPIKeyRequest(&request); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.ValidationErrorResponse{Errors: validationErrors})
+		return
+	}
+
+	// Keys may only be scoped to campaigns the user belongs to
+	if len(request.CampaignIDs) > 0 && principal.Role != models.RoleAdmin {
+		memberships, err := h.campaigns.ListByMember(principal.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check campaign membership"})
+			return
+		}
+		member := make(map[string]bool, len(memberships))
+		for _, campaign := range memberships {
+			member[campaign.ID] = true
+		}
+		for _, campaignID := range request.CampaignIDs {
+			if !member[campaignID] {
+				c.JSON(http.StatusForbidden, gin.H{"error": "You are not a member of campaign '" + campaignID + "'"})
+				return
+			}
+		}
+	}
+
+	plaintext, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		h.logger.ErrorWithContext(c.Request.Context(), "Failed to generate API key", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate API key"})
+		return
+	}
+
+	key := models.APIKey{
+		Name:        request.Name,
+		UserID:      principal.UserID,
+		Prefix:      prefix,
+		KeyHash:     hash,
+		Scopes:      request.Scopes,
+		CampaignIDs: request.CampaignIDs,
+		ExpiresAt:   request.ExpiresAt,
+	}
+	if err := h.store.Create(&key); err != nil {
+		h.logger.ErrorWithContext(c.Request.Context(), "Failed to create API key", err, "user_id", principal.UserID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create API key: " + err.Error()})
+		return
+	}
+
+	h.logger.Info("API key created successfully",
+		"api_key_id", key.ID,
+		"user_id", principal.UserID,
+		"scopes", key.Scopes)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    models.CreateAPIKeyResponse{Key: plaintext, APIKey: key},
+		"message": "API key created successfully; store it now, it will not be shown again",
+		"success": true,
+	})
+}
+
+// ListAPIKeys handles GET /api/apikeys
+// @Summary List API keys
+// @Description List the caller's API keys, including revoked ones, with last-used timestamps
+// @Tags apikeys
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.APIKey
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/apikeys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	principal := auth.CurrentPrincipal(c)
+	if principal == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	keys, err := h.store.ListByUser(principal.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve API keys"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    keys,
+		"message": "API keys retrieved successfully",
+		"success": true,
+	})
+}
+
+// RevokeAPIKey handles DELETE /api/apikeys/{id}
+// @Summary Revoke an API key
+// @Description Revoke one of the caller's API keys; it stops working immediately
+// @Tags apikeys
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "API key ID"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/apikeys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	principal := auth.CurrentPrincipal(c)
+	if principal == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	// Other users' keys are reported as missing rather than forbidden
+	key, err := h.store.Get(c.Param("id"))
+	if err != nil || (key.UserID != principal.UserID && principal.Role != models.RoleAdmin) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+		return
+	}
+
+	if err := h.store.Revoke(key.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke API key: " + err.Error()})
+		return
+	}
+
+	h.logger.Info("API key revoked",
+		"api_key_id", key.ID,
+		"user_id", principal.UserID)
+
+	c.Status(http.StatusNoContent)
+}
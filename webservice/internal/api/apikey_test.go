@@ -0,0 +1,111 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"player-character/internal/auth"
+	"player-character/internal/authz"
+	"player-character/internal/models"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestAPIKeyLifecycle tests minting, scoped use, last-used tracking and revocation of API keys
+func TestAPIKeyLifecycle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	campaignStore := database.NewMemoryCampaignStore()
+	keyStore := database.NewMemoryAPIKeyStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	tokens, err := auth.NewTokenManager([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create token manager: %v", err)
+	}
+
+	characterHandler := NewCharacterHandler(store, logger, WithPolicy(authz.NewPolicy(store, campaignStore)))
+	apiKeyHandler := NewAPIKeyHandler(keyStore, campaignStore, logger)
+
+	router := gin.New()
+	v1 := router.Group("/api", auth.Middleware(tokens, keyStore))
+	{
+		v1.GET("/characters", auth.RequireScope(models.ScopeRead, models.ScopeWrite), characterHandler.ListCharacters)
+		v1.POST("/characters", auth.RequireScope(models.ScopeWrite), characterHandler.CreateCharacter)
+		v1.POST("/apikeys", auth.RequireSession(), apiKeyHandler.CreateAPIKey)
+		v1.DELETE("/apikeys/:id", auth.RequireSession(), apiKeyHandler.RevokeAPIKey)
+	}
+
+	session, _, err := tokens.Issue(&models.User{ID: "user-1", Username: "bot-owner", Role: models.RoleUser})
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+
+	do := func(method, path, credential string, body interface{}) *httptest.ResponseRecorder {
+		var reader *bytes.Buffer
+		if body != nil {
+			jsonData, _ := json.Marshal(body)
+			reader = bytes.NewBuffer(jsonData)
+		} else {
+			reader = &bytes.Buffer{}
+		}
+		req, _ := http.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+credential)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("POST", "/api/apikeys", session, models.CreateAPIKeyRequest{Name: "discord-bot", Scopes: []string{models.ScopeRead}})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var created struct {
+		Data models.CreateAPIKeyResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	key := created.Data.Key
+
+	stored, _ := keyStore.Get(created.Data.APIKey.ID)
+	if stored.KeyHash == key || stored.KeyHash != auth.HashAPIKey(key) {
+		t.Error("Expected the key to be stored hashed")
+	}
+
+	if w := do("GET", "/api/characters", key, nil); w.Code != http.StatusOK {
+		t.Errorf("Expected read-only key to list characters, got %d", w.Code)
+	}
+	if stored, _ := keyStore.Get(created.Data.APIKey.ID); stored.LastUsedAt == nil {
+		t.Error("Expected last-used timestamp to be recorded")
+	}
+
+	if w := do("POST", "/api/characters", key, models.Character{}); w.Code != http.StatusForbidden {
+		t.Errorf("Expected read-only key to be refused writes with %d, got %d", http.StatusForbidden, w.Code)
+	}
+
+	if w := do("POST", "/api/apikeys", key, models.CreateAPIKeyRequest{Name: "nested", Scopes: []string{models.ScopeWrite}}); w.Code != http.StatusForbidden {
+		t.Errorf("Expected keys to be unable to mint keys, got %d", w.Code)
+	}
+
+	if w := do("POST", "/api/apikeys", session, models.CreateAPIKeyRequest{Name: "sync", Scopes: []string{models.ScopeWrite}, CampaignIDs: []string{"not-mine"}}); w.Code != http.StatusForbidden {
+		t.Errorf("Expected scoping to a foreign campaign to be refused, got %d", w.Code)
+	}
+
+	if w := do("DELETE", "/api/apikeys/"+created.Data.APIKey.ID, session, nil); w.Code != http.StatusNoContent {
+		t.Fatalf("Expected revoke to succeed, got %d", w.Code)
+	}
+	if w := do("GET", "/api/characters", key, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected revoked key to be rejected with %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"player-character/internal/auth"
+	"player-character/internal/models"
+	"player-character/internal/validation"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthHandler handles account registration and login
+type AuthHandler struct {
+	users    database.UserStore
+	tokens   *auth.TokenManager
+	throttle *auth.LoginThrottle
+	logger   *logging.Logger
+}
+
+// NewAuthHandler creates a new auth handler
+func NewAuthHandler(users database.UserStore, tokens *auth.TokenManager, logger *logging.Logger) *AuthHandler {
+	return &AuthHandler{
+		users:    users,
+		tokens:   tokens,
+		throttle: auth.NewLoginThrottle(auth.DefaultLoginThrottleConfig()),
+		logger:   logger,
+	}
+}
+
+// Register handles POST /api/auth/register
+// @Summary Register a user account
+// @Description Create a new user account with a hashed password
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param account body models.RegisterRequest true "Account details"
+// @Success 201 {object} models.User
+// @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/register [post]
+func (h *AuthHandler) Register(c *gin.Context) {
+	var request models.RegisterRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+		return
+	}
+
+	if validationErrors := validation.ValidateRegistration(&request); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.ValidationErrorResponse{Errors: validationErrors})
+		return
+	}
+
+	hash, err := auth.HashPassword(request.Password)
+	if err != nil {
+		h.logger.ErrorWithContext(c.Request.Context(), "Failed to hash password", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create account"})
+		return
+	}
+
+	user := models.User{
+		Username:     request.Username,
+		Email:        request.Email,
+		PasswordHash: hash,
+		Role:         models.RoleUser,
+	}
+	if err := h.users.Create(&user); err != nil {
+		if err.Error() == "username already taken" {
+			c.JSON(http.StatusConflict, gin.H{"error": "Username already taken"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create account: " + err.Error()})
+		}
+		return
+	}
+
+	h.logger.Info("User registered successfully",
+		"user_id", user.ID,
+		"username", user.Username)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    user,
+		"message": "User registered successfully",
+		"success": true,
+	})
+}
+
+// Login handles POST /api/auth/login
+// @Summary Log in
+// @Description Exchange a username and password for a signed bearer token. After repeated failures for a username or from an address, logins are refused for a while.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body models.LoginRequest true "Login credentials"
+// @Success 200 {object} models.TokenResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var request models.LoginRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+		return
+	}
+
+	clientIP := c.ClientIP()
+	if wait, ok := h.throttle.Allow(request.Username, clientIP, time.Now()); !ok {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many failed logins; try again later"})
+		return
+	}
+
+	// Unknown usernames are checked against no hash, which takes as long as a wrong password
+	user, err := h.users.GetByUsername(request.Username)
+	hash := ""
+	if err == nil {
+		hash = user.PasswordHash
+	}
+	if !auth.CheckPassword(hash, request.Password) {
+		h.throttle.Fail(request.Username, clientIP, time.Now())
+		h.logger.Warn("Failed login attempt",
+			"username", request.Username,
+			"client_ip", clientIP)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username or password"})
+		return
+	}
+	h.throttle.Succeed(request.Username)
+
+	token, expiresAt, err := h.tokens.Issue(user)
+	if err != nil {
+		h.logger.ErrorWithContext(c.Request.Context(), "Failed to issue token", err, "user_id", user.ID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": models.TokenResponse{
+			Token:     token,
+			TokenType: "Bearer",
+			ExpiresAt: expiresAt,
+			User:      *user,
+		},
+		"message": "Login successful",
+		"success": true,
+	})
+}
+
+// Me handles GET /api/auth/me
+// @Summary Get the current user
+// @Description Return the account of the authenticated caller
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.User
+// @Failure 401 {object} map[string]string
+// @Router /api/auth/me [get]
+func (h *AuthHandler) Me(c *gin.Context) {
+	principal := auth.CurrentPrincipal(c)
+	if principal == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	user, err := h.users.Get(principal.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Account no longer exists"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    user,
+		"message": "User retrieved successfully",
+		"success": true,
+	})
+}
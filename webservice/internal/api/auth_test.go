@@ -0,0 +1,168 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"player-character/internal/auth"
+	"player-character/internal/models"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupAuthRouter wires auth routes and an authenticated character route over in-memory stores
+func setupAuthRouter(t *testing.T) (*gin.Engine, *database.MemoryStore) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	users := database.NewMemoryUserStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	tokens, err := auth.NewTokenManager([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create token manager: %v", err)
+	}
+
+	authHandler := NewAuthHandler(users, tokens, logger)
+	characterHandler := NewCharacterHandler(store, logger)
+
+	router := gin.New()
+	router.POST("/api/auth/register", authHandler.Register)
+	router.POST("/api/auth/login", authHandler.Login)
+	router.GET("/api/auth/me", auth.Middleware(tokens, nil), authHandler.Me)
+
+	v1 := router.Group("/api")
+	v1.Use(auth.Middleware(tokens, nil))
+	{
+		v1.POST("/characters", characterHandler.CreateCharacter)
+	}
+
+	return router, store
+}
+
+// postJSON sends a JSON POST request with an optional bearer token
+func postJSON(router *gin.Engine, path string, body interface{}, token string) *httptest.ResponseRecorder {
+	jsonData, _ := json.Marshal(body)
+	req, _ := http.NewRequest("POST", path, bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestAuthFlow tests registration, login and owner assignment on character creation
+func TestAuthFlow(t *testing.T) {
+	router, store := setupAuthRouter(t)
+
+	w := postJSON(router, "/api/auth/register", models.RegisterRequest{Username: "vex", Password: "correct-horse"}, "")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if bytes.Contains(w.Body.Bytes(), []byte("correct-horse")) || bytes.Contains(w.Body.Bytes(), []byte("passwordHash")) {
+		t.Error("Registration response must not expose the password or its hash")
+	}
+
+	w = postJSON(router, "/api/auth/register", models.RegisterRequest{Username: "VEX", Password: "another-pass"}, "")
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected duplicate username to return %d, got %d", http.StatusConflict, w.Code)
+	}
+
+	w = postJSON(router, "/api/auth/login", models.LoginRequest{Username: "vex", Password: "wrong-password"}, "")
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected wrong password to return %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	w = postJSON(router, "/api/auth/login", models.LoginRequest{Username: "vex", Password: "correct-horse"}, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var login struct {
+		Data models.TokenResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &login); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if login.Data.Token == "" {
+		t.Fatal("Expected a token")
+	}
+
+	character := models.Character{
+		CharacterName: "Owned Character",
+		OwnerID:       "someone-else",
+		Race:          "Human",
+		Class:         "Fighter",
+		Level:         1,
+		AbilityScores: models.AbilityScores{
+			Strength:     models.AbilityScore{Base: 15},
+			Dexterity:    models.AbilityScore{Base: 14},
+			Constitution: models.AbilityScore{Base: 13},
+			Intelligence: models.AbilityScore{Base: 12},
+			Wisdom:       models.AbilityScore{Base: 10},
+			Charisma:     models.AbilityScore{Base: 8},
+		},
+	}
+
+	w = postJSON(router, "/api/characters", character, "")
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected anonymous create to return %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	w = postJSON(router, "/api/characters", character, login.Data.Token)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var created struct {
+		Data models.Character `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	stored, err := store.Get(created.Data.ID)
+	if err != nil {
+		t.Fatalf("Failed to get created character: %v", err)
+	}
+	if stored.OwnerID != login.Data.User.ID {
+		t.Errorf("Expected owner %s, got %s", login.Data.User.ID, stored.OwnerID)
+	}
+}
+
+// TestLoginThrottling tests that repeated failed logins for a username are refused
+func TestLoginThrottling(t *testing.T) {
+	router, _ := setupAuthRouter(t)
+
+	if w := postJSON(router, "/api/auth/register", models.RegisterRequest{Username: "vex", Password: "correct-horse"}, ""); w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	// Unknown usernames fail just like wrong passwords
+	if w := postJSON(router, "/api/auth/login", models.LoginRequest{Username: "nobody", Password: "correct-horse"}, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected an unknown username to return %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	for i := 0; i < auth.DefaultLoginThrottleConfig().PerUsername; i++ {
+		if w := postJSON(router, "/api/auth/login", models.LoginRequest{Username: "vex", Password: "wrong-password"}, ""); w.Code != http.StatusUnauthorized {
+			t.Fatalf("Expected failure %d to return %d, got %d", i+1, http.StatusUnauthorized, w.Code)
+		}
+	}
+
+	// Even the right password is refused until the window ends
+	w := postJSON(router, "/api/auth/login", models.LoginRequest{Username: "VEX", Password: "correct-horse"}, "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected a Retry-After header")
+	}
+}
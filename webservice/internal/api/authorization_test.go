@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"player-character/internal/auth"
+	"player-character/internal/authz"
+	"player-character/internal/models"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// asUser is a test middleware that authenticates every request as the user in the X-Test-User header
+func asUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userID := c.GetHeader("X-Test-User"); userID != "" {
+			auth.SetPrincipal(c, &auth.Principal{UserID: userID, Role: models.RoleUser})
+		}
+		c.Next()
+	}
+}
+
+// TestCharacterAuthorization tests owner, DM, party member and outsider access rules
+func TestCharacterAuthorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	campaignStore := database.NewMemoryCampaignStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	policy := authz.NewPolicy(store, campaignStore)
+	handler := NewCharacterHandler(store, logger, WithCampaignStore(campaignStore), WithPolicy(policy))
+
+	router := gin.New()
+	router.Use(asUser())
+	router.GET("/api/characters", handler.ListCharacters)
+	router.GET("/api/characters/:id", handler.GetCharacter)
+	router.DELETE("/api/characters/:id", handler.DeleteCharacter)
+
+	campaign := models.Campaign{
+		Name:    "Tomb of Annihilation",
+		DMID:    "dm",
+		Players: []string{"alice", "bob", "carol"},
+		Parties: []models.Party{{Name: "Chult Expedition"}, {Name: "Port Nyanzaru"}},
+	}
+	if err := campaignStore.Create(&campaign); err != nil {
+		t.Fatalf("Failed to create campaign: %v", err)
+	}
+
+	scores := models.AbilityScores{
+		Strength:     models.AbilityScore{Base: 15},
+		Dexterity:    models.AbilityScore{Base: 14},
+		Constitution: models.AbilityScore{Base: 13},
+		Intelligence: models.AbilityScore{Base: 12},
+		Wisdom:       models.AbilityScore{Base: 10},
+		Charisma:     models.AbilityScore{Base: 8},
+	}
+	alice := models.Character{CharacterName: "Alice's Fighter", Race: "Human", Class: "Fighter", Level: 1, AbilityScores: scores,
+		OwnerID: "alice", CampaignID: campaign.ID, PartyID: campaign.Parties[0].ID, SecretBackstory: "Exiled prince", DMNotes: "Is the villain's heir"}
+	bob := models.Character{CharacterName: "Bob's Rogue", Race: "Elf", Class: "Rogue", Level: 1, AbilityScores: scores,
+		OwnerID: "bob", CampaignID: campaign.ID, PartyID: campaign.Parties[0].ID}
+	carol := models.Character{CharacterName: "Carol's Wizard", Race: "Gnome", Class: "Wizard", Level: 1, AbilityScores: scores,
+		OwnerID: "carol", CampaignID: campaign.ID, PartyID: campaign.Parties[1].ID}
+	for _, character := range []*models.Character{&alice, &bob, &carol} {
+		if err := store.Create(character); err != nil {
+			t.Fatalf("Failed to create character: %v", err)
+		}
+	}
+
+	get := func(userID, path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		req.Header.Set("X-Test-User", userID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	decode := func(w *httptest.ResponseRecorder) models.Character {
+		var response struct {
+			Data models.Character `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		return response.Data
+	}
+
+	// The owner sees their secret backstory but not the DM's notes
+	w := get("alice", "/api/characters/"+alice.ID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected owner read to succeed, got %d", w.Code)
+	}
+	if got := decode(w); got.SecretBackstory == "" || got.DMNotes != "" {
+		t.Errorf("Owner should see secrets but not DM notes, got %+v", got)
+	}
+
+	// The DM sees everything
+	if got := decode(get("dm", "/api/characters/"+alice.ID)); got.DMNotes == "" || got.SecretBackstory == "" {
+		t.Errorf("DM should see all hidden fields, got %+v", got)
+	}
+
+	// A party member can read the sheet, with hidden fields stripped
+	w = get("bob", "/api/characters/"+alice.ID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected party member read to succeed, got %d", w.Code)
+	}
+	if got := decode(w); got.SecretBackstory != "" || got.DMNotes != "" {
+		t.Errorf("Party member should not see hidden fields, got %+v", got)
+	}
+
+	// Players outside the party cannot
+	if w := get("carol", "/api/characters/"+alice.ID); w.Code != http.StatusForbidden {
+		t.Errorf("Expected %d for a player in another party, got %d", http.StatusForbidden, w.Code)
+	}
+
+	// Listings only include visible characters
+	var list models.PaginationResponse
+	if err := json.Unmarshal(get("bob", "/api/characters").Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if list.Pagination.Total != 2 {
+		t.Errorf("Expected bob to see 2 characters, got %d", list.Pagination.Total)
+	}
+	if err := json.Unmarshal(get("dm", "/api/characters").Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if list.Pagination.Total != 3 {
+		t.Errorf("Expected the DM to see 3 characters, got %d", list.Pagination.Total)
+	}
+
+	// Only the owner or the DM may delete
+	req, _ := http.NewRequest("DELETE", "/api/characters/"+alice.ID, nil)
+	req.Header.Set("X-Test-User", "bob")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected %d for a party member delete, got %d", http.StatusForbidden, w.Code)
+	}
+
+	req, _ = http.NewRequest("DELETE", "/api/characters/"+alice.ID, nil)
+	req.Header.Set("X-Test-User", "dm")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected the DM delete to succeed, got %d", w.Code)
+	}
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"time"
+
+	"player-character/internal/backup"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxRestoreSize bounds the archive accepted by a restore
+const MaxRestoreSize = 512 << 20
+
+// BackupHandler handles database backups and restores
+type BackupHandler struct {
+	stores backup.Stores
+	logger *logging.Logger
+}
+
+// NewBackupHandler creates a new backup handler
+func NewBackupHandler(stores backup.Stores, logger *logging.Logger) *BackupHandler {
+	return &BackupHandler{
+		stores: stores,
+		logger: logger,
+	}
+}
+
+// GetBackup handles GET /api/admin/backup
+// @Summary Back up the database
+// @Description Download a compressed archive of every character, campaign, user, API key and webhook, with a manifest of checksums. Admins only.
+// @Tags admin
+// @Security BearerAuth
+// @Produce application/gzip
+// @Success 200 {file} file
+// @Failure 403 {object} map[string]string
+// @Router /api/admin/backup [get]
+func (h *BackupHandler) GetBackup(c *gin.Context) {
+	// The archive is built before anything is sent, so a failure is still reported as an error
+	var archive bytes.Buffer
+	manifest, err := backup.Write(&archive, h.stores, time.Now())
+	if err != nil {
+		h.logger.ErrorWithContext(c.Request.Context(), "Failed to write backup", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write backup"})
+		return
+	}
+
+	h.logger.Info("Backup written", "size", archive.Len(), "collections", len(manifest.Collections))
+
+	c.Header("Content-Disposition", `attachment; filename="`+backup.FileName(manifest.CreatedAt)+`"`)
+	c.Data(http.StatusOK, "application/gzip", archive.Bytes())
+}
+
+// RestoreBackup handles POST /api/admin/restore
+// @Summary Restore a backup
+// @Description Restore an archive made by GET /api/admin/backup or the backup command. The whole archive is verified first; records whose ID is already taken are skipped, so restoring twice is harmless. Admins only.
+// @Tags admin
+// @Security BearerAuth
+// @Accept application/gzip
+// @Produce json
+// @Param archive body string true "Backup archive"
+// @Success 200 {object} backup.Result
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Router /api/admin/restore [post]
+func (h *BackupHandler) RestoreBackup(c *gin.Context) {
+	archive, err := backup.Read(http.MaxBytesReader(c.Writer, c.Request.Body, MaxRestoreSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Backup archive is too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := archive.Restore(h.stores)
+	if err != nil {
+		if errors.Is(err, backup.ErrInvalid) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.ErrorWithContext(c.Request.Context(), "Failed to restore backup", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore backup", "collections": result.Collections})
+		return
+	}
+
+	h.logger.Info("Backup restored", "created_at", archive.Manifest.CreatedAt, "collections", result.Collections)
+	c.JSON(http.StatusOK, result)
+}
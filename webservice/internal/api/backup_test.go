@@ -0,0 +1,204 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"player-character/internal/auth"
+	"player-character/internal/backup"
+	"player-character/internal/models"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupBackupRouter creates a router serving the admin routes over fresh in-memory stores
+func setupBackupRouter(t *testing.T, tokens *auth.TokenManager) (*gin.Engine, backup.Stores) {
+	t.Helper()
+	stores := backup.Stores{
+		Characters: database.NewMemoryStore(),
+		Campaigns:  database.NewMemoryCampaignStore(),
+		Users:      database.NewMemoryUserStore(),
+		APIKeys:    database.NewMemoryAPIKeyStore(),
+		Webhooks:   database.NewMemoryWebhookStore(),
+	}
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	handler := NewBackupHandler(stores, logger)
+
+	router := gin.New()
+	admin := router.Group("/api/admin", auth.Middleware(tokens, stores.APIKeys), auth.RequireAdmin())
+	admin.GET("/backup", handler.GetBackup)
+	admin.POST("/restore", handler.RestoreBackup)
+	return router, stores
+}
+
+// TestBackupAndRestore tests that an admin can download a backup and restore it into another server
+func TestBackupAndRestore(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokens, err := auth.NewTokenManager([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create token manager: %v", err)
+	}
+	adminToken, _, _ := tokens.Issue(&models.User{ID: "admin-1", Username: "admin", Role: models.RoleAdmin})
+	userToken, _, _ := tokens.Issue(&models.User{ID: "user-1", Username: "player", Role: models.RoleUser})
+
+	source, sourceStores := setupBackupRouter(t, tokens)
+	if err := sourceStores.Characters.Create(&models.Character{ID: "character-1", CharacterName: "Thorin", Race: "Dwarf", Class: "Fighter", Level: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(router *gin.Engine, method, path, token string, body []byte) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := do(source, "GET", "/api/admin/backup", userToken, nil); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for a non-admin, got %d", w.Code)
+	}
+
+	w := do(source, "GET", "/api/admin/backup", adminToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Content-Type") != "application/gzip" {
+		t.Errorf("Expected a gzip archive, got %q", w.Header().Get("Content-Type"))
+	}
+	if disposition := w.Header().Get("Content-Disposition"); !strings.HasPrefix(disposition, `attachment; filename="backup-`) {
+		t.Errorf("Unexpected Content-Disposition %q", disposition)
+	}
+	archive := w.Body.Bytes()
+
+	target, targetStores := setupBackupRouter(t, tokens)
+	if w := do(target, "POST", "/api/admin/restore", userToken, archive); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for a non-admin, got %d", w.Code)
+	}
+	if w := do(target, "POST", "/api/admin/restore", adminToken, []byte("not an archive")); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a broken archive, got %d", w.Code)
+	}
+
+	w = do(target, "POST", "/api/admin/restore", adminToken, archive)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var result backup.Result
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	restored := map[string]int{}
+	for _, c := range result.Collections {
+		restored[c.Name] = c.Restored
+	}
+	if restored["characters"] != 1 {
+		t.Errorf("Expected 1 character restored, got %+v", result.Collections)
+	}
+	if character, err := targetStores.Characters.Get("character-1"); err != nil || character.CharacterName != "Thorin" {
+		t.Errorf("Expected the character to be restored, got %+v (%v)", character, err)
+	}
+}
+
+// TestBackupAsPromotedAdmin tests backup and restore through the API as a
+// registered account made an admin the way the user promote command does it
+func TestBackupAsPromotedAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokens, err := auth.NewTokenManager([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create token manager: %v", err)
+	}
+	router, stores := setupBackupRouter(t, tokens)
+	authHandler := NewAuthHandler(stores.Users, tokens, logging.NewLogger(logging.Config{Level: "error", Format: "json", Output: "console"}))
+	router.POST("/api/auth/register", authHandler.Register)
+	router.POST("/api/auth/login", authHandler.Login)
+
+	if err := stores.Characters.Create(&models.Character{ID: "character-1", CharacterName: "Thorin", Race: "Dwarf", Class: "Fighter", Level: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	credentials := models.LoginRequest{Username: "dungeonmaster", Password: "correct-horse"}
+	if w := postJSON(router, "/api/auth/register", models.RegisterRequest{Username: credentials.Username, Password: credentials.Password}, ""); w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	login := func() string {
+		t.Helper()
+		w := postJSON(router, "/api/auth/login", credentials, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response struct {
+			Data models.TokenResponse `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response.Data.Token
+	}
+	do := func(method, path, token string, body []byte) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	token := login()
+	if w := do("GET", "/api/admin/backup", token, nil); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 before promotion, got %d", w.Code)
+	}
+
+	user, err := stores.Users.GetByUsername(credentials.Username)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stores.Users.SetRole(user.ID, models.RoleAdmin); err != nil {
+		t.Fatalf("SetRole failed: %v", err)
+	}
+
+	// Sessions keep the role they were issued with
+	if w := do("GET", "/api/admin/backup", token, nil); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for a session issued before promotion, got %d", w.Code)
+	}
+
+	token = login()
+	w := do("GET", "/api/admin/backup", token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 after promotion, got %d: %s", w.Code, w.Body.String())
+	}
+	archive := w.Body.Bytes()
+
+	// Restoring into the server it came from skips what is still there and brings back what is not
+	if err := stores.Characters.Delete("character-1"); err != nil {
+		t.Fatal(err)
+	}
+	w = do("POST", "/api/admin/restore", token, archive)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var result backup.Result
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	for _, c := range result.Collections {
+		want := 0
+		if c.Name == "characters" {
+			want = 1
+		}
+		if c.Restored != want {
+			t.Errorf("Expected %d %s restored, got %d", want, c.Name, c.Restored)
+		}
+	}
+	if character, err := stores.Characters.Get("character-1"); err != nil || character.CharacterName != "Thorin" {
+		t.Errorf("Expected the deleted character to be restored, got %+v (%v)", character, err)
+	}
+}
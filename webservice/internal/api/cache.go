@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"player-character/pkg/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheHandler reports on the character cache
+type CacheHandler struct {
+	cache *database.CachedStore
+}
+
+// NewCacheHandler creates a new cache handler. A nil cache reports the cache as disabled.
+func NewCacheHandler(cache *database.CachedStore) *CacheHandler {
+	return &CacheHandler{cache: cache}
+}
+
+// GetCacheStats handles GET /api/admin/cache
+// @Summary Get character cache statistics
+// @Description Hits, misses and evictions of this replica's character cache since it started, and how many entries it holds. Admins only.
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} database.CacheStats
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/cache [get]
+func (h *CacheHandler) GetCacheStats(c *gin.Context) {
+	if h.cache == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Character cache is disabled"})
+		return
+	}
+	c.JSON(http.StatusOK, h.cache.Stats())
+}
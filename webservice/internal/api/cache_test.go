@@ -0,0 +1,50 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetCacheStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	get := func(handler *CacheHandler) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.GET("/api/admin/cache", handler.GetCacheStats)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/admin/cache", nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := get(NewCacheHandler(nil)); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 without a cache, got %d", w.Code)
+	}
+
+	cache := database.NewCachedStore(database.NewMemoryStore(), database.DefaultCacheConfig())
+	character := &models.Character{CharacterName: "Thorin", Race: "Dwarf", Class: "Fighter", Level: 3}
+	if err := cache.Create(character); err != nil {
+		t.Fatal(err)
+	}
+	cache.Get(character.ID)
+	cache.Get(character.ID)
+
+	w := get(NewCacheHandler(cache))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var stats database.CacheStats
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("Failed to decode stats: %v", err)
+	}
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("Expected 1 hit, 1 miss and 1 entry, got %+v", stats)
+	}
+}
@@ -0,0 +1,310 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"player-character/internal/auth"
+	"player-character/internal/authz"
+	"player-character/internal/models"
+	"player-character/internal/render"
+	"player-character/internal/service"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CampaignHandler handles campaign-related HTTP requests
+type CampaignHandler struct {
+	service    *service.Campaigns
+	characters *service.Characters
+	logger     *logging.Logger
+}
+
+// NewCampaignHandler creates a new campaign handler. A nil policy disables access checks.
+func NewCampaignHandler(store database.CampaignStore, characters database.CharacterStore, policy *authz.Policy, logger *logging.Logger) *CampaignHandler {
+	return &CampaignHandler{
+		service:    service.NewCampaigns(store, policy, logger),
+		characters: service.NewCharacters(characters, store, policy, nil, logger),
+		logger:     logger,
+	}
+}
+
+// Service returns the campaign service behind the handler, for other transports to share
+func (h *CampaignHandler) Service() *service.Campaigns {
+	return h.service
+}
+
+// CreateCampaign handles POST /api/campaigns
+// @Summary Create a new campaign
+// @Description Create a campaign with a DM, member players, parties and settings
+// @Tags campaigns
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param campaign body models.Campaign true "Campaign data"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
+// @Success 201 {object} models.Campaign
+// @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 500 {object} map[string]string
+// @Router /api/campaigns [post]
+func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
+	var campaign models.Campaign
+	if err := c.ShouldBindJSON(&campaign); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+		return
+	}
+
+	if err := h.service.Create(c.Request.Context(), auth.CurrentPrincipal(c), &campaign); err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    campaign,
+		"message": "Campaign created successfully",
+		"success": true,
+	})
+}
+
+// GetCampaign handles GET /api/campaigns/{id}
+// @Summary Get a campaign by ID
+// @Description Retrieve a specific campaign by its ID
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Campaign ID"
+// @Success 200 {object} models.Campaign
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/campaigns/{id} [get]
+func (h *CampaignHandler) GetCampaign(c *gin.Context) {
+	campaign, err := h.service.Get(c.Request.Context(), auth.CurrentPrincipal(c), c.Param("id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    campaign,
+		"message": "Campaign retrieved successfully",
+		"success": true,
+	})
+}
+
+// ListCampaigns handles GET /api/campaigns
+// @Summary List campaigns
+// @Description Get a paginated list of the campaigns the caller runs, plays in or views, newest first
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param limit query int false "Items per page (default: 20, max: 100)" minimum(1) maximum(100)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/campaigns [get]
+func (h *CampaignHandler) ListCampaigns(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page parameter"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter (1-100)"})
+		return
+	}
+
+	campaigns, total, err := h.service.List(c.Request.Context(), auth.CurrentPrincipal(c), page, limit)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	totalPages := (total + limit - 1) / limit
+	c.JSON(http.StatusOK, gin.H{
+		"data": campaigns,
+		"pagination": gin.H{
+			"page":       page,
+			"limit":      limit,
+			"total":      total,
+			"totalPages": totalPages,
+			"hasNext":    page < totalPages,
+		},
+	})
+}
+
+// UpdateCampaign handles PUT /api/campaigns/{id}
+// @Summary Update a campaign
+// @Description Update an existing campaign by ID
+// @Tags campaigns
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Campaign ID"
+// @Param campaign body models.Campaign true "Updated campaign data"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
+// @Success 200 {object} models.Campaign
+// @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/campaigns/{id} [put]
+func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
+	var campaign models.Campaign
+	if err := c.ShouldBindJSON(&campaign); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+		return
+	}
+
+	if err := h.service.Update(c.Request.Context(), auth.CurrentPrincipal(c), c.Param("id"), &campaign); err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    campaign,
+		"message": "Campaign updated successfully",
+		"success": true,
+	})
+}
+
+// DeleteCampaign handles DELETE /api/campaigns/{id}
+// @Summary Delete a campaign
+// @Description Delete a campaign by ID
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Campaign ID"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
+// @Success 204 "No Content"
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/campaigns/{id} [delete]
+func (h *CampaignHandler) DeleteCampaign(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), auth.CurrentPrincipal(c), c.Param("id")); err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListCampaignCharacters handles GET /api/campaigns/{id}/characters
+// @Summary List characters in a campaign
+// @Description Get a paginated list of the characters that belong to a campaign, optionally narrowed to one party
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Campaign ID"
+// @Param partyId query string false "Only characters in this party"
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param limit query int false "Items per page (default: 20, max: 100)" minimum(1) maximum(100)
+// @Param sortBy query string false "Sort field (characterName, level, race, class, createdAt)" enum(characterName,level,race,class,createdAt)
+// @Param sortOrder query string false "Sort order (asc, desc)" enum(asc,desc)
+// @Param search query string false "Search term to filter characters by name, race, or class"
+// @Param filter query string false "Structured filter, e.g. level>=5 AND class in (Wizard,Sorcerer)"
+// @Param cursor query string false "Continue after this pagination.nextCursor instead of using page"
+// @Param count query bool false "Count matching characters for total and totalPages (default: true)"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,characterName,level,class"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/campaigns/{id}/characters [get]
+func (h *CampaignHandler) ListCampaignCharacters(c *gin.Context) {
+	campaign, err := h.service.Get(c.Request.Context(), auth.CurrentPrincipal(c), c.Param("id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	opts, ok := parseListOptions(c)
+	if !ok {
+		return
+	}
+
+	opts.CampaignID = campaign.ID
+	if partyID := c.Query("partyId"); partyID != "" {
+		if !campaign.HasParty(partyID) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Party not found"})
+			return
+		}
+		opts.PartyID = partyID
+	}
+
+	respondWithCharacterList(c, h.characters, opts)
+}
+
+// maxRosterSize is the most characters a roster lists
+const maxRosterSize = 100
+
+// RenderRoster handles GET /api/campaigns/{id}/roster
+// @Summary Render a party roster
+// @Description Render the characters of a campaign, or of one of its parties, as a compact Markdown or HTML roster, using the campaign's template when it has one. At most 100 characters are listed, by name.
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce text/markdown
+// @Produce text/html
+// @Param id path string true "Campaign ID"
+// @Param partyId query string false "Only characters in this party"
+// @Param format query string false "Output format (markdown or html)" default(markdown)
+// @Success 200 {string} string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /api/campaigns/{id}/roster [get]
+func (h *CampaignHandler) RenderRoster(c *gin.Context) {
+	format, ok := parseRenderFormat(c)
+	if !ok {
+		return
+	}
+
+	principal := auth.CurrentPrincipal(c)
+	campaign, err := h.service.Get(c.Request.Context(), principal, c.Param("id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	opts := database.ListOptions{
+		Page:       1,
+		Limit:      maxRosterSize,
+		SortBy:     "characterName",
+		SortOrder:  "asc",
+		CampaignID: campaign.ID,
+	}
+	var party *models.Party
+	if partyID := c.Query("partyId"); partyID != "" {
+		for i := range campaign.Parties {
+			if campaign.Parties[i].ID == partyID {
+				party = &campaign.Parties[i]
+			}
+		}
+		if party == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Party not found"})
+			return
+		}
+		opts.PartyID = partyID
+	}
+
+	characters, total, err := h.characters.List(c.Request.Context(), principal, opts)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	var out bytes.Buffer
+	if err := render.RenderRoster(&out, campaign, party, characters, total, format); err != nil {
+		respondWithRenderError(c, h.logger, err)
+		return
+	}
+	c.Data(http.StatusOK, render.ContentType(format), out.Bytes())
+}
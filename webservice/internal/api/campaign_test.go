@@ -0,0 +1,320 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupCampaignRouter wires character and campaign handlers over in-memory stores
+func setupCampaignRouter() (*gin.Engine, *database.MemoryStore, *database.MemoryCampaignStore) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	campaignStore := database.NewMemoryCampaignStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	characterHandler := NewCharacterHandler(store, logger, WithCampaignStore(campaignStore))
+	campaignHandler := NewCampaignHandler(campaignStore, store, nil, logger)
+
+	router := gin.New()
+	v1 := router.Group("/api")
+	{
+		characters := v1.Group("/characters")
+		{
+			characters.POST("", characterHandler.CreateCharacter)
+			characters.GET("/:id/render", characterHandler.RenderCharacter)
+		}
+		campaigns := v1.Group("/campaigns")
+		{
+			campaigns.POST("", campaignHandler.CreateCampaign)
+			campaigns.PUT("/:id", campaignHandler.UpdateCampaign)
+			campaigns.GET("/:id/characters", campaignHandler.ListCampaignCharacters)
+			campaigns.GET("/:id/roster", campaignHandler.RenderRoster)
+		}
+	}
+
+	return router, store, campaignStore
+}
+
+// TestListCampaignCharacters tests that campaign listing only returns the campaign's characters
+func TestListCampaignCharacters(t *testing.T) {
+	router, store, campaignStore := setupCampaignRouter()
+
+	campaign := models.Campaign{
+		Name:    "Curse of Strahd",
+		DMID:    "dm-1",
+		Parties: []models.Party{{Name: "Night Watch"}},
+	}
+	if err := campaignStore.Create(&campaign); err != nil {
+		t.Fatalf("Failed to create campaign: %v", err)
+	}
+	partyID := campaign.Parties[0].ID
+
+	scores := models.AbilityScores{
+		Strength:     models.AbilityScore{Base: 15},
+		Dexterity:    models.AbilityScore{Base: 14},
+		Constitution: models.AbilityScore{Base: 13},
+		Intelligence: models.AbilityScore{Base: 12},
+		Wisdom:       models.AbilityScore{Base: 10},
+		Charisma:     models.AbilityScore{Base: 8},
+	}
+	members := []models.Character{
+		{CharacterName: "Ireena", Race: "Human", Class: "Fighter", Level: 1, AbilityScores: scores, CampaignID: campaign.ID, PartyID: partyID},
+		{CharacterName: "Ismark", Race: "Human", Class: "Fighter", Level: 1, AbilityScores: scores, CampaignID: campaign.ID},
+		{CharacterName: "Outsider", Race: "Elf", Class: "Wizard", Level: 1, AbilityScores: scores},
+	}
+	for i := range members {
+		if err := store.Create(&members[i]); err != nil {
+			t.Fatalf("Failed to create character: %v", err)
+		}
+	}
+
+	req, _ := http.NewRequest("GET", "/api/campaigns/"+campaign.ID+"/characters", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response models.PaginationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Pagination.Total != 2 {
+		t.Errorf("Expected 2 campaign characters, got %d", response.Pagination.Total)
+	}
+
+	req, _ = http.NewRequest("GET", "/api/campaigns/"+campaign.ID+"/characters?partyId="+partyID, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Data) != 1 || response.Data[0].CharacterName != "Ireena" {
+		t.Errorf("Expected only the party member, got %+v", response.Data)
+	}
+}
+
+// TestCreateCharacter_CampaignSettings tests that campaign settings are enforced on creation
+func TestCreateCharacter_CampaignSettings(t *testing.T) {
+	router, _, campaignStore := setupCampaignRouter()
+
+	campaign := models.Campaign{
+		Name: "Standard Array Only",
+		DMID: "dm-1",
+		Settings: models.CampaignSettings{
+			AllowedClasses:     []string{"Fighter", "Rogue"},
+			Leveling:           models.LevelingMilestone,
+			AbilityScoreMethod: models.AbilityMethodStandardArray,
+		},
+	}
+	if err := campaignStore.Create(&campaign); err != nil {
+		t.Fatalf("Failed to create campaign: %v", err)
+	}
+
+	character := models.Character{
+		CharacterName:    "Rule Breaker",
+		Race:             "Human",
+		Class:            "Wizard",
+		Level:            1,
+		ExperiencePoints: 300,
+		CampaignID:       campaign.ID,
+		AbilityScores: models.AbilityScores{
+			Strength:     models.AbilityScore{Base: 18},
+			Dexterity:    models.AbilityScore{Base: 14},
+			Constitution: models.AbilityScore{Base: 13},
+			Intelligence: models.AbilityScore{Base: 12},
+			Wisdom:       models.AbilityScore{Base: 10},
+			Charisma:     models.AbilityScore{Base: 8},
+		},
+	}
+
+	jsonData, _ := json.Marshal(character)
+	req, _ := http.NewRequest("POST", "/api/characters", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	var response models.ValidationErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	codes := map[string]bool{}
+	for _, e := range response.Errors {
+		codes[e.Code] = true
+	}
+	for _, want := range []string{"CLASS_NOT_ALLOWED", "XP_NOT_ALLOWED", "INVALID_ABILITY_METHOD"} {
+		if !codes[want] {
+			t.Errorf("Expected validation error %s, got %+v", want, response.Errors)
+		}
+	}
+}
+
+// TestRenderCharacterTemplates tests stat blocks with the default and campaign templates
+func TestRenderCharacterTemplates(t *testing.T) {
+	router, store, campaignStore := setupCampaignRouter()
+
+	campaign := models.Campaign{Name: "Curse of Strahd", DMID: "dm-1"}
+	if err := campaignStore.Create(&campaign); err != nil {
+		t.Fatalf("Failed to create campaign: %v", err)
+	}
+	character := models.Character{
+		CharacterName: "Ireena <Kolyana>",
+		Race:          "Human",
+		Class:         "Fighter",
+		Level:         3,
+		CampaignID:    campaign.ID,
+		AbilityScores: models.AbilityScores{
+			Strength:     models.AbilityScore{Base: 15},
+			Dexterity:    models.AbilityScore{Base: 14},
+			Constitution: models.AbilityScore{Base: 14},
+			Intelligence: models.AbilityScore{Base: 12},
+			Wisdom:       models.AbilityScore{Base: 10},
+			Charisma:     models.AbilityScore{Base: 8},
+		},
+	}
+	if err := store.Create(&character); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/api/characters/"+character.ID+"/render"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/markdown; charset=utf-8" {
+		t.Fatalf("Expected a Markdown stat block, got %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	if body := w.Body.String(); !strings.Contains(body, `## Ireena \<Kolyana\>`) || !strings.Contains(body, "**Hit Points** 28") {
+		t.Errorf("Expected the escaped name and derived hit points, got:\n%s", body)
+	}
+
+	w = get("?format=html")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Fatalf("Expected an HTML stat block, got %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	if body := w.Body.String(); !strings.Contains(body, "<h2>Ireena &lt;Kolyana&gt;</h2>") {
+		t.Errorf("Expected the name escaped for HTML, got:\n%s", body)
+	}
+
+	if w = get("?format=pdf"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown format, got %d", w.Code)
+	}
+
+	// The campaign's template replaces the default
+	update := func(templates models.CampaignTemplates) int {
+		updated := campaign
+		updated.Settings.Templates = templates
+		body, _ := json.Marshal(updated)
+		req, _ := http.NewRequest("PUT", "/api/campaigns/"+campaign.ID, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	if code := update(models.CampaignTemplates{CharacterMarkdown: "{{.CharacterName}} ({{.CampaignName}}, level {{.TotalLevel}}, {{signed .ProficiencyBonus}})"}); code != http.StatusOK {
+		t.Fatalf("Expected the template to be saved, got %d", code)
+	}
+	if w = get(""); w.Body.String() != "Ireena <Kolyana> (Curse of Strahd, level 3, +2)" {
+		t.Errorf("Expected the campaign template, got %q", w.Body.String())
+	}
+	if w = get("?format=html"); !strings.Contains(w.Body.String(), "<article") {
+		t.Errorf("Expected the default HTML template, got %q", w.Body.String())
+	}
+
+	// Broken templates are rejected when saved, and ones that fail to run report why
+	if code := update(models.CampaignTemplates{CharacterMarkdown: "{{.CharacterName"}); code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a template that does not parse, got %d", code)
+	}
+	if code := update(models.CampaignTemplates{CharacterMarkdown: "{{.Nickname}}"}); code != http.StatusOK {
+		t.Fatalf("Expected the template to be saved, got %d", code)
+	}
+	if w = get(""); w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), "Nickname") {
+		t.Errorf("Expected status 422 naming the missing field, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+// TestRenderRoster tests campaign and party rosters
+func TestRenderRoster(t *testing.T) {
+	router, store, campaignStore := setupCampaignRouter()
+
+	campaign := models.Campaign{
+		Name:    "Curse of Strahd",
+		DMID:    "dm-1",
+		Parties: []models.Party{{Name: "Night Watch"}},
+	}
+	if err := campaignStore.Create(&campaign); err != nil {
+		t.Fatalf("Failed to create campaign: %v", err)
+	}
+	partyID := campaign.Parties[0].ID
+
+	scores := models.AbilityScores{
+		Strength:     models.AbilityScore{Base: 15},
+		Dexterity:    models.AbilityScore{Base: 14},
+		Constitution: models.AbilityScore{Base: 13},
+		Intelligence: models.AbilityScore{Base: 12},
+		Wisdom:       models.AbilityScore{Base: 10},
+		Charisma:     models.AbilityScore{Base: 8},
+	}
+	members := []models.Character{
+		{CharacterName: "Ismark", PlayerName: "Ann", Race: "Human", Class: "Fighter", Level: 2, AbilityScores: scores, CampaignID: campaign.ID},
+		{CharacterName: "Ireena", PlayerName: "Bo", Race: "Elf", Subrace: "High Elf", Class: "Wizard", Level: 1, AbilityScores: scores, CampaignID: campaign.ID, PartyID: partyID},
+		{CharacterName: "Outsider", Race: "Elf", Class: "Wizard", Level: 1, AbilityScores: scores},
+	}
+	for i := range members {
+		if err := store.Create(&members[i]); err != nil {
+			t.Fatalf("Failed to create character: %v", err)
+		}
+	}
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/api/campaigns/"+campaign.ID+"/roster"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	expected := "## Curse of Strahd\n\n" +
+		"| Character | Player | Race | Class | Level | HP |\n" +
+		"|---|---|---|---|:---:|:---:|\n" +
+		"| Ireena | Bo | High Elf | Wizard 1 | 1 | 7 |\n" +
+		"| Ismark | Ann | Human | Fighter 2 | 2 | 18 |\n"
+	if w.Body.String() != expected {
+		t.Errorf("Expected roster\n%s\ngot\n%s", expected, w.Body.String())
+	}
+
+	w = get("?format=html&partyId=" + partyID)
+	if body := w.Body.String(); !strings.Contains(body, "<h2>Curse of Strahd: Night Watch</h2>") || !strings.Contains(body, "Ireena") || strings.Contains(body, "Ismark") {
+		t.Errorf("Expected the party's roster, got:\n%s", body)
+	}
+
+	if w = get("?partyId=missing"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for an unknown party, got %d", w.Code)
+	}
+}
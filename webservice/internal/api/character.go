@@ -1,12 +1,25 @@
 package api
 
 import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand/v2"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
+	"player-character/internal/auth"
+	"player-character/internal/authz"
+	"player-character/internal/events"
+	"player-character/internal/formats"
 	"player-character/internal/models"
-	"player-character/internal/validation"
+	"player-character/internal/render"
+	"player-character/internal/service"
+	"player-character/internal/sheet"
 	"player-character/pkg/database"
+	"player-character/pkg/filter"
 	"player-character/pkg/logging"
 
 	"github.com/gin-gonic/gin"
@@ -14,27 +27,68 @@ import (
 
 // CharacterHandler handles character-related HTTP requests
 type CharacterHandler struct {
-	store  database.CharacterStore
-	logger *logging.Logger
+	store     database.CharacterStore
+	campaigns database.CampaignStore
+	policy    *authz.Policy
+	events    events.Publisher
+	service   *service.Characters
+	logger    *logging.Logger
+}
+
+// CharacterHandlerOption configures optional CharacterHandler dependencies
+type CharacterHandlerOption func(*CharacterHandler)
+
+// WithCampaignStore enables campaign membership checks and campaign-level validation
+func WithCampaignStore(campaigns database.CampaignStore) CharacterHandlerOption {
+	return func(h *CharacterHandler) {
+		h.campaigns = campaigns
+	}
+}
+
+// WithPolicy enforces ownership, campaign and party access rules on every request
+func WithPolicy(policy *authz.Policy) CharacterHandlerOption {
+	return func(h *CharacterHandler) {
+		h.policy = policy
+	}
+}
+
+// WithEvents publishes a change event after every successful create, update and delete
+func WithEvents(publisher events.Publisher) CharacterHandlerOption {
+	return func(h *CharacterHandler) {
+		h.events = publisher
+	}
 }
 
 // NewCharacterHandler creates a new character handler
-func NewCharacterHandler(store database.CharacterStore, logger *logging.Logger) *CharacterHandler {
-	return &CharacterHandler{
+func NewCharacterHandler(store database.CharacterStore, logger *logging.Logger, opts ...CharacterHandlerOption) *CharacterHandler {
+	h := &CharacterHandler{
 		store:  store,
 		logger: logger,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.service = service.NewCharacters(h.store, h.campaigns, h.policy, h.events, h.logger)
+	return h
+}
+
+// Service returns the character service behind the handler, for other transports to share
+func (h *CharacterHandler) Service() *service.Characters {
+	return h.service
 }
 
 // CreateCharacter handles POST /api/characters
 // @Summary Create a new character
 // @Description Create a new D&D 5e character with validation
 // @Tags characters
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param character body models.Character true "Character data"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
 // @Success 201 {object} models.Character
 // @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/characters [post]
 func (h *CharacterHandler) CreateCharacter(c *gin.Context) {
@@ -48,29 +102,11 @@ func (h *CharacterHandler) CreateCharacter(c *gin.Context) {
 		return
 	}
 
-	// Validate character
-	if validationErrors := validation.ValidateCharacter(&character); len(validationErrors) > 0 {
-		h.logger.Warn("Character validation failed",
-			"character_name", character.CharacterName,
-			"validation_errors", validationErrors)
-		c.JSON(http.StatusBadRequest, models.ValidationErrorResponse{Errors: validationErrors})
+	if err := h.service.Create(c.Request.Context(), auth.CurrentPrincipal(c), &character); err != nil {
+		respondWithError(c, err)
 		return
 	}
 
-	// Create character
-	if err := h.store.Create(&character); err != nil {
-		h.logger.ErrorWithContext(c.Request.Context(), "Failed to create character", err,
-			"character_name", character.CharacterName,
-			"character_id", character.ID)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create character: " + err.Error()})
-		return
-	}
-
-	h.logger.Info("Character created successfully",
-		"character_id", character.ID,
-		"character_name", character.CharacterName,
-		"character_class", character.Class)
-
 	c.JSON(http.StatusCreated, gin.H{
 		"data":    character,
 		"message": "Character created successfully",
@@ -82,10 +118,13 @@ func (h *CharacterHandler) CreateCharacter(c *gin.Context) {
 // @Summary Get a character by ID
 // @Description Retrieve a specific character by its ID
 // @Tags characters
+// @Security BearerAuth
 // @Produce json
 // @Param id path string true "Character ID"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,characterName,level,class"
 // @Success 200 {object} models.Character
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Router /api/characters/{id} [get]
 func (h *CharacterHandler) GetCharacter(c *gin.Context) {
@@ -95,14 +134,26 @@ func (h *CharacterHandler) GetCharacter(c *gin.Context) {
 		return
 	}
 
-	character, err := h.store.Get(idStr)
+	fields, err := database.ParseFields(c.Query("fields"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid fields parameter: " + err.Error()})
+		return
+	}
+
+	character, err := h.service.Get(c.Request.Context(), auth.CurrentPrincipal(c), idStr)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Character not found"})
+		respondWithError(c, err)
+		return
+	}
+
+	data, err := sparseCharacter(character, fields)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render character"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data":    character,
+		"data":    data,
 		"message": "Character retrieved successfully",
 		"success": true,
 	})
@@ -112,17 +163,91 @@ func (h *CharacterHandler) GetCharacter(c *gin.Context) {
 // @Summary List characters
 // @Description Get a paginated list of characters with optional sorting and search
 // @Tags characters
+// @Security BearerAuth
 // @Produce json
 // @Param page query int false "Page number (default: 1)" minimum(1)
 // @Param limit query int false "Items per page (default: 20, max: 100)" minimum(1) maximum(100)
 // @Param sortBy query string false "Sort field (characterName, level, race, class, createdAt)" enum(characterName,level,race,class,createdAt)
 // @Param sortOrder query string false "Sort order (asc, desc)" enum(asc,desc)
 // @Param search query string false "Search term to filter characters by name, race, or class"
+// @Param filter query string false "Structured filter, e.g. level>=5 AND class in (Wizard,Sorcerer)"
+// @Param cursor query string false "Continue after this pagination.nextCursor instead of using page"
+// @Param count query bool false "Count matching characters for total and totalPages (default: true)"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,characterName,level,class"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/characters [get]
 func (h *CharacterHandler) ListCharacters(c *gin.Context) {
+	opts, ok := parseListOptions(c)
+	if !ok {
+		return
+	}
+
+	respondWithCharacterList(c, h.service, opts)
+}
+
+// SearchCharacters handles GET /api/characters/search
+// @Summary Search characters
+// @Description Full-text search over name, player name, features, personality, backstory and notes, most relevant first.
+// @Description Words match any; "quoted phrases" must appear and -words must not. Each hit carries HTML snippets with matches in <mark> tags.
+// @Tags characters
+// @Security BearerAuth
+// @Produce json
+// @Param q query string true "Search query"
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param limit query int false "Items per page (default: 20, max: 100)" minimum(1) maximum(100)
+// @Param filter query string false "Structured filter, e.g. level>=5 AND class in (Wizard,Sorcerer)"
+// @Param count query bool false "Count matching characters for total and totalPages (default: true)"
+// @Success 200 {object} models.SearchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/search [get]
+func (h *CharacterHandler) SearchCharacters(c *gin.Context) {
+	opts, ok := parseListOptions(c)
+	if !ok {
+		return
+	}
+	if opts.After != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Search results are paged by page, not cursor"})
+		return
+	}
+	if opts.Fields != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Search results always include every field"})
+		return
+	}
+
+	// Without a count, read one extra hit to tell whether another page follows
+	opts.Lookahead = opts.SkipCount
+
+	hits, total, err := h.service.Search(c.Request.Context(), auth.CurrentPrincipal(c), c.Query("q"), opts)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	pagination := gin.H{
+		"page":  opts.Page,
+		"limit": opts.Limit,
+	}
+	if opts.SkipCount {
+		pagination["hasNext"] = len(hits) > opts.Limit
+		hits = hits[:min(len(hits), opts.Limit)]
+	} else {
+		pagination["total"] = total
+		pagination["totalPages"] = (total + opts.Limit - 1) / opts.Limit // Ceiling division
+		pagination["hasNext"] = opts.Page*opts.Limit < total
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       hits,
+		"pagination": pagination,
+	})
+}
+
+// parseListOptions reads and validates pagination, sorting, search, filter and fields query parameters.
+// It writes a 400 response and returns false when a parameter is invalid.
+func parseListOptions(c *gin.Context) (database.ListOptions, bool) {
 	// Parse pagination parameters
 	pageStr := c.DefaultQuery("page", "1")
 	limitStr := c.DefaultQuery("limit", "20")
@@ -130,13 +255,13 @@ func (h *CharacterHandler) ListCharacters(c *gin.Context) {
 	page, err := strconv.Atoi(pageStr)
 	if err != nil || page < 1 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page parameter"})
-		return
+		return database.ListOptions{}, false
 	}
 
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil || limit < 1 || limit > 100 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter (1-100)"})
-		return
+		return database.ListOptions{}, false
 	}
 
 	// Parse sorting parameters
@@ -153,48 +278,106 @@ func (h *CharacterHandler) ListCharacters(c *gin.Context) {
 	}
 	if !validSortFields[sortBy] {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sortBy parameter"})
-		return
+		return database.ListOptions{}, false
 	}
 
 	// Validate sortOrder parameter
 	if sortOrder != "asc" && sortOrder != "desc" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sortOrder parameter (must be 'asc' or 'desc')"})
-		return
+		return database.ListOptions{}, false
+	}
+
+	// Parse the structured filter
+	expr, err := filter.Parse(c.Query("filter"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return database.ListOptions{}, false
 	}
 
-	// Parse search parameter
-	search := c.DefaultQuery("search", "")
+	// A cursor continues from a previous page in place of the page number
+	var after *database.Cursor
+	if token := c.Query("cursor"); token != "" {
+		after, err = database.DecodeCursor(token, sortBy, sortOrder)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor parameter (it must come from a listing with the same sortBy and sortOrder)"})
+			return database.ListOptions{}, false
+		}
+	}
+
+	count, err := strconv.ParseBool(c.DefaultQuery("count", "true"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid count parameter (must be 'true' or 'false')"})
+		return database.ListOptions{}, false
+	}
 
-	characters, total, err := h.store.List(page, limit, sortBy, sortOrder, search)
+	// A sparse fieldset loads and returns only the listed fields
+	fields, err := database.ParseFields(c.Query("fields"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve characters"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid fields parameter: " + err.Error()})
+		return database.ListOptions{}, false
+	}
+
+	return database.ListOptions{
+		Page:      page,
+		Limit:     limit,
+		After:     after,
+		SkipCount: !count,
+		SortBy:    sortBy,
+		SortOrder: sortOrder,
+		Search:    c.DefaultQuery("search", ""),
+		Filter:    expr,
+		Fields:    fields,
+	}, true
+}
+
+// respondWithCharacterList runs a list query and writes the paginated response.
+// Only characters visible to the caller are listed, with hidden fields stripped.
+func respondWithCharacterList(c *gin.Context, characters *service.Characters, opts database.ListOptions) {
+	page, err := characters.Page(c.Request.Context(), auth.CurrentPrincipal(c), opts)
+	if err != nil {
+		respondWithError(c, err)
 		return
 	}
 
-	// Calculate pagination metadata
-	totalPages := (total + limit - 1) / limit // Ceiling division
-	hasNext := page < totalPages
+	// Cursor pages have no page number, and uncounted listings no totals
+	pagination := gin.H{
+		"limit":   opts.Limit,
+		"hasNext": page.HasNext,
+	}
+	if opts.After == nil {
+		pagination["page"] = opts.Page
+	}
+	if !opts.SkipCount {
+		pagination["total"] = page.Total
+		pagination["totalPages"] = (page.Total + opts.Limit - 1) / opts.Limit // Ceiling division
+	}
+	if page.NextCursor != "" {
+		pagination["nextCursor"] = page.NextCursor
+	}
+
+	data, err := sparseCharacters(page.Characters, opts.Fields)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render characters"})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data": characters,
-		"pagination": gin.H{
-			"page":       page,
-			"limit":      limit,
-			"total":      total,
-			"totalPages": totalPages,
-			"hasNext":    hasNext,
-		},
+		"data":       data,
+		"pagination": pagination,
 	})
 } // UpdateCharacter handles PUT /api/characters/{id}
 // @Summary Update a character
 // @Description Update an existing character by ID
 // @Tags characters
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path string true "Character ID"
 // @Param character body models.Character true "Updated character data"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
 // @Success 200 {object} models.Character
 // @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/characters/{id} [put]
@@ -211,19 +394,8 @@ func (h *CharacterHandler) UpdateCharacter(c *gin.Context) {
 		return
 	}
 
-	// Validate character
-	if validationErrors := validation.ValidateCharacter(&character); len(validationErrors) > 0 {
-		c.JSON(http.StatusBadRequest, models.ValidationErrorResponse{Errors: validationErrors})
-		return
-	}
-
-	// Update character
-	if err := h.store.Update(idStr, &character); err != nil {
-		if err.Error() == "character not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Character not found"})
-		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update character: " + err.Error()})
-		}
+	if err := h.service.Update(c.Request.Context(), auth.CurrentPrincipal(c), idStr, &character); err != nil {
+		respondWithError(c, err)
 		return
 	}
 
@@ -238,10 +410,13 @@ func (h *CharacterHandler) UpdateCharacter(c *gin.Context) {
 // @Summary Delete a character
 // @Description Delete a character by ID
 // @Tags characters
+// @Security BearerAuth
 // @Produce json
 // @Param id path string true "Character ID"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/characters/{id} [delete]
@@ -252,14 +427,342 @@ func (h *CharacterHandler) DeleteCharacter(c *gin.Context) {
 		return
 	}
 
-	if err := h.store.Delete(idStr); err != nil {
-		if err.Error() == "character not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Character not found"})
+	if err := h.service.Delete(c.Request.Context(), auth.CurrentPrincipal(c), idStr); err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// BulkCharacters handles POST /api/characters/bulk
+// @Summary Create, update and delete characters in one batch
+// @Description Run up to 100 operations, each checked and validated before any is written, with a result per operation.
+// @Description With atomic set, either every operation is applied or none is. Otherwise the valid operations are applied and the rest reported.
+// @Tags characters
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param batch body models.BulkRequest true "Operations to run"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
+// @Success 200 {object} models.BulkResponse "Every operation was applied"
+// @Success 207 {object} models.BulkResponse "Some or all operations were not applied"
+// @Failure 400 {object} models.ValidationErrorResponse
+// @Router /api/characters/bulk [post]
+func (h *CharacterHandler) BulkCharacters(c *gin.Context) {
+	var request models.BulkRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+		return
+	}
+
+	results, err := h.service.Bulk(c.Request.Context(), auth.CurrentPrincipal(c), request.Operations, request.Atomic)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	response := models.BulkResponse{Success: true, Results: make([]models.BulkResult, len(results))}
+	for i, result := range results {
+		response.Results[i] = bulkResult(i, result)
+		if result.Applied {
+			response.Applied++
 		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete character: " + err.Error()})
+			response.Success = false
+		}
+	}
+
+	status := http.StatusOK
+	if !response.Success {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, response)
+}
+
+// bulkResult renders the outcome of one batch operation with the status it would have had alone
+func bulkResult(index int, result service.BulkResult) models.BulkResult {
+	rendered := models.BulkResult{Index: index, Op: result.Op, ID: result.ID, Data: result.Character}
+
+	var serviceErr *service.Error
+	switch {
+	case result.Applied && result.Op == "create":
+		rendered.Status = http.StatusCreated
+	case result.Applied && result.Op == "delete":
+		rendered.Status = http.StatusNoContent
+	case result.Applied:
+		rendered.Status = http.StatusOK
+	case errors.As(result.Err, &serviceErr):
+		rendered.Status = errorStatus(serviceErr)
+		rendered.Error = serviceErr.Error()
+		rendered.Errors = serviceErr.Validation
+	case result.Err != nil:
+		rendered.Status = http.StatusInternalServerError
+		rendered.Error = result.Err.Error()
+	default:
+		rendered.Status = http.StatusFailedDependency
+		rendered.Error = "Not applied because another operation in the atomic batch failed"
+	}
+	return rendered
+}
+
+// maxImportSize bounds import documents; exports with spell lists and inventories run to a few megabytes
+const maxImportSize = 10 << 20
+
+// ImportCharacter handles POST /api/characters/import
+// @Summary Import a character from another tool
+// @Description Map a character exported from Foundry VTT (dnd5e actor export) or an open 5e JSON character sheet.
+// @Description The response lists the parts of the document that have no place in a character, and warnings about values that could not be mapped or fail validation.
+// @Description A character with validation problems is not saved; with dryRun it is only mapped and checked.
+// @Tags characters
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param document body object true "Exported character JSON"
+// @Param format query string false "Format of the document, detected when omitted" enum(foundry,open5e)
+// @Param dryRun query bool false "Map and validate without saving (default: false)"
+// @Param campaignId query string false "Campaign to add the character to"
+// @Param partyId query string false "Party within the campaign"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
+// @Success 200 {object} models.ImportResponse "Dry run"
+// @Success 201 {object} models.ImportResponse
+// @Failure 400 {object} models.ImportResponse
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/import [post]
+func (h *CharacterHandler) ImportCharacter(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dryRun parameter (must be 'true' or 'false')"})
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read document: " + err.Error()})
+		return
+	}
+
+	result, err := formats.Import(data, c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	character := result.Character
+	character.CampaignID = c.Query("campaignId")
+	character.PartyID = c.Query("partyId")
+	response := models.ImportResponse{
+		Format:   result.Format,
+		DryRun:   dryRun,
+		Unmapped: result.Unmapped,
+		Warnings: result.Warnings,
+	}
+
+	if dryRun {
+		response.Warnings = append(response.Warnings, h.service.Validate(&character)...)
+		response.Data = character
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	if err := h.service.Create(c.Request.Context(), auth.CurrentPrincipal(c), &character); err != nil {
+		var serviceErr *service.Error
+		if !errors.As(err, &serviceErr) || serviceErr.Kind != service.KindInvalid {
+			respondWithError(c, err)
+			return
 		}
+		response.Warnings = append(response.Warnings, serviceErr.Validation...)
+		response.Data = character
+		c.JSON(http.StatusBadRequest, response)
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	response.Saved = true
+	response.Data = character
+	c.JSON(http.StatusCreated, response)
+}
+
+// ExportCharacter handles GET /api/characters/{id}/export
+// @Summary Export a character
+// @Description Download a character as a file for another tool: a Foundry VTT dnd5e actor (foundry) or an open 5e sheet (open5e). Hit points, hit dice and modifiers are worked out for the export; the secret backstory and DM notes are left out.
+// @Tags characters
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Character ID"
+// @Param format query string false "Format to export (foundry or open5e)" default(foundry)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/characters/{id}/export [get]
+func (h *CharacterHandler) ExportCharacter(c *gin.Context) {
+	character, err := h.service.Get(c.Request.Context(), auth.CurrentPrincipal(c), c.Param("id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	format := c.DefaultQuery("format", "foundry")
+	data, err := formats.Export(character, format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+formats.FileName(character, format)+`"`)
+	c.Data(http.StatusOK, "application/json", data)
+}
+
+// CharacterSheet handles GET /api/characters/{id}/sheet.pdf
+// @Summary Print a character sheet
+// @Description Render a printable, multi-page 5e character sheet as PDF, with modifiers, saving throws, hit points and spellcasting filled in and space to write in attacks, inventory and spells
+// @Tags characters
+// @Security BearerAuth
+// @Produce application/pdf
+// @Param id path string true "Character ID"
+// @Param layout query string false "Sheet layout (classic or compact)" default(classic)
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/characters/{id}/sheet.pdf [get]
+func (h *CharacterHandler) CharacterSheet(c *gin.Context) {
+	layout := c.DefaultQuery("layout", sheet.Layouts()[0])
+	if !slices.Contains(sheet.Layouts(), layout) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid layout parameter (must be one of " + strings.Join(sheet.Layouts(), ", ") + ")"})
+		return
+	}
+
+	character, err := h.service.Get(c.Request.Context(), auth.CurrentPrincipal(c), c.Param("id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	var pdf bytes.Buffer
+	if err := sheet.Render(&pdf, character, layout); err != nil {
+		h.logger.ErrorWithContext(c.Request.Context(), "Failed to render character sheet", err,
+			"character_id", character.ID,
+			"layout", layout)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render character sheet"})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="`+character.Slug()+`.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", pdf.Bytes())
+}
+
+// RenderCharacter handles GET /api/characters/{id}/render
+// @Summary Render a character stat block
+// @Description Render a character as a Markdown or HTML stat block for wikis and chat, using its campaign's template when the campaign has one
+// @Tags characters
+// @Security BearerAuth
+// @Produce text/markdown
+// @Produce text/html
+// @Param id path string true "Character ID"
+// @Param format query string false "Output format (markdown or html)" default(markdown)
+// @Success 200 {string} string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /api/characters/{id}/render [get]
+func (h *CharacterHandler) RenderCharacter(c *gin.Context) {
+	format, ok := parseRenderFormat(c)
+	if !ok {
+		return
+	}
+
+	character, err := h.service.Get(c.Request.Context(), auth.CurrentPrincipal(c), c.Param("id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	// Characters outside a campaign, or whose campaign is gone, use the default templates
+	var campaign *models.Campaign
+	if character.CampaignID != "" && h.campaigns != nil {
+		campaign, _ = h.campaigns.Get(character.CampaignID)
+	}
+
+	var out bytes.Buffer
+	if err := render.RenderCharacter(&out, character, campaign, format); err != nil {
+		respondWithRenderError(c, h.logger, err)
+		return
+	}
+	c.Data(http.StatusOK, render.ContentType(format), out.Bytes())
+}
+
+// parseRenderFormat reads the format query parameter of a rendering, writing an error response if it is invalid
+func parseRenderFormat(c *gin.Context) (string, bool) {
+	format := c.DefaultQuery("format", render.Markdown)
+	if !slices.Contains(render.Formats(), format) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format parameter (must be one of " + strings.Join(render.Formats(), ", ") + ")"})
+		return "", false
+	}
+	return format, true
+}
+
+// respondWithRenderError writes the response for a failed rendering. A
+// campaign's broken template is the campaign's to fix, not a server error.
+func respondWithRenderError(c *gin.Context, logger *logging.Logger, err error) {
+	var templateErr *render.TemplateError
+	switch {
+	case errors.As(err, &templateErr):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": templateErr.Error()})
+	default:
+		logger.ErrorWithContext(c.Request.Context(), "Failed to render template", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render"})
+	}
+}
+
+// RollCharacter handles POST /api/characters/{id}/roll
+// @Summary Roll an ability check
+// @Description Roll a d20 ability check for a character, adding the ability modifier
+// @Tags characters
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Character ID"
+// @Param roll body models.RollRequest true "Ability to roll"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
+// @Success 200 {object} models.RollResult
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/characters/{id}/roll [post]
+func (h *CharacterHandler) RollCharacter(c *gin.Context) {
+	character, err := h.service.Get(c.Request.Context(), auth.CurrentPrincipal(c), c.Param("id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	var request models.RollRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+		return
+	}
+
+	score, ok := character.AbilityScores.Get(request.Ability)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ability '" + request.Ability + "'"})
+		return
+	}
+
+	roll := rand.IntN(20) + 1
+	result := models.RollResult{
+		CharacterID:   character.ID,
+		CharacterName: character.CharacterName,
+		Ability:       request.Ability,
+		Roll:          roll,
+		Modifier:      score.Modifier(),
+		Total:         roll + score.Modifier(),
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    result,
+		"message": "Roll completed successfully",
+		"success": true,
+	})
 }
@@ -3,8 +3,10 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"player-character/internal/models"
@@ -318,9 +320,550 @@ func TestListCharacters(t *testing.T) {
 	if response.Pagination.Total != 2 {
 		t.Errorf("Expected total 2, got %d", response.Pagination.Total)
 	}
+
+	// Structured filters narrow the list
+	req, _ = http.NewRequest("GET", "/api/characters?filter="+url.QueryEscape("level>=2 AND class in (Wizard,Sorcerer)"), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	response = models.PaginationResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Errorf("Failed to unmarshal response: %v", err)
+	}
+	if response.Pagination.Total != 1 {
+		t.Errorf("Expected total 1 with filter, got %d", response.Pagination.Total)
+	}
+
+	// Invalid filters are rejected
+	req, _ = http.NewRequest("GET", "/api/characters?filter="+url.QueryEscape("level>=high"), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid filter, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+// TestListCharacters_Cursor tests walking a list with cursors and without counting
+func TestListCharacters_Cursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	handler := NewCharacterHandler(store, logger)
+	router := gin.New()
+	router.GET("/api/characters", handler.ListCharacters)
+
+	for i := 0; i < 5; i++ {
+		character := models.Character{
+			CharacterName: fmt.Sprintf("Character %d", i),
+			Race:          "Human",
+			Class:         "Fighter",
+			Level:         1,
+		}
+		if err := store.Create(&character); err != nil {
+			t.Fatalf("Failed to create character: %v", err)
+		}
+	}
+
+	seen := map[string]bool{}
+	query := "/api/characters?limit=2&sortBy=characterName&sortOrder=asc&count=false"
+	for requests := 0; requests < 5; requests++ {
+		req, _ := http.NewRequest("GET", query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var response struct {
+			Data       []models.Character     `json:"data"`
+			Pagination map[string]interface{} `json:"pagination"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if _, ok := response.Pagination["total"]; ok {
+			t.Error("Expected no total when count=false")
+		}
+		for _, c := range response.Data {
+			if seen[c.ID] {
+				t.Errorf("Character %s returned twice", c.CharacterName)
+			}
+			seen[c.ID] = true
+		}
+
+		cursor, _ := response.Pagination["nextCursor"].(string)
+		if response.Pagination["hasNext"] != (cursor != "") {
+			t.Errorf("Expected nextCursor exactly when hasNext, got %v", response.Pagination)
+		}
+		if cursor == "" {
+			break
+		}
+		query = "/api/characters?limit=2&sortBy=characterName&sortOrder=asc&count=false&cursor=" + url.QueryEscape(cursor)
+	}
+	if len(seen) != 5 {
+		t.Errorf("Expected to walk 5 characters, got %d", len(seen))
+	}
+
+	// Cursors only continue the sort they came from
+	req, _ := http.NewRequest("GET", "/api/characters?sortBy=level&cursor=bogus", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid cursor, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+// TestSearchCharacters tests full-text search with highlights
+func TestSearchCharacters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	handler := NewCharacterHandler(store, logger)
+	router := gin.New()
+	router.GET("/api/characters/search", handler.SearchCharacters)
+
+	for _, character := range []models.Character{
+		{CharacterName: "Wulfgar", Race: "Human", Class: "Barbarian", Level: 5, Backstory: "Raised by dwarves after a raid on Icewind Dale."},
+		{CharacterName: "Catti-brie", Race: "Human", Class: "Fighter", Level: 5, Notes: "Wields Taulmaril."},
+	} {
+		character := character
+		if err := store.Create(&character); err != nil {
+			t.Fatalf("Failed to create character: %v", err)
+		}
+	}
+
+	req, _ := http.NewRequest("GET", "/api/characters/search?q="+url.QueryEscape("icewind"), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response models.SearchResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Data) != 1 || response.Data[0].Character.CharacterName != "Wulfgar" {
+		t.Fatalf("Expected Wulfgar, got %+v", response.Data)
+	}
+	if len(response.Data[0].Highlights) != 1 || response.Data[0].Highlights[0].Field != "backstory" {
+		t.Errorf("Expected a backstory highlight, got %+v", response.Data[0].Highlights)
+	}
+
+	// A query is required
+	req, _ = http.NewRequest("GET", "/api/characters/search", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d without a query, got %d", http.StatusBadRequest, w.Code)
+	}
 }
 
 // TestUpdateCharacter_Success tests successful character update
+func TestCharacterFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	handler := NewCharacterHandler(store, logger)
+	router := gin.New()
+	router.GET("/api/characters", handler.ListCharacters)
+	router.GET("/api/characters/:id", handler.GetCharacter)
+
+	character := models.Character{
+		CharacterName: "Lyra",
+		PlayerName:    "Sam",
+		Race:          "Elf",
+		Class:         "Wizard",
+		Level:         4,
+	}
+	if err := store.Create(&character); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+
+	get := func(query string) (int, map[string]interface{}) {
+		req, _ := http.NewRequest("GET", query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		return w.Code, response
+	}
+	expectFields := func(name string, data interface{}) {
+		t.Helper()
+		fields, _ := data.(map[string]interface{})
+		if len(fields) != 2 || fields["characterName"] != "Lyra" || fields["level"] != float64(4) {
+			t.Errorf("%s: expected only characterName and level, got %v", name, data)
+		}
+	}
+
+	code, response := get("/api/characters?fields=characterName,level&sortBy=class")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %v", http.StatusOK, code, response)
+	}
+	data, _ := response["data"].([]interface{})
+	if len(data) != 1 {
+		t.Fatalf("Expected 1 character, got %v", response["data"])
+	}
+	expectFields("list", data[0])
+
+	code, response = get("/api/characters/" + character.ID + "?fields=level,characterName")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %v", http.StatusOK, code, response)
+	}
+	expectFields("get", response["data"])
+
+	for _, query := range []string{"/api/characters?fields=level,hitPoints", "/api/characters/" + character.ID + "?fields=nope"} {
+		if code, _ := get(query); code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d for an unknown field, got %d", query, http.StatusBadRequest, code)
+		}
+	}
+}
+
+func TestBulkCharacters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	handler := NewCharacterHandler(store, logger)
+	router := gin.New()
+	router.POST("/api/characters/bulk", handler.BulkCharacters)
+
+	newCharacter := func(name string, level int) *models.Character {
+		return &models.Character{
+			CharacterName: name,
+			Race:          "Human",
+			Class:         "Fighter",
+			Level:         level,
+			AbilityScores: models.AbilityScores{
+				Strength:     models.AbilityScore{Base: 15},
+				Dexterity:    models.AbilityScore{Base: 14},
+				Constitution: models.AbilityScore{Base: 13},
+				Intelligence: models.AbilityScore{Base: 12},
+				Wisdom:       models.AbilityScore{Base: 10},
+				Charisma:     models.AbilityScore{Base: 8},
+			},
+		}
+	}
+	existing := newCharacter("Existing", 2)
+	if err := store.Create(existing); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+
+	send := func(request models.BulkRequest) (int, models.BulkResponse) {
+		jsonData, _ := json.Marshal(request)
+		req, _ := http.NewRequest("POST", "/api/characters/bulk", bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response models.BulkResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		return w.Code, response
+	}
+	statuses := func(response models.BulkResponse) []int {
+		var result []int
+		for _, r := range response.Results {
+			result = append(result, r.Status)
+		}
+		return result
+	}
+	count := func() int {
+		_, total, _ := store.List(database.ListOptions{Page: 1, Limit: 100})
+		return total
+	}
+
+	// An atomic batch with an invalid operation writes nothing
+	code, response := send(models.BulkRequest{Atomic: true, Operations: []models.BulkOperation{
+		{Op: "create", Character: newCharacter("Valid", 1)},
+		{Op: "create", Character: newCharacter("Invalid", 0)},
+		{Op: "delete", ID: existing.ID},
+	}})
+	if code != http.StatusMultiStatus || response.Success || response.Applied != 0 {
+		t.Fatalf("Expected an unapplied batch, got %d %+v", code, response)
+	}
+	if got := fmt.Sprint(statuses(response)); got != "[424 400 424]" {
+		t.Errorf("Expected statuses [424 400 424], got %s", got)
+	}
+	if len(response.Results[1].Errors) == 0 {
+		t.Error("Expected validation errors for the invalid operation")
+	}
+	if count() != 1 {
+		t.Errorf("Expected the store to be unchanged, got %d characters", count())
+	}
+
+	// Otherwise the valid operations are applied and the rest reported
+	code, response = send(models.BulkRequest{Operations: []models.BulkOperation{
+		{Op: "create", Character: newCharacter("Valid", 1)},
+		{Op: "delete", ID: "missing"},
+		{Op: "update", ID: existing.ID, Character: newCharacter("Updated", 3)},
+		{Op: "delete", ID: existing.ID},
+		{Op: "rename"},
+	}})
+	if code != http.StatusMultiStatus || response.Applied != 2 {
+		t.Fatalf("Expected 2 applied operations, got %d %+v", code, response)
+	}
+	if got := fmt.Sprint(statuses(response)); got != "[201 404 200 400 400]" {
+		t.Errorf("Expected statuses [201 404 200 400 400], got %s", got)
+	}
+	if response.Results[0].ID == "" || response.Results[2].Data == nil || response.Results[2].Data.CharacterName != "Updated" {
+		t.Errorf("Expected applied operations to return their characters, got %+v", response.Results)
+	}
+
+	// A fully applied batch succeeds
+	code, response = send(models.BulkRequest{Atomic: true, Operations: []models.BulkOperation{
+		{Op: "delete", ID: existing.ID},
+		{Op: "delete", ID: response.Results[0].ID},
+	}})
+	if code != http.StatusOK || !response.Success || response.Applied != 2 {
+		t.Fatalf("Expected a successful batch, got %d %+v", code, response)
+	}
+	if count() != 0 {
+		t.Errorf("Expected every character deleted, got %d", count())
+	}
+
+	if code, _ := send(models.BulkRequest{Operations: []models.BulkOperation{}}); code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an empty batch, got %d", http.StatusBadRequest, code)
+	}
+}
+
+func TestImportCharacter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	handler := NewCharacterHandler(store, logger)
+	router := gin.New()
+	router.POST("/api/characters/import", handler.ImportCharacter)
+
+	send := func(query, body string) (int, models.ImportResponse) {
+		req, _ := http.NewRequest("POST", "/api/characters/import"+query, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response models.ImportResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response
+	}
+	count := func() int {
+		_, total, _ := store.List(database.ListOptions{Page: 1, Limit: 100})
+		return total
+	}
+
+	sheet := `{
+		"name": "Pip", "race": "Lightfoot Halfling", "class": "Rogue", "level": 3, "alignment": "CN",
+		"ability_scores": {"str": 8, "dex": 16, "con": 12, "int": 13, "wis": 10, "cha": 14},
+		"hit_points": 21
+	}`
+
+	// A dry run reports the mapping without saving
+	code, response := send("?dryRun=true", sheet)
+	if code != http.StatusOK || response.Saved || !response.DryRun {
+		t.Fatalf("Expected an unsaved dry run, got %d %+v", code, response)
+	}
+	if response.Format != "open5e" || response.Data.Race != "Halfling" || response.Data.Alignment != "Chaotic Neutral" {
+		t.Errorf("Expected the sheet to be mapped, got %+v", response)
+	}
+	if fmt.Sprint(response.Unmapped) != "[hit_points]" {
+		t.Errorf("Expected hit_points to be unmapped, got %v", response.Unmapped)
+	}
+	if count() != 0 {
+		t.Errorf("Expected nothing saved, got %d characters", count())
+	}
+
+	// A dry run of an invalid character returns its validation errors as warnings
+	code, response = send("?dryRun=true", `{"name": "Pip", "race": "Halfling", "class": "Rogue", "level": 0}`)
+	if code != http.StatusOK || len(response.Warnings) == 0 {
+		t.Errorf("Expected warnings for an invalid character, got %d %+v", code, response)
+	}
+
+	// An import saves the character
+	code, response = send("?campaignId=camp-1", sheet)
+	if code != http.StatusCreated || !response.Saved || response.Data.ID == "" || response.Data.CampaignID != "camp-1" {
+		t.Fatalf("Expected a saved character, got %d %+v", code, response)
+	}
+	if _, err := store.Get(response.Data.ID); err != nil {
+		t.Errorf("Expected the character to be stored: %v", err)
+	}
+
+	// An invalid character is not saved
+	code, response = send("", `{"name": "Pip", "race": "Halfling", "class": "Rogue", "level": 0}`)
+	if code != http.StatusBadRequest || response.Saved || len(response.Warnings) == 0 {
+		t.Errorf("Expected a rejected import, got %d %+v", code, response)
+	}
+
+	// Unrecognized documents and formats are rejected
+	for _, tt := range []struct{ query, body string }{
+		{"", `{"hello": "world"}`},
+		{"?format=fightclub", sheet},
+		{"", `not json`},
+	} {
+		if code, _ := send(tt.query, tt.body); code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s %s, got %d", tt.query, tt.body, code)
+		}
+	}
+	if count() != 1 {
+		t.Errorf("Expected one character saved, got %d", count())
+	}
+}
+
+func TestExportCharacter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	handler := NewCharacterHandler(store, logger)
+	router := gin.New()
+	router.GET("/api/characters/:id/export", handler.ExportCharacter)
+	router.POST("/api/characters/import", handler.ImportCharacter)
+
+	character := &models.Character{
+		CharacterName:   "Lyra Moonwhisper",
+		Race:            "Elf",
+		Subrace:         "High Elf",
+		Class:           "Wizard",
+		Level:           5,
+		SecretBackstory: "Is a spy.",
+		AbilityScores: models.AbilityScores{
+			Strength:     models.AbilityScore{Base: 8},
+			Dexterity:    models.AbilityScore{Base: 14},
+			Constitution: models.AbilityScore{Base: 13},
+			Intelligence: models.AbilityScore{Base: 17},
+			Wisdom:       models.AbilityScore{Base: 12},
+			Charisma:     models.AbilityScore{Base: 10},
+		},
+	}
+	if err := store.Create(character); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+
+	for _, format := range []string{"foundry", "open5e"} {
+		req, _ := http.NewRequest("GET", "/api/characters/"+character.ID+"/export?format="+format, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 for %s, got %d: %s", format, w.Code, w.Body.String())
+		}
+		if expected := `attachment; filename="lyra-moonwhisper.` + format + `.json"`; w.Header().Get("Content-Disposition") != expected {
+			t.Errorf("Expected Content-Disposition %s, got %s", expected, w.Header().Get("Content-Disposition"))
+		}
+		if bytes.Contains(w.Body.Bytes(), []byte("Is a spy.")) {
+			t.Errorf("Expected the %s export to leave out the secret backstory", format)
+		}
+
+		// The file imports back as the same character
+		req, _ = http.NewRequest("POST", "/api/characters/import?dryRun=true", bytes.NewBuffer(w.Body.Bytes()))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response models.ImportResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		if w.Code != http.StatusOK || response.Format != format {
+			t.Fatalf("Expected the %s export to import, got %d %s", format, w.Code, w.Body.String())
+		}
+		if response.Data.CharacterName != character.CharacterName || response.Data.Subrace != "High Elf" || response.Data.AbilityScores != character.AbilityScores {
+			t.Errorf("Expected the %s export to round-trip, got %+v", format, response.Data)
+		}
+	}
+
+	for path, expected := range map[string]int{
+		"/api/characters/" + character.ID + "/export?format=fightclub": http.StatusBadRequest,
+		"/api/characters/missing/export":                               http.StatusNotFound,
+	} {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != expected {
+			t.Errorf("Expected status %d for %s, got %d", expected, path, w.Code)
+		}
+	}
+}
+
+func TestCharacterSheet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	handler := NewCharacterHandler(store, logger)
+	router := gin.New()
+	router.GET("/api/characters/:id", handler.GetCharacter)
+	router.GET("/api/characters/:id/sheet.pdf", handler.CharacterSheet)
+
+	character := &models.Character{
+		CharacterName: "Lyra Moonwhisper",
+		Race:          "Elf",
+		Class:         "Wizard",
+		Level:         5,
+		AbilityScores: models.AbilityScores{
+			Strength:     models.AbilityScore{Base: 8},
+			Dexterity:    models.AbilityScore{Base: 14},
+			Constitution: models.AbilityScore{Base: 13},
+			Intelligence: models.AbilityScore{Base: 17},
+			Wisdom:       models.AbilityScore{Base: 12},
+			Charisma:     models.AbilityScore{Base: 10},
+		},
+	}
+	if err := store.Create(character); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+
+	for _, query := range []string{"", "?layout=classic", "?layout=compact"} {
+		req, _ := http.NewRequest("GET", "/api/characters/"+character.ID+"/sheet.pdf"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 for %q, got %d: %s", query, w.Code, w.Body.String())
+		}
+		if w.Header().Get("Content-Type") != "application/pdf" || !bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-")) {
+			t.Errorf("Expected a PDF for %q, got %s", query, w.Header().Get("Content-Type"))
+		}
+		if expected := `inline; filename="lyra-moonwhisper.pdf"`; w.Header().Get("Content-Disposition") != expected {
+			t.Errorf("Expected Content-Disposition %s, got %s", expected, w.Header().Get("Content-Disposition"))
+		}
+	}
+
+	for path, expected := range map[string]int{
+		"/api/characters/" + character.ID + "/sheet.pdf?layout=fancy": http.StatusBadRequest,
+		"/api/characters/missing/sheet.pdf":                           http.StatusNotFound,
+	} {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != expected {
+			t.Errorf("Expected status %d for %s, got %d", expected, path, w.Code)
+		}
+	}
+}
+
 func TestUpdateCharacter_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	store := database.NewMemoryStore()
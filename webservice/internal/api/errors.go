@@ -0,0 +1,40 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"player-character/internal/models"
+	"player-character/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondWithError writes the HTTP response for an error returned by a service
+func respondWithError(c *gin.Context, err error) {
+	var serviceErr *service.Error
+	if !errors.As(err, &serviceErr) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if serviceErr.Kind == service.KindInvalid {
+		c.JSON(http.StatusBadRequest, models.ValidationErrorResponse{Errors: serviceErr.Validation})
+		return
+	}
+	c.JSON(errorStatus(serviceErr), gin.H{"error": serviceErr.Error()})
+}
+
+// errorStatus returns the HTTP status for a service error
+func errorStatus(err *service.Error) int {
+	switch err.Kind {
+	case service.KindInvalid:
+		return http.StatusBadRequest
+	case service.KindForbidden:
+		return http.StatusForbidden
+	case service.KindNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"player-character/internal/auth"
+	"player-character/internal/authz"
+	"player-character/internal/events"
+	"player-character/internal/service"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// keepAliveInterval is how often an idle event stream sends a comment to keep proxies from closing it
+const keepAliveInterval = 15 * time.Second
+
+// EventHandler streams character change events to clients with Server-Sent Events
+type EventHandler struct {
+	broker     events.Broker
+	characters *service.Characters
+	campaigns  *service.Campaigns
+	logger     *logging.Logger
+}
+
+// NewEventHandler creates a new event handler. A nil policy disables access checks.
+func NewEventHandler(broker events.Broker, characters database.CharacterStore, campaigns database.CampaignStore, policy *authz.Policy, logger *logging.Logger) *EventHandler {
+	return &EventHandler{
+		broker:     broker,
+		characters: service.NewCharacters(characters, campaigns, policy, nil, logger),
+		campaigns:  service.NewCampaigns(campaigns, policy, logger),
+		logger:     logger,
+	}
+}
+
+// StreamCharacterEvents handles GET /api/characters/{id}/events
+// @Summary Stream character changes
+// @Description Server-Sent Events stream of created, updated and deleted events for one character
+// @Tags events
+// @Security BearerAuth
+// @Produce text/event-stream
+// @Param id path string true "Character ID"
+// @Success 200 {object} events.Event
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/characters/{id}/events [get]
+func (h *EventHandler) StreamCharacterEvents(c *gin.Context) {
+	character, err := h.characters.Get(c.Request.Context(), auth.CurrentPrincipal(c), c.Param("id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	h.stream(c, events.Filter{CharacterID: character.ID})
+}
+
+// StreamCampaignEvents handles GET /api/campaigns/{id}/events
+// @Summary Stream campaign changes
+// @Description Server-Sent Events stream of changes to every character in a campaign that the caller may read
+// @Tags events
+// @Security BearerAuth
+// @Produce text/event-stream
+// @Param id path string true "Campaign ID"
+// @Success 200 {object} events.Event
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/campaigns/{id}/events [get]
+func (h *EventHandler) StreamCampaignEvents(c *gin.Context) {
+	campaign, err := h.campaigns.Get(c.Request.Context(), auth.CurrentPrincipal(c), c.Param("id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	h.stream(c, events.Filter{CampaignID: campaign.ID})
+}
+
+// stream subscribes to matching events and writes them as SSE until the client disconnects.
+// Each event is checked against the caller's current access and redacted before sending.
+func (h *EventHandler) stream(c *gin.Context, filter events.Filter) {
+	ctx := c.Request.Context()
+	subscription, err := h.broker.Subscribe(ctx, filter)
+	if err != nil {
+		h.logger.ErrorWithContext(ctx, "Failed to subscribe to events", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to subscribe to events"})
+		return
+	}
+
+	principal := auth.CurrentPrincipal(c)
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-keepAlive.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			return err == nil
+		case event, ok := <-subscription:
+			if !ok {
+				return false
+			}
+			event, visible := h.characters.VisibleEvent(principal, event)
+			if !visible {
+				return true
+			}
+			c.SSEvent(event.Type, event)
+			return true
+		}
+	})
+}
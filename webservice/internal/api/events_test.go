@@ -0,0 +1,100 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"player-character/internal/events"
+	"player-character/internal/models"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestStreamCharacterEvents tests that updates made through the API are pushed to SSE subscribers
+func TestStreamCharacterEvents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	campaignStore := database.NewMemoryCampaignStore()
+	broker := events.NewMemoryBroker()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	characterHandler := NewCharacterHandler(store, logger, WithEvents(broker))
+	eventHandler := NewEventHandler(broker, store, campaignStore, nil, logger)
+
+	router := gin.New()
+	router.PUT("/api/characters/:id", characterHandler.UpdateCharacter)
+	router.GET("/api/characters/:id/events", eventHandler.StreamCharacterEvents)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	character := models.Character{
+		CharacterName: "Streamed",
+		Race:          "Dwarf",
+		Class:         "Cleric",
+		Level:         3,
+		AbilityScores: models.AbilityScores{
+			Strength:     models.AbilityScore{Base: 14},
+			Dexterity:    models.AbilityScore{Base: 10},
+			Constitution: models.AbilityScore{Base: 15},
+			Intelligence: models.AbilityScore{Base: 8},
+			Wisdom:       models.AbilityScore{Base: 16},
+			Charisma:     models.AbilityScore{Base: 12},
+		},
+	}
+	if err := store.Create(&character); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+
+	resp, err := http.Get(server.URL + "/api/characters/" + character.ID + "/events")
+	if err != nil {
+		t.Fatalf("Failed to open event stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Fatalf("Expected an event stream, got %q", ct)
+	}
+
+	// The subscription is registered before the response headers are flushed
+	character.Level = 4
+	jsonData, _ := json.Marshal(character)
+	req, _ := http.NewRequest("PUT", server.URL+"/api/characters/"+character.ID, bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	if _, err := http.DefaultClient.Do(req); err != nil {
+		t.Fatalf("Failed to update character: %v", err)
+	}
+
+	received := make(chan events.Event, 1)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if data, ok := strings.CutPrefix(scanner.Text(), "data:"); ok {
+				var event events.Event
+				if json.Unmarshal([]byte(data), &event) == nil {
+					received <- event
+					return
+				}
+			}
+		}
+	}()
+
+	select {
+	case event := <-received:
+		if event.Type != events.CharacterUpdated || event.Character == nil || event.Character.Level != 4 {
+			t.Errorf("Unexpected event: %+v", event)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Timed out waiting for the update event")
+	}
+}
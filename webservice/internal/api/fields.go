@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+
+	"player-character/internal/models"
+)
+
+// sparseCharacter renders a character with only the requested fields. With no
+// fields it renders the whole character. Fields the store loaded for its own
+// use, such as the owner for redaction, are dropped here.
+func sparseCharacter(character *models.Character, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return character, nil
+	}
+
+	data, err := json.Marshal(character)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	// Fields left out by omitempty stay absent, as in a full response
+	sparse := make(map[string]json.RawMessage, len(fields))
+	for _, name := range fields {
+		if value, ok := all[name]; ok {
+			sparse[name] = value
+		}
+	}
+	return sparse, nil
+}
+
+// sparseCharacters renders each character with only the requested fields
+func sparseCharacters(characters []models.Character, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return characters, nil
+	}
+
+	result := make([]interface{}, len(characters))
+	for i := range characters {
+		sparse, err := sparseCharacter(&characters[i], fields)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = sparse
+	}
+	return result, nil
+}
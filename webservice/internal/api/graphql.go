@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+
+	"player-character/internal/auth"
+	"player-character/internal/graph"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQLHandler serves the GraphQL endpoint
+type GraphQLHandler struct {
+	schema *graph.Schema
+	logger *logging.Logger
+}
+
+// NewGraphQLHandler creates a new GraphQL handler
+func NewGraphQLHandler(schema *graph.Schema, logger *logging.Logger) *GraphQLHandler {
+	return &GraphQLHandler{
+		schema: schema,
+		logger: logger,
+	}
+}
+
+// Query handles POST /graphql
+// @Summary GraphQL endpoint
+// @Description Run a GraphQL query or mutation over characters and campaigns. Select only the fields you need; related campaigns, parties and characters are loaded in batches. Errors are reported in the response's errors array with a code extension.
+// @Tags graphql
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body graph.Request true "GraphQL query, operation name and variables"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Router /graphql [post]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var request graph.Request
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+		return
+	}
+	if request.Query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query is required"})
+		return
+	}
+
+	result := h.schema.Execute(c.Request.Context(), auth.CurrentPrincipal(c), request)
+	if result.HasErrors() {
+		h.logger.Debug("GraphQL request returned errors",
+			"operation", request.OperationName,
+			"errors", result.Errors)
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CharacterHistoryResponse is a character's events up to a point in time,
+// and the character as it then stood
+type CharacterHistoryResponse struct {
+	Events    []database.CharacterEvent `json:"events"`
+	Character *models.Character         `json:"character"` // null if the character did not exist then
+}
+
+// HistoryHandler serves the change history kept by the event-sourced store
+type HistoryHandler struct {
+	history *database.EventSourcedStore
+	logger  *logging.Logger
+}
+
+// NewHistoryHandler creates a new history handler. A nil store reports that no history is kept.
+func NewHistoryHandler(history *database.EventSourcedStore, logger *logging.Logger) *HistoryHandler {
+	return &HistoryHandler{
+		history: history,
+		logger:  logger,
+	}
+}
+
+// GetCharacterHistory handles GET /api/admin/characters/{id}/history
+// @Summary Get a character's change history
+// @Description Every recorded change to a character, including deleted characters, and the character replayed to the time given by at (default now). Only kept with STORE=events. Admins only.
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Character ID"
+// @Param at query string false "Replay to this time (RFC 3339)"
+// @Success 200 {object} CharacterHistoryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/characters/{id}/history [get]
+func (h *HistoryHandler) GetCharacterHistory(c *gin.Context) {
+	if h.history == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Character history is only kept with STORE=events"})
+		return
+	}
+
+	at := time.Now()
+	if value := c.Query("at"); value != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "at must be an RFC 3339 time"})
+			return
+		}
+		at = parsed
+	}
+
+	id := c.Param("id")
+	events, err := h.history.History(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Character not found"})
+		return
+	}
+	response := CharacterHistoryResponse{Events: []database.CharacterEvent{}}
+	for _, event := range events {
+		if event.Timestamp.After(at) {
+			break
+		}
+		response.Events = append(response.Events, event)
+	}
+
+	// A character not existing at the time is not an error; its history says why
+	if character, err := h.history.CharacterAt(id, at); err == nil {
+		response.Character = character
+	}
+	c.JSON(http.StatusOK, response)
+}
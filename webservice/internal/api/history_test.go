@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetCharacterHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := logging.NewLogger(logging.Config{Level: "error", Format: "json", Output: "console"})
+
+	get := func(handler *HistoryHandler, path string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.GET("/api/admin/characters/:id/history", handler.GetCharacterHistory)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := get(NewHistoryHandler(nil, logger), "/api/admin/characters/any/history"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 without an event-sourced store, got %d", w.Code)
+	}
+
+	db, err := database.OpenBolt(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	store, err := database.NewEventSourcedStore(db, database.DefaultSnapshotInterval)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := NewHistoryHandler(store, logger)
+
+	character := &models.Character{CharacterName: "Thorin", Race: "Dwarf", Class: "Fighter", Level: 3}
+	if err := store.Create(character); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	created := time.Now()
+	time.Sleep(2 * time.Millisecond)
+	update := *character
+	update.Level = 4
+	if err := store.Update(character.ID, &update); err != nil {
+		t.Fatal(err)
+	}
+
+	decode := func(w *httptest.ResponseRecorder) CharacterHistoryResponse {
+		t.Helper()
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response CharacterHistoryResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	path := "/api/admin/characters/" + character.ID + "/history"
+	response := decode(get(handler, path))
+	if len(response.Events) != 2 || response.Events[1].Type != database.LevelGained || response.Character == nil || response.Character.Level != 4 {
+		t.Errorf("Expected the whole history and the current character, got %+v", response)
+	}
+
+	response = decode(get(handler, path+"?at="+url.QueryEscape(created.Format(time.RFC3339Nano))))
+	if len(response.Events) != 1 || response.Character == nil || response.Character.Level != 3 {
+		t.Errorf("Expected the creation and the character as created, got %+v", response)
+	}
+
+	response = decode(get(handler, path+"?at=2000-01-01T00:00:00Z"))
+	if len(response.Events) != 0 || response.Character != nil {
+		t.Errorf("Expected nothing before the character was created, got %+v", response)
+	}
+
+	if w := get(handler, path+"?at=yesterday"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid time, got %d", w.Code)
+	}
+	if w := get(handler, "/api/admin/characters/missing/history"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for an unknown character, got %d", w.Code)
+	}
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"player-character/internal/auth"
+	"player-character/internal/models"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client's key for a mutating request
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed from an earlier request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLength bounds the keys clients may send
+	maxIdempotencyKeyLength = 255
+)
+
+// maxIdempotentBodySize bounds the request bodies read to fingerprint them,
+// unless WithBodyLimit sets another bound for the route
+var maxIdempotentBodySize int64 = 10 << 20
+
+// IdempotencyOption configures the Idempotency middleware
+type IdempotencyOption func(*idempotency)
+
+// idempotency holds the Idempotency middleware's options
+type idempotency struct {
+	bodyLimits map[string]int64 // by route path
+}
+
+// WithBodyLimit fingerprints bodies of up to limit bytes for the route with
+// the given path, as registered with gin, instead of the 10 MiB default.
+// Bodies are held in memory while they are fingerprinted, so raise it only
+// for routes that must take larger bodies, and that only admins can use.
+func WithBodyLimit(route string, limit int64) IdempotencyOption {
+	return func(i *idempotency) {
+		i.bodyLimits[route] = limit
+	}
+}
+
+// Idempotency makes POST, PUT, PATCH and DELETE requests safe to retry when
+// they carry an Idempotency-Key header. The first response for a key is kept
+// for ttl and replayed for retries with the same method, URI and body; a key
+// reused for a different request is rejected with 422, and one whose request
+// is still running with 409. Server errors are not kept, so they can be
+// retried. Keys are scoped to the caller, so it must run after authentication.
+func Idempotency(store database.IdempotencyStore, ttl time.Duration, logger *logging.Logger, opts ...IdempotencyOption) gin.HandlerFunc {
+	config := &idempotency{bodyLimits: make(map[string]int64)}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || !isMutating(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must be at most 255 characters"})
+			return
+		}
+
+		limit, ok := config.bodyLimits[c.FullPath()]
+		if !ok {
+			limit = maxIdempotentBodySize
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, limit))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Request body is too large"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := time.Now()
+		record := &models.IdempotencyRecord{
+			Key:         idempotencyScope(auth.CurrentPrincipal(c)) + ":" + key,
+			Fingerprint: requestFingerprint(c.Request.Method, c.Request.URL.RequestURI(), body),
+			ExpiresAt:   now.Add(ttl),
+		}
+
+		existing, err := store.Reserve(record)
+		if err != nil {
+			logger.ErrorWithContext(c.Request.Context(), "Failed to reserve idempotency key", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check Idempotency-Key"})
+			return
+		}
+		if existing != nil {
+			switch {
+			case existing.Fingerprint != record.Fingerprint:
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used for a different request"})
+			case !existing.Completed:
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is still in progress"})
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(existing.Status, existing.ContentType, existing.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		// Free the key if a handler panics, rather than leaving it in progress until it expires
+		stored := false
+		defer func() {
+			if !stored {
+				store.Release(record.Key)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			err = store.Release(record.Key)
+		} else {
+			err = store.Complete(record.Key, status, recorder.Header().Get("Content-Type"), recorder.body.Bytes())
+		}
+		stored = true
+		if err != nil {
+			logger.ErrorWithContext(c.Request.Context(), "Failed to store idempotent response", err)
+		}
+	}
+}
+
+// isMutating reports whether requests with the method may be made idempotent
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// idempotencyScope keeps callers from replaying each other's responses
+func idempotencyScope(principal *auth.Principal) string {
+	switch {
+	case principal == nil:
+		return "anonymous"
+	case principal.APIKeyID != "":
+		return "key:" + principal.APIKeyID
+	default:
+		return "user:" + principal.UserID
+	}
+}
+
+// requestFingerprint identifies a request by method, URI and body
+func requestFingerprint(method, uri string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + uri + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body as it is written
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
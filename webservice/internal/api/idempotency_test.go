@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestIdempotency tests replay, mismatch and in-progress handling of Idempotency-Key
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	keys := database.NewMemoryIdempotencyStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	handler := NewCharacterHandler(store, logger)
+
+	failures := 1
+	router := gin.New()
+	router.Use(asUser(), Idempotency(keys, time.Hour, logger, WithBodyLimit("/api/uploads/:kind", 1<<20)))
+	router.POST("/api/characters", handler.CreateCharacter)
+	router.POST("/api/uploads/:kind", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	router.POST("/api/flaky", func(c *gin.Context) {
+		if failures > 0 {
+			failures--
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "try again"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	character := models.Character{
+		CharacterName: "Retried",
+		Race:          "Human",
+		Class:         "Fighter",
+		Level:         1,
+		AbilityScores: models.AbilityScores{
+			Strength:     models.AbilityScore{Base: 15},
+			Dexterity:    models.AbilityScore{Base: 14},
+			Constitution: models.AbilityScore{Base: 13},
+			Intelligence: models.AbilityScore{Base: 12},
+			Wisdom:       models.AbilityScore{Base: 10},
+			Charisma:     models.AbilityScore{Base: 8},
+		},
+	}
+	body, _ := json.Marshal(character)
+
+	post := func(path, userID, key string, body []byte) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-User", userID)
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	count := func() int {
+		_, total, _ := store.List(database.ListOptions{Page: 1, Limit: 100})
+		return total
+	}
+
+	// A retry replays the first response instead of creating again
+	first := post("/api/characters", "alice", "create-1", body)
+	retry := post("/api/characters", "alice", "create-1", body)
+	if first.Code != http.StatusCreated || retry.Code != http.StatusCreated {
+		t.Fatalf("Expected both responses to be %d, got %d and %d", http.StatusCreated, first.Code, retry.Code)
+	}
+	if retry.Body.String() != first.Body.String() || retry.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("Expected the first response to be replayed, got %s", retry.Body.String())
+	}
+	if first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Error("Expected the first response not to be marked as replayed")
+	}
+	if count() != 1 {
+		t.Errorf("Expected 1 character, got %d", count())
+	}
+
+	// Keys are per caller, and requests without one are not deduplicated
+	if w := post("/api/characters", "bob", "create-1", body); w.Code != http.StatusCreated || w.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Errorf("Expected another caller's key to be independent, got %d", w.Code)
+	}
+	post("/api/characters", "alice", "", body)
+	if count() != 3 {
+		t.Errorf("Expected 3 characters, got %d", count())
+	}
+
+	// Reusing a key for a different request is rejected
+	character.CharacterName = "Different"
+	different, _ := json.Marshal(character)
+	if w := post("/api/characters", "alice", "create-1", different); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d for a reused key, got %d", http.StatusUnprocessableEntity, w.Code)
+	}
+
+	// A key whose request has not finished cannot be used yet
+	keys.Reserve(&models.IdempotencyRecord{
+		Key:         "user:alice:pending",
+		Fingerprint: requestFingerprint("POST", "/api/characters", body),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	if w := post("/api/characters", "alice", "pending", body); w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d for a key in progress, got %d", http.StatusConflict, w.Code)
+	}
+
+	// Server errors are not kept, so the retry runs again
+	if w := post("/api/flaky", "alice", "flaky-1", nil); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+	if w := post("/api/flaky", "alice", "flaky-1", nil); w.Code != http.StatusOK {
+		t.Errorf("Expected the retry to succeed, got %d", w.Code)
+	}
+
+	// Bodies are read up to the default limit, unless the route has its own
+	defer func(size int64) { maxIdempotentBodySize = size }(maxIdempotentBodySize)
+	maxIdempotentBodySize = int64(len(body)) - 1
+	if w := post("/api/characters", "alice", "too-large", body); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status %d for a body over the limit, got %d", http.StatusRequestEntityTooLarge, w.Code)
+	}
+	if w := post("/api/uploads/archive", "alice", "large", body); w.Code != http.StatusOK {
+		t.Errorf("Expected the route's own limit to allow the body, got %d", w.Code)
+	}
+	if w := post("/api/uploads/archive", "alice", "larger", make([]byte, 1<<20+1)); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status %d for a body over the route's limit, got %d", http.StatusRequestEntityTooLarge, w.Code)
+	}
+	if w := post("/api/characters", "alice", "", body); w.Code != http.StatusCreated {
+		t.Errorf("Expected requests without a key not to be limited here, got %d", w.Code)
+	}
+}
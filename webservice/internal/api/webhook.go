@@ -0,0 +1,297 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"player-character/internal/auth"
+	"player-character/internal/authz"
+	"player-character/internal/models"
+	"player-character/internal/validation"
+	"player-character/internal/webhooks"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler handles webhook registration and the delivery log
+type WebhookHandler struct {
+	store      database.WebhookStore
+	dispatcher *webhooks.Dispatcher
+	campaigns  database.CampaignStore
+	policy     *authz.Policy
+	logger     *logging.Logger
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(store database.WebhookStore, dispatcher *webhooks.Dispatcher, campaigns database.CampaignStore, policy *authz.Policy, logger *logging.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		store:      store,
+		dispatcher: dispatcher,
+		campaigns:  campaigns,
+		policy:     policy,
+		logger:     logger,
+	}
+}
+
+// getOwned loads the webhook named by the :id parameter, writing a 404 when
+// it does not exist or belongs to someone else
+func (h *WebhookHandler) getOwned(c *gin.Context, principal *auth.Principal) (*models.Webhook, bool) {
+	// Other users' webhooks are reported as missing rather than forbidden
+	webhook, err := h.store.Get(c.Param("id"))
+	if err != nil || (webhook.OwnerID != principal.UserID && principal.Role != models.RoleAdmin) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+		return nil, false
+	}
+	return webhook, true
+}
+
+// CreateWebhook handles POST /api/webhooks
+// @Summary Register a webhook
+// @Description Register an endpoint that receives signed JSON payloads for character events in a campaign, or for every campaign when campaignId is omitted (admins only). The signing secret is only shown once.
+// @Tags webhooks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param webhook body models.CreateWebhookRequest true "Endpoint URL, campaign and events"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
+// @Success 201 {object} models.CreateWebhookResponse
+// @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	principal := auth.CurrentPrincipal(c)
+	if principal == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var request models.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+		return
+	}
+
+	if validationErrors := validation.ValidateWebhookRequest(&request, h.dispatcher.AllowsPrivateAddresses()); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.ValidationErrorResponse{Errors: validationErrors})
+		return
+	}
+
+	// Campaign webhooks need the right to manage the campaign; global ones are for admins
+	if request.CampaignID == "" {
+		if principal.Role != models.RoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only admins may register global webhooks"})
+			return
+		}
+	} else {
+		campaign, err := h.campaigns.Get(request.CampaignID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ValidationErrorResponse{Errors: []models.ValidationError{{
+				Field:   "campaignId",
+				Message: "Campaign '" + request.CampaignID + "' does not exist",
+				Code:    "INVALID_CAMPAIGN",
+			}}})
+			return
+		}
+		if h.policy != nil && !h.policy.CampaignAccess(principal, campaign).Manage {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not allowed to manage this campaign"})
+			return
+		}
+	}
+
+	secret := request.Secret
+	if secret == "" {
+		generated, err := webhooks.GenerateSecret()
+		if err != nil {
+			h.logger.ErrorWithContext(c.Request.Context(), "Failed to generate webhook secret", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate webhook secret"})
+			return
+		}
+		secret = generated
+	}
+
+	webhook := models.Webhook{
+		URL:        request.URL,
+		Secret:     secret,
+		OwnerID:    principal.UserID,
+		CampaignID: request.CampaignID,
+		Events:     request.Events,
+		Active:     true,
+	}
+	if err := h.store.Create(&webhook); err != nil {
+		h.logger.ErrorWithContext(c.Request.Context(), "Failed to create webhook", err, "user_id", principal.UserID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create webhook: " + err.Error()})
+		return
+	}
+
+	h.logger.Info("Webhook registered successfully",
+		"webhook_id", webhook.ID,
+		"campaign_id", webhook.CampaignID,
+		"user_id", principal.UserID)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    models.CreateWebhookResponse{Secret: secret, Webhook: webhook},
+		"message": "Webhook registered successfully; store the secret now, it will not be shown again",
+		"success": true,
+	})
+}
+
+// ListWebhooks handles GET /api/webhooks
+// @Summary List webhooks
+// @Description List the webhooks registered by the caller
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.Webhook
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	principal := auth.CurrentPrincipal(c)
+	if principal == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	registered, err := h.store.ListByOwner(principal.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve webhooks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    registered,
+		"message": "Webhooks retrieved successfully",
+		"success": true,
+	})
+}
+
+// DeleteWebhook handles DELETE /api/webhooks/{id}
+// @Summary Delete a webhook
+// @Description Stop sending events to a webhook. Pending deliveries are dead-lettered; the delivery log is kept.
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	principal := auth.CurrentPrincipal(c)
+	if principal == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	webhook, ok := h.getOwned(c, principal)
+	if !ok {
+		return
+	}
+
+	if err := h.store.Delete(webhook.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete webhook: " + err.Error()})
+		return
+	}
+
+	h.logger.Info("Webhook deleted",
+		"webhook_id", webhook.ID,
+		"user_id", principal.UserID)
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /api/webhooks/{id}/deliveries
+// @Summary Webhook delivery log
+// @Description List recent deliveries for a webhook, newest first, with attempt counts, response codes and dead-letter status
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param limit query int false "Maximum deliveries to return (1-100)" default(20)
+// @Success 200 {array} models.WebhookDelivery
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	principal := auth.CurrentPrincipal(c)
+	if principal == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter (must be 1-100)"})
+		return
+	}
+
+	webhook, ok := h.getOwned(c, principal)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.store.ListDeliveries(webhook.ID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve deliveries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    deliveries,
+		"message": "Deliveries retrieved successfully",
+		"success": true,
+	})
+}
+
+// RedeliverDelivery handles POST /api/webhooks/{id}/deliveries/{deliveryId}/redeliver
+// @Summary Retry a webhook delivery
+// @Description Queue a delivery, typically a dead-lettered one, for another full round of attempts
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Param Idempotency-Key header string false "Key making retries of this request safe; the first response is replayed"
+// @Success 202 {object} models.WebhookDelivery
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandler) RedeliverDelivery(c *gin.Context) {
+	principal := auth.CurrentPrincipal(c)
+	if principal == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	webhook, ok := h.getOwned(c, principal)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.store.GetDelivery(c.Param("deliveryId"))
+	if err != nil || delivery.WebhookID != webhook.ID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found"})
+		return
+	}
+
+	delivery, err = h.dispatcher.Redeliver(delivery.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue delivery: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"data":    delivery,
+		"message": "Delivery queued",
+		"success": true,
+	})
+}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"player-character/internal/authz"
+	"player-character/internal/events"
+	"player-character/internal/models"
+	"player-character/internal/webhooks"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestWebhookDelivery tests registering a campaign webhook and receiving signed level-up events
+func TestWebhookDelivery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := database.NewMemoryStore()
+	campaignStore := database.NewMemoryCampaignStore()
+	webhookStore := database.NewMemoryWebhookStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	policy := authz.NewPolicy(store, campaignStore)
+
+	config := webhooks.DefaultConfig()
+	config.PollInterval = 10 * time.Millisecond
+	config.AllowPrivateAddresses = true
+	dispatcher := webhooks.NewDispatcher(webhookStore, config, logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dispatcher.Run(ctx)
+
+	characterHandler := NewCharacterHandler(store, logger,
+		WithCampaignStore(campaignStore),
+		WithPolicy(policy),
+		WithEvents(events.Publishers(events.NewMemoryBroker(), dispatcher)))
+	webhookHandler := NewWebhookHandler(webhookStore, dispatcher, campaignStore, policy, logger)
+
+	router := gin.New()
+	router.Use(asUser())
+	router.PUT("/api/characters/:id", characterHandler.UpdateCharacter)
+	router.POST("/api/webhooks", webhookHandler.CreateWebhook)
+	router.GET("/api/webhooks/:id/deliveries", webhookHandler.ListDeliveries)
+
+	// Local stand-in for the receiving service
+	type receipt struct {
+		event     string
+		signature string
+		timestamp string
+		body      []byte
+	}
+	received := make(chan receipt, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receipt{
+			event:     r.Header.Get(webhooks.HeaderEvent),
+			signature: r.Header.Get(webhooks.HeaderSignature),
+			timestamp: r.Header.Get(webhooks.HeaderTimestamp),
+			body:      body,
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	campaign := models.Campaign{Name: "Waterdeep", DMID: "dm", Players: []string{"alice"}}
+	if err := campaignStore.Create(&campaign); err != nil {
+		t.Fatalf("Failed to create campaign: %v", err)
+	}
+
+	character := models.Character{
+		CharacterName: "Hooked",
+		Race:          "Human",
+		Class:         "Fighter",
+		Level:         1,
+		OwnerID:       "alice",
+		CampaignID:    campaign.ID,
+		AbilityScores: models.AbilityScores{
+			Strength:     models.AbilityScore{Base: 15},
+			Dexterity:    models.AbilityScore{Base: 14},
+			Constitution: models.AbilityScore{Base: 13},
+			Intelligence: models.AbilityScore{Base: 12},
+			Wisdom:       models.AbilityScore{Base: 10},
+			Charisma:     models.AbilityScore{Base: 8},
+		},
+	}
+	if err := store.Create(&character); err != nil {
+		t.Fatalf("Failed to create character: %v", err)
+	}
+
+	send := func(method, path, userID string, body interface{}) *httptest.ResponseRecorder {
+		jsonData, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-User", userID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	request := models.CreateWebhookRequest{URL: receiver.URL, CampaignID: campaign.ID}
+
+	// Only the DM may register campaign webhooks, and only admins global ones
+	if w := send("POST", "/api/webhooks", "alice", request); w.Code != http.StatusForbidden {
+		t.Errorf("Expected player registration to be forbidden, got %d", w.Code)
+	}
+	if w := send("POST", "/api/webhooks", "dm", models.CreateWebhookRequest{URL: receiver.URL}); w.Code != http.StatusForbidden {
+		t.Errorf("Expected global registration by a non-admin to be forbidden, got %d", w.Code)
+	}
+	if w := send("POST", "/api/webhooks", "dm", models.CreateWebhookRequest{URL: "ftp://example.com"}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected a non-HTTP URL to be rejected, got %d", w.Code)
+	}
+
+	w := send("POST", "/api/webhooks", "dm", request)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		Data models.CreateWebhookResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if created.Data.Secret == "" {
+		t.Fatal("Expected the signing secret in the response")
+	}
+
+	// Levelling up sends both the update and the level-up event
+	character.Level = 2
+	if w := send("PUT", "/api/characters/"+character.ID, "alice", character); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	seen := map[string]bool{}
+	for len(seen) < 2 {
+		select {
+		case r := <-received:
+			seen[r.event] = true
+
+			timestamp, _ := strconv.ParseInt(r.timestamp, 10, 64)
+			if r.signature != webhooks.Sign(created.Data.Secret, timestamp, r.body) {
+				t.Errorf("Invalid signature on %s delivery", r.event)
+			}
+
+			var event events.Event
+			if err := json.Unmarshal(r.body, &event); err != nil {
+				t.Fatalf("Failed to unmarshal payload: %v", err)
+			}
+			if event.CharacterID != character.ID || event.Character == nil || event.Character.Level != 2 {
+				t.Errorf("Unexpected payload: %s", r.body)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for deliveries, got %v", seen)
+		}
+	}
+	if !seen[events.CharacterUpdated] || !seen[events.CharacterLeveledUp] {
+		t.Errorf("Expected updated and leveled_up deliveries, got %v", seen)
+	}
+
+	// The delivery log is only visible to the webhook's owner
+	if w := send("GET", "/api/webhooks/"+created.Data.Webhook.ID+"/deliveries", "alice", nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected another user's delivery log to be hidden, got %d", w.Code)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		w := send("GET", "/api/webhooks/"+created.Data.Webhook.ID+"/deliveries", "dm", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var log struct {
+			Data []models.WebhookDelivery `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &log)
+
+		succeeded := 0
+		for _, delivery := range log.Data {
+			if delivery.Status == models.DeliverySucceeded {
+				succeeded++
+			}
+		}
+		if succeeded == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected 2 succeeded deliveries in the log, got %+v", log.Data)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestWebhookPrivateAddresses tests that webhooks cannot be aimed at internal services
+func TestWebhookPrivateAddresses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	campaignStore := database.NewMemoryCampaignStore()
+	webhookStore := database.NewMemoryWebhookStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	policy := authz.NewPolicy(database.NewMemoryStore(), campaignStore)
+	dispatcher := webhooks.NewDispatcher(webhookStore, webhooks.DefaultConfig(), logger)
+	webhookHandler := NewWebhookHandler(webhookStore, dispatcher, campaignStore, policy, logger)
+
+	router := gin.New()
+	router.Use(asUser())
+	router.POST("/api/webhooks", webhookHandler.CreateWebhook)
+
+	campaign := models.Campaign{Name: "Waterdeep", DMID: "dm"}
+	if err := campaignStore.Create(&campaign); err != nil {
+		t.Fatalf("Failed to create campaign: %v", err)
+	}
+
+	for _, url := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://[::1]/hook",
+		"http://10.1.2.3/hook",
+		"http://192.168.0.10/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://0.0.0.0/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+	} {
+		jsonData, _ := json.Marshal(models.CreateWebhookRequest{URL: url, CampaignID: campaign.ID})
+		req, _ := http.NewRequest("POST", "/api/webhooks", bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-User", "dm")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected %s to be rejected, got %d: %s", url, w.Code, w.Body.String())
+		}
+	}
+
+	registered, _ := webhookStore.ListByOwner("dm")
+	if len(registered) != 0 {
+		t.Errorf("Expected no webhooks to be registered, got %d", len(registered))
+	}
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// APIKeyPrefix marks a bearer credential as an API key rather than a session token
+const APIKeyPrefix = "pck_"
+
+// GenerateAPIKey returns a new random API key, its display prefix and the hash to store
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	prefix = key[:len(APIKeyPrefix)+8]
+	return key, prefix, HashAPIKey(key), nil
+}
+
+// HashAPIKey hashes an API key for storage and lookup. Keys carry 256 bits of
+// entropy, so a fast unsalted hash is sufficient, unlike user passwords.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAPIKey reports whether a credential looks like an API key
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, APIKeyPrefix)
+}
@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// principalKey is the gin context key holding the authenticated Principal
+const principalKey = "auth.principal"
+
+// lastUsedResolution limits how often an API key's last-used timestamp is written
+const lastUsedResolution = time.Minute
+
+// Principal identifies the caller of an authenticated request
+type Principal struct {
+	UserID   string
+	Username string
+	Role     string
+
+	// Set only when the caller authenticated with an API key
+	APIKeyID    string
+	Scopes      []string
+	CampaignIDs []string // campaigns the key is limited to; empty means unrestricted
+}
+
+// HasScope reports whether the principal carries one of the scopes.
+// Session users are not scoped and always pass.
+func (p *Principal) HasScope(scopes ...string) bool {
+	if p.APIKeyID == "" {
+		return true
+	}
+	for _, have := range p.Scopes {
+		for _, want := range scopes {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AllowsCampaign reports whether the principal may act within the campaign
+func (p *Principal) AllowsCampaign(campaignID string) bool {
+	if len(p.CampaignIDs) == 0 {
+		return true
+	}
+	for _, allowed := range p.CampaignIDs {
+		if allowed == campaignID {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returned by Authenticate
+var (
+	ErrNoCredential       = errors.New("Authentication required")
+	ErrAPIKeysNotAccepted = errors.New("API keys are not accepted here")
+	ErrInvalidAPIKey      = errors.New("Invalid, expired or revoked API key")
+	ErrInvalidToken       = errors.New("Invalid or expired token")
+)
+
+// Authenticate resolves a session token or API key to the principal it identifies.
+// Pass a nil store to accept session tokens only.
+func Authenticate(tokens *TokenManager, apiKeys database.APIKeyStore, credential string) (*Principal, error) {
+	if credential == "" {
+		return nil, ErrNoCredential
+	}
+
+	if IsAPIKey(credential) {
+		if apiKeys == nil {
+			return nil, ErrAPIKeysNotAccepted
+		}
+		principal, ok := authenticateAPIKey(apiKeys, credential)
+		if !ok {
+			return nil, ErrInvalidAPIKey
+		}
+		return principal, nil
+	}
+
+	claims, err := tokens.Verify(credential)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return &Principal{
+		UserID:   claims.Subject,
+		Username: claims.Username,
+		Role:     claims.Role,
+	}, nil
+}
+
+// Middleware returns a Gin middleware that requires a valid session token or API key.
+// API keys are accepted as a bearer credential or in the X-API-Key header; pass a nil
+// store to accept session tokens only. Requests without a credential are rejected with 401.
+func Middleware(tokens *TokenManager, apiKeys database.APIKeyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		credential, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !found || credential == "" {
+			credential = c.GetHeader("X-API-Key")
+		}
+
+		principal, err := Authenticate(tokens, apiKeys, credential)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		SetPrincipal(c, principal)
+		c.Next()
+	}
+}
+
+// authenticateAPIKey resolves an API key to a principal and records its use.
+// Keys always act with ordinary user privileges, even when minted by an admin.
+func authenticateAPIKey(apiKeys database.APIKeyStore, credential string) (*Principal, bool) {
+	key, err := apiKeys.GetByHash(HashAPIKey(credential))
+	if err != nil {
+		return nil, false
+	}
+
+	now := time.Now()
+	if !key.Active(now) {
+		return nil, false
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		// Best effort; a failed timestamp write must not fail the request
+		_ = apiKeys.TouchLastUsed(key.ID, now)
+	}
+
+	return &Principal{
+		UserID:      key.UserID,
+		Role:        models.RoleUser,
+		APIKeyID:    key.ID,
+		Scopes:      key.Scopes,
+		CampaignIDs: key.CampaignIDs,
+	}, true
+}
+
+// RequireScope returns a Gin middleware rejecting API keys that carry none of the scopes with 403
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := CurrentPrincipal(c)
+		if principal != nil && !principal.HasScope(scopes...) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API key is missing the required scope: " + strings.Join(scopes, " or ")})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSession returns a Gin middleware rejecting API keys with 403, for routes only people may use
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := CurrentPrincipal(c)
+		if principal != nil && principal.APIKeyID != "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "This endpoint requires a user session"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireAdmin returns a Gin middleware rejecting everyone but admins with 403.
+// API keys never act as admins, so they are rejected too.
+func RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := CurrentPrincipal(c)
+		if principal == nil || principal.Role != models.RoleAdmin {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "This endpoint requires an admin"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// SetPrincipal records the authenticated caller on the request context
+func SetPrincipal(c *gin.Context, principal *Principal) {
+	c.Set(principalKey, principal)
+}
+
+// CurrentPrincipal returns the authenticated caller, or nil for anonymous requests
+func CurrentPrincipal(c *gin.Context) *Principal {
+	value, exists := c.Get(principalKey)
+	if !exists {
+		return nil
+	}
+	principal, _ := value.(*Principal)
+	return principal
+}
+
+type principalContextKey struct{}
+
+// NewContext returns a context carrying the principal, for transports other than Gin
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// FromContext returns the principal stored by NewContext, or nil
+func FromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalContextKey{}).(*Principal)
+	return principal
+}
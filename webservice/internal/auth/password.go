@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"crypto/rand"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyHash is checked instead of a user's hash when there is no such user,
+// so that an unknown username takes as long to reject as a wrong password.
+// It hashes random bytes, so no password matches it.
+var dummyHash = sync.OnceValue(func() []byte {
+	secret := make([]byte, 32)
+	rand.Read(secret)
+	hash, _ := bcrypt.GenerateFromPassword(secret, bcrypt.DefaultCost)
+	return hash
+})
+
+// HashPassword hashes a plaintext password with bcrypt
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether password matches the stored bcrypt hash. An
+// empty hash, for a user that does not exist, never matches, but is rejected
+// in the time a wrong password takes.
+func CheckPassword(hash, password string) bool {
+	if hash == "" {
+		bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
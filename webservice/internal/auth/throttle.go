@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// LoginThrottleConfig bounds failed logins
+type LoginThrottleConfig struct {
+	PerUsername int           // failures allowed for one username in a window
+	PerIP       int           // failures allowed from one client IP in a window
+	Window      time.Duration // how long failures are counted
+}
+
+// DefaultLoginThrottleConfig returns the limits used unless configured otherwise
+func DefaultLoginThrottleConfig() LoginThrottleConfig {
+	return LoginThrottleConfig{
+		PerUsername: 10,
+		PerIP:       50,
+		Window:      15 * time.Minute,
+	}
+}
+
+// LoginThrottle counts failed logins per username and per client IP, and
+// refuses further attempts once either is over its limit until the window
+// the failures were counted in ends. Counts are kept in memory, so each
+// replica limits separately.
+type LoginThrottle struct {
+	config    LoginThrottleConfig
+	mutex     sync.Mutex
+	failures  map[string]*failureCount // by "user:<name>" and "ip:<address>"
+	lastSweep time.Time
+}
+
+// failureCount counts the failures in a window ending at reset
+type failureCount struct {
+	count int
+	reset time.Time
+}
+
+// NewLoginThrottle creates a throttle with the given limits
+func NewLoginThrottle(config LoginThrottleConfig) *LoginThrottle {
+	return &LoginThrottle{
+		config:   config,
+		failures: make(map[string]*failureCount),
+	}
+}
+
+// Allow reports whether a login for username from ip may be attempted, and if
+// not, how long until it may
+func (t *LoginThrottle) Allow(username, ip string, now time.Time) (time.Duration, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var wait time.Duration
+	for key, limit := range t.keys(username, ip) {
+		if f, ok := t.failures[key]; ok && now.Before(f.reset) && f.count >= limit {
+			wait = max(wait, f.reset.Sub(now))
+		}
+	}
+	return wait, wait == 0
+}
+
+// Fail counts a failed login for username from ip
+func (t *LoginThrottle) Fail(username, ip string, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.sweep(now)
+	for key := range t.keys(username, ip) {
+		f, ok := t.failures[key]
+		if !ok || !now.Before(f.reset) {
+			f = &failureCount{reset: now.Add(t.config.Window)}
+			t.failures[key] = f
+		}
+		f.count++
+	}
+}
+
+// Succeed forgets the failures counted for username; those from its IP still count
+func (t *LoginThrottle) Succeed(username string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.failures, "user:"+strings.ToLower(username))
+}
+
+// keys returns the counters a login touches, with their limits
+func (t *LoginThrottle) keys(username, ip string) map[string]int {
+	return map[string]int{
+		"user:" + strings.ToLower(username): t.config.PerUsername,
+		"ip:" + ip:                          t.config.PerIP,
+	}
+}
+
+// sweep drops counters whose window has ended, at most once a window; the
+// caller holds the mutex
+func (t *LoginThrottle) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < t.config.Window {
+		return
+	}
+	t.lastSweep = now
+	for key, f := range t.failures {
+		if !now.Before(f.reset) {
+			delete(t.failures, key)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLoginThrottle tests that failures are limited per username and per IP until their window ends
+func TestLoginThrottle(t *testing.T) {
+	throttle := NewLoginThrottle(LoginThrottleConfig{PerUsername: 2, PerIP: 3, Window: time.Minute})
+	now := time.Now()
+
+	throttle.Fail("Vex", "10.0.0.1", now)
+	if _, ok := throttle.Allow("vex", "10.0.0.1", now); !ok {
+		t.Error("Expected a login after one failure to be allowed")
+	}
+	throttle.Fail("vex", "10.0.0.2", now.Add(10*time.Second))
+	wait, ok := throttle.Allow("VEX", "10.0.0.3", now.Add(20*time.Second))
+	if ok || wait != 40*time.Second {
+		t.Errorf("Expected the username to be refused for 40s after two failures, got %v, %v", wait, ok)
+	}
+	if _, ok := throttle.Allow("other", "10.0.0.1", now.Add(20*time.Second)); !ok {
+		t.Error("Expected other usernames from the same IP to be allowed")
+	}
+
+	// A third failure from one IP blocks it for every username
+	throttle.Fail("a", "10.0.0.1", now.Add(30*time.Second))
+	throttle.Fail("b", "10.0.0.1", now.Add(30*time.Second))
+	if _, ok := throttle.Allow("c", "10.0.0.1", now.Add(30*time.Second)); ok {
+		t.Error("Expected the IP to be refused after three failures")
+	}
+
+	// Counts reset once the window ends
+	if _, ok := throttle.Allow("vex", "10.0.0.1", now.Add(time.Minute)); !ok {
+		t.Error("Expected logins to be allowed once the window has ended")
+	}
+
+	// Logging in forgets the username's failures, but not the IP's
+	throttle.Fail("wren", "10.0.0.9", now)
+	throttle.Fail("wren", "10.0.0.9", now)
+	throttle.Succeed("Wren")
+	if _, ok := throttle.Allow("wren", "10.0.0.8", now); !ok {
+		t.Error("Expected a successful login to reset the username's failures")
+	}
+}
+
+// TestCheckPassword_UnknownUser tests that the stand-in for a missing hash never matches
+func TestCheckPassword_UnknownUser(t *testing.T) {
+	if CheckPassword("", "") || CheckPassword("", "correct-horse") {
+		t.Error("Expected an empty hash never to match")
+	}
+	hash, err := HashPassword("correct-horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !CheckPassword(hash, "correct-horse") || CheckPassword(hash, "wrong") {
+		t.Error("Expected only the right password to match its hash")
+	}
+}
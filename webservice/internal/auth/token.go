@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenIssuer is the issuer claim written to and required on every token
+const tokenIssuer = "player-character"
+
+// Claims are the JWT claims carried by session tokens
+type Claims struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// TokenManager issues and verifies HMAC-signed session tokens
+type TokenManager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewTokenManager creates a token manager signing with secret; tokens expire after ttl
+func NewTokenManager(secret []byte, ttl time.Duration) (*TokenManager, error) {
+	if len(secret) < 32 {
+		return nil, errors.New("token secret must be at least 32 bytes")
+	}
+	return &TokenManager{
+		secret: secret,
+		ttl:    ttl,
+	}, nil
+}
+
+// Issue signs a new token for user and returns it with its expiry time
+func (m *TokenManager) Issue(user *models.User) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+
+	claims := Claims{
+		Username: user.Username,
+		Role:     user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID,
+			Issuer:    tokenIssuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// Verify parses a token, checking its signature, issuer and expiry
+func (m *TokenManager) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("invalid token: missing subject")
+	}
+
+	return claims, nil
+}
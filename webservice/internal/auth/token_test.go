@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// TestTokenManager_IssueAndVerify tests that issued tokens verify and carry the user's identity
+func TestTokenManager_IssueAndVerify(t *testing.T) {
+	tokens, err := NewTokenManager(testSecret, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create token manager: %v", err)
+	}
+
+	user := &models.User{ID: "user-1", Username: "strahd", Role: models.RoleUser}
+	token, _, err := tokens.Issue(user)
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+
+	claims, err := tokens.Verify(token)
+	if err != nil {
+		t.Fatalf("Failed to verify token: %v", err)
+	}
+	if claims.Subject != user.ID || claims.Username != user.Username {
+		t.Errorf("Unexpected claims: %+v", claims)
+	}
+}
+
+// TestTokenManager_Rejects tests that tampered, foreign and expired tokens are rejected
+func TestTokenManager_Rejects(t *testing.T) {
+	tokens, _ := NewTokenManager(testSecret, time.Hour)
+	expired, _ := NewTokenManager(testSecret, -time.Minute)
+	other, _ := NewTokenManager([]byte(strings.Repeat("x", 32)), time.Hour)
+
+	user := &models.User{ID: "user-1", Username: "strahd"}
+	valid, _, _ := tokens.Issue(user)
+	expiredToken, _, _ := expired.Issue(user)
+	foreignToken, _, _ := other.Issue(user)
+
+	cases := map[string]string{
+		"tampered": valid[:len(valid)-2] + "xx",
+		"expired":  expiredToken,
+		"foreign":  foreignToken,
+		"garbage":  "not-a-token",
+	}
+	for name, token := range cases {
+		if _, err := tokens.Verify(token); err == nil {
+			t.Errorf("Expected %s token to be rejected", name)
+		}
+	}
+}
+
+// TestNewTokenManager_ShortSecret tests that weak secrets are refused
+func TestNewTokenManager_ShortSecret(t *testing.T) {
+	if _, err := NewTokenManager([]byte("short"), time.Hour); err == nil {
+		t.Error("Expected error for short secret")
+	}
+}
@@ -0,0 +1,191 @@
+package authz
+
+import (
+	"player-character/internal/auth"
+	"player-character/internal/models"
+	"player-character/pkg/database"
+)
+
+// CharacterAccess describes what a principal may do with one character
+type CharacterAccess struct {
+	Read       bool
+	Edit       bool
+	Delete     bool
+	SeeSecrets bool // secret backstory, shared between the owner and the DM
+	SeeDMNotes bool // DM notes, visible to the DM only
+}
+
+// CampaignAccess describes what a principal may do with one campaign
+type CampaignAccess struct {
+	Read   bool
+	Manage bool
+}
+
+// Policy decides who may read and change characters and campaigns.
+//
+// Players read and edit their own characters, the campaign DM reads and edits
+// every character in the campaign, party members and campaign viewers read
+// each other's sheets with hidden fields stripped, and admins may do anything.
+type Policy struct {
+	characters database.CharacterStore
+	campaigns  database.CampaignStore
+}
+
+// NewPolicy creates a policy that looks up campaigns and party membership in the given stores
+func NewPolicy(characters database.CharacterStore, campaigns database.CampaignStore) *Policy {
+	return &Policy{
+		characters: characters,
+		campaigns:  campaigns,
+	}
+}
+
+// CharacterAccess computes the principal's access to a character
+func (p *Policy) CharacterAccess(principal *auth.Principal, character *models.Character) (CharacterAccess, error) {
+	if principal == nil {
+		return CharacterAccess{}, nil
+	}
+	if !principal.AllowsCampaign(character.CampaignID) {
+		return CharacterAccess{}, nil
+	}
+	if principal.Role == models.RoleAdmin {
+		return CharacterAccess{Read: true, Edit: true, Delete: true, SeeSecrets: true, SeeDMNotes: true}, nil
+	}
+
+	var access CharacterAccess
+	if character.OwnerID != "" && character.OwnerID == principal.UserID {
+		access = CharacterAccess{Read: true, Edit: true, Delete: true, SeeSecrets: true}
+	}
+
+	if character.CampaignID != "" {
+		campaign, err := p.campaigns.Get(character.CampaignID)
+		if err == nil {
+			if campaign.DMID == principal.UserID {
+				return CharacterAccess{Read: true, Edit: true, Delete: true, SeeSecrets: true, SeeDMNotes: true}, nil
+			}
+			if campaign.IsViewer(principal.UserID) {
+				access.Read = true
+			}
+		}
+	}
+
+	if !access.Read && character.PartyID != "" {
+		inParty, err := p.ownsCharacterInParty(principal.UserID, character.PartyID)
+		if err != nil {
+			return CharacterAccess{}, err
+		}
+		access.Read = inParty
+	}
+
+	return access, nil
+}
+
+// ownsCharacterInParty reports whether the user has a character in the party
+func (p *Policy) ownsCharacterInParty(userID, partyID string) (bool, error) {
+	_, total, err := p.characters.List(database.ListOptions{
+		Page:    1,
+		Limit:   1,
+		PartyID: partyID,
+		OwnerID: userID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// CanJoinCampaign reports whether the principal may place a character in the
+// campaign, or outside any campaign when campaignID is empty
+func (p *Policy) CanJoinCampaign(principal *auth.Principal, campaignID string) (bool, error) {
+	if principal == nil {
+		return false, nil
+	}
+	if !principal.AllowsCampaign(campaignID) {
+		return false, nil
+	}
+	if principal.Role == models.RoleAdmin || campaignID == "" {
+		return true, nil
+	}
+
+	campaign, err := p.campaigns.Get(campaignID)
+	if err != nil {
+		// Unknown campaigns are reported by validation instead
+		return true, nil
+	}
+	return campaign.IsMember(principal.UserID), nil
+}
+
+// CampaignAccess computes the principal's access to a campaign
+func (p *Policy) CampaignAccess(principal *auth.Principal, campaign *models.Campaign) CampaignAccess {
+	if principal == nil || !principal.AllowsCampaign(campaign.ID) {
+		return CampaignAccess{}
+	}
+	if principal.Role == models.RoleAdmin || campaign.DMID == principal.UserID {
+		return CampaignAccess{Read: true, Manage: true}
+	}
+	return CampaignAccess{Read: campaign.IsMember(principal.UserID) || campaign.IsViewer(principal.UserID)}
+}
+
+// Visibility returns the store-level filter for characters the principal may
+// list, or nil when the principal may list every character
+func (p *Policy) Visibility(principal *auth.Principal) (*database.Visibility, error) {
+	if principal == nil {
+		// Anonymous callers match nothing: no character is owned by the empty user
+		return &database.Visibility{}, nil
+	}
+	if principal.Role == models.RoleAdmin {
+		return nil, nil
+	}
+
+	campaigns, err := p.campaigns.ListByMember(principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	visibility := &database.Visibility{UserID: principal.UserID, WithinCampaigns: principal.CampaignIDs}
+	for _, campaign := range campaigns {
+		if campaign.DMID == principal.UserID || campaign.IsViewer(principal.UserID) {
+			visibility.CampaignIDs = append(visibility.CampaignIDs, campaign.ID)
+		}
+	}
+	return visibility, nil
+}
+
+// Redact strips the hidden fields the access does not cover
+func Redact(character *models.Character, access CharacterAccess) {
+	if !access.SeeSecrets {
+		character.SecretBackstory = ""
+	}
+	if !access.SeeDMNotes {
+		character.DMNotes = ""
+	}
+}
+
+// RedactAll strips hidden fields from characters returned by a visibility-filtered listing
+func (p *Policy) RedactAll(principal *auth.Principal, characters []models.Character) {
+	if principal != nil && principal.Role == models.RoleAdmin {
+		return
+	}
+
+	// Cache campaign lookups; listings usually span only a few campaigns
+	dmOf := make(map[string]bool)
+	for i := range characters {
+		character := &characters[i]
+		var access CharacterAccess
+		if principal != nil {
+			access.SeeSecrets = character.OwnerID != "" && character.OwnerID == principal.UserID
+			if character.CampaignID != "" {
+				isDM, cached := dmOf[character.CampaignID]
+				if !cached {
+					campaign, err := p.campaigns.Get(character.CampaignID)
+					isDM = err == nil && campaign.DMID == principal.UserID
+					dmOf[character.CampaignID] = isDM
+				}
+				if isDM {
+					access.SeeSecrets = true
+					access.SeeDMNotes = true
+				}
+			}
+		}
+		Redact(character, access)
+	}
+}
@@ -0,0 +1,355 @@
+// Package backup writes every store to a portable archive and restores one
+// into any backend.
+//
+// An archive is a gzip-compressed tar file. Its first entry, manifest.json,
+// records the archive version and, for every collection, the file holding it,
+// its record count and the SHA-256 checksum of the file. Each collection is a
+// JSON Lines file with one record per line, written as MongoDB Extended JSON
+// in the records' storage layout so that password hashes and secrets, which
+// the API never shows, survive the round trip. Restore verifies the whole
+// archive before it writes anything.
+//
+// Archives hold characters, campaigns, users, API keys and webhooks. Webhook
+// delivery logs and idempotency records are operational state and are left
+// out: restoring pending deliveries would send them again.
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Format identifies backup archives in their manifest
+const Format = "player-character-backup"
+
+// Version is the archive layout written by Write. Restore reads this version
+// and earlier ones.
+const Version = 1
+
+// manifestName is the name of the manifest entry in an archive
+const manifestName = "manifest.json"
+
+// maxEntrySize bounds a single archive entry, so a crafted archive cannot
+// exhaust memory while it is being verified
+const maxEntrySize = 1 << 30
+
+// ErrInvalid is returned, wrapped, when an archive is corrupt, fails its
+// checksums or is not a backup archive
+var ErrInvalid = errors.New("invalid backup archive")
+
+// Stores are the stores an archive is written from or restored into
+type Stores struct {
+	Characters database.CharacterStore
+	Campaigns  database.CampaignStore
+	Users      database.UserStore
+	APIKeys    database.APIKeyStore
+	Webhooks   database.WebhookStore
+}
+
+// Manifest describes an archive
+type Manifest struct {
+	Format      string       `json:"format"`
+	Version     int          `json:"version"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	Collections []Collection `json:"collections"`
+}
+
+// Collection describes one collection's file in an archive
+type Collection struct {
+	Name    string `json:"name"`
+	File    string `json:"file"`
+	Records int    `json:"records"`
+	SHA256  string `json:"sha256"`
+}
+
+// Result reports what a restore did with each collection
+type Result struct {
+	Collections []RestoredCollection `json:"collections"`
+}
+
+// RestoredCollection reports the restore of one collection. Records whose ID
+// is already taken, or users whose username is, are skipped.
+type RestoredCollection struct {
+	Name     string `json:"name"`
+	Restored int    `json:"restored"`
+	Skipped  int    `json:"skipped"`
+}
+
+// collection reads and writes one collection of records
+type collection struct {
+	name string
+	dump func(Stores) ([]byte, int, error)
+	// load decodes a collection's file, returning how many records it holds
+	// and a function writing them into stores
+	load func([]byte) (int, func(Stores) (RestoredCollection, error), error)
+}
+
+// collections lists what an archive holds, in restore order: records come
+// after the records they refer to
+var collections = []collection{
+	newCollection("users", func(s Stores) ([]models.User, error) { return s.Users.All() },
+		func(s Stores, u *models.User) error { return s.Users.Restore(u) }),
+	newCollection("campaigns", func(s Stores) ([]models.Campaign, error) { return s.Campaigns.All() },
+		func(s Stores, c *models.Campaign) error { return s.Campaigns.Restore(c) }),
+	newCollection("characters", func(s Stores) ([]models.Character, error) { return s.Characters.All() },
+		func(s Stores, c *models.Character) error { return s.Characters.Restore(c) }),
+	newCollection("apikeys", func(s Stores) ([]models.APIKey, error) { return s.APIKeys.All() },
+		func(s Stores, k *models.APIKey) error { return s.APIKeys.Restore(k) }),
+	newCollection("webhooks", func(s Stores) ([]models.Webhook, error) { return s.Webhooks.All() },
+		func(s Stores, w *models.Webhook) error { return s.Webhooks.Restore(w) }),
+}
+
+// newCollection builds a collection of records of type T, which must carry an ID
+func newCollection[T any](name string, all func(Stores) ([]T, error), restore func(Stores, *T) error) collection {
+	return collection{
+		name: name,
+		dump: func(stores Stores) ([]byte, int, error) {
+			records, err := all(stores)
+			if err != nil {
+				return nil, 0, fmt.Errorf("reading %s: %w", name, err)
+			}
+			var buf bytes.Buffer
+			for i := range records {
+				line, err := bson.MarshalExtJSON(&records[i], false, false)
+				if err != nil {
+					return nil, 0, fmt.Errorf("encoding %s: %w", name, err)
+				}
+				buf.Write(line)
+				buf.WriteByte('\n')
+			}
+			return buf.Bytes(), len(records), nil
+		},
+		load: func(data []byte) (int, func(Stores) (RestoredCollection, error), error) {
+			records, err := decode[T](data)
+			if err != nil {
+				return 0, nil, fmt.Errorf("%s: %w", name, err)
+			}
+			write := func(stores Stores) (RestoredCollection, error) {
+				result := RestoredCollection{Name: name}
+				for i := range records {
+					err := restore(stores, &records[i])
+					switch {
+					case errors.Is(err, database.ErrExists):
+						result.Skipped++
+					case err != nil:
+						return result, fmt.Errorf("restoring %s: %w", name, err)
+					default:
+						result.Restored++
+					}
+				}
+				return result, nil
+			}
+			return len(records), write, nil
+		},
+	}
+}
+
+// decode reads JSON Lines records, rejecting records without an ID
+func decode[T any](data []byte) ([]T, error) {
+	records := []T{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, maxEntrySize)
+	for line := 1; scanner.Scan(); line++ {
+		var record T
+		if err := bson.UnmarshalExtJSON(scanner.Bytes(), false, &record); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalid, line, err)
+		}
+		var id struct {
+			ID string `bson:"id"`
+		}
+		if err := bson.UnmarshalExtJSON(scanner.Bytes(), false, &id); err != nil || id.ID == "" {
+			return nil, fmt.Errorf("%w: line %d: record has no id", ErrInvalid, line)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	return records, nil
+}
+
+// Write writes an archive of every store to w and returns its manifest
+func Write(w io.Writer, stores Stores, now time.Time) (*Manifest, error) {
+	manifest := &Manifest{Format: Format, Version: Version, CreatedAt: now.UTC()}
+	files := make([][]byte, len(collections))
+	for i, c := range collections {
+		data, count, err := c.dump(stores)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		files[i] = data
+		manifest.Collections = append(manifest.Collections, Collection{
+			Name:    c.name,
+			File:    c.name + ".jsonl",
+			Records: count,
+			SHA256:  hex.EncodeToString(sum[:]),
+		})
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+	if err := writeEntry(archive, manifestName, manifestData, manifest.CreatedAt); err != nil {
+		return nil, err
+	}
+	for i, c := range manifest.Collections {
+		if err := writeEntry(archive, c.File, files[i], manifest.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// FileName returns the conventional file name of an archive created at a time
+func FileName(createdAt time.Time) string {
+	return "backup-" + createdAt.UTC().Format("20060102T150405Z") + ".tar.gz"
+}
+
+// writeEntry writes one file to a tar archive
+func writeEntry(archive *tar.Writer, name string, data []byte, modified time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: modified,
+		Format:  tar.FormatPAX,
+	}
+	if err := archive.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := archive.Write(data)
+	return err
+}
+
+// Archive is an archive read and verified by Read
+type Archive struct {
+	Manifest Manifest
+	files    map[string][]byte
+}
+
+// Read reads an archive and verifies its manifest and checksums
+func Read(r io.Reader) (*Archive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	defer gz.Close()
+
+	entries := map[string][]byte{}
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if header.Size > maxEntrySize {
+			return nil, fmt.Errorf("%w: %s is too large", ErrInvalid, header.Name)
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+		entries[header.Name] = data
+	}
+
+	data, found := entries[manifestName]
+	if !found {
+		return nil, fmt.Errorf("%w: no %s", ErrInvalid, manifestName)
+	}
+	archive := &Archive{files: map[string][]byte{}}
+	if err := json.Unmarshal(data, &archive.Manifest); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalid, manifestName, err)
+	}
+	if archive.Manifest.Format != Format {
+		return nil, fmt.Errorf("%w: not a %s archive", ErrInvalid, Format)
+	}
+	if archive.Manifest.Version < 1 || archive.Manifest.Version > Version {
+		return nil, fmt.Errorf("%w: unsupported version %d (expected at most %d)", ErrInvalid, archive.Manifest.Version, Version)
+	}
+
+	for _, c := range archive.Manifest.Collections {
+		data, found := entries[c.File]
+		if !found {
+			return nil, fmt.Errorf("%w: %s is missing", ErrInvalid, c.File)
+		}
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != c.SHA256 {
+			return nil, fmt.Errorf("%w: checksum mismatch for %s", ErrInvalid, c.File)
+		}
+		archive.files[c.Name] = data
+	}
+	return archive, nil
+}
+
+// Restore writes the archive's records into stores, keeping their IDs and
+// timestamps. Records that already exist are skipped, so restoring the same
+// archive twice is harmless. Every collection is decoded before anything is
+// written.
+func (a *Archive) Restore(stores Stores) (*Result, error) {
+	var writes []func(Stores) (RestoredCollection, error)
+	for _, c := range collections {
+		data, found := a.files[c.name]
+		if !found {
+			continue
+		}
+		count, write, err := c.load(data)
+		if err != nil {
+			return nil, err
+		}
+		if expected := a.Manifest.records(c.name); count != expected {
+			return nil, fmt.Errorf("%w: %s holds %d records, manifest says %d", ErrInvalid, c.name, count, expected)
+		}
+		writes = append(writes, write)
+	}
+
+	result := &Result{Collections: []RestoredCollection{}}
+	for _, write := range writes {
+		restored, err := write(stores)
+		result.Collections = append(result.Collections, restored)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// records returns the number of records the manifest lists for a collection
+func (m Manifest) records(name string) int {
+	for _, c := range m.Collections {
+		if c.Name == name {
+			return c.Records
+		}
+	}
+	return 0
+}
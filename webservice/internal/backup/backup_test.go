@@ -0,0 +1,231 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+)
+
+// newStores returns empty in-memory stores
+func newStores() Stores {
+	return Stores{
+		Characters: database.NewMemoryStore(),
+		Campaigns:  database.NewMemoryCampaignStore(),
+		Users:      database.NewMemoryUserStore(),
+		APIKeys:    database.NewMemoryAPIKeyStore(),
+		Webhooks:   database.NewMemoryWebhookStore(),
+	}
+}
+
+// seed fills stores with one record of each kind
+func seed(t *testing.T, stores Stores) {
+	t.Helper()
+	if err := stores.Users.Create(&models.User{ID: "user-1", Username: "Gandalf", PasswordHash: "hash", Role: models.RoleAdmin}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stores.Campaigns.Create(&models.Campaign{ID: "campaign-1", Name: "Lost Mine", DMID: "user-1"}); err != nil {
+		t.Fatal(err)
+	}
+	character := &models.Character{ID: "character-1", CharacterName: "Thorin", PlayerName: "Sam", Race: "Dwarf",
+		Class: "Fighter", Level: 3, SecretBackstory: "Heir of Durin", CampaignID: "campaign-1", OwnerID: "user-1"}
+	if err := stores.Characters.Create(character); err != nil {
+		t.Fatal(err)
+	}
+	if err := stores.APIKeys.Create(&models.APIKey{ID: "key-1", Name: "bot", UserID: "user-1", KeyHash: "key-hash", Scopes: []string{models.ScopeRead}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stores.Webhooks.Create(&models.Webhook{ID: "webhook-1", OwnerID: "user-1", URL: "https://example.com/hook", Secret: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestRoundTrip tests that a restore reproduces every record, secrets and timestamps included
+func TestRoundTrip(t *testing.T) {
+	source := newStores()
+	seed(t, source)
+
+	var archive bytes.Buffer
+	manifest, err := Write(&archive, source, time.Now())
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if len(manifest.Collections) != len(collections) {
+		t.Fatalf("Expected %d collections, got %d", len(collections), len(manifest.Collections))
+	}
+	for _, c := range manifest.Collections {
+		if c.Records != 1 || len(c.SHA256) != 64 {
+			t.Errorf("Unexpected manifest entry %+v", c)
+		}
+	}
+
+	read, err := Read(bytes.NewReader(archive.Bytes()))
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	target := newStores()
+	result, err := read.Restore(target)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	for _, c := range result.Collections {
+		if c.Restored != 1 || c.Skipped != 0 {
+			t.Errorf("Expected %s to restore 1 record, got %+v", c.Name, c)
+		}
+	}
+
+	original, _ := source.Characters.Get("character-1")
+	restored, err := target.Characters.Get("character-1")
+	if err != nil {
+		t.Fatalf("Character was not restored: %v", err)
+	}
+	if restored.CharacterName != "Thorin" || restored.CampaignID != "campaign-1" || restored.SecretBackstory != "Heir of Durin" {
+		t.Errorf("Unexpected restored character %+v", restored)
+	}
+	if !restored.CreatedAt.Equal(original.CreatedAt.Truncate(time.Millisecond)) {
+		t.Errorf("Expected creation time %v to be kept, got %v", original.CreatedAt, restored.CreatedAt)
+	}
+
+	user, err := target.Users.GetByUsername("gandalf")
+	if err != nil || user.PasswordHash != "hash" || user.Role != models.RoleAdmin {
+		t.Errorf("Expected the user and password hash to be restored, got %+v (%v)", user, err)
+	}
+	key, err := target.APIKeys.GetByHash("key-hash")
+	if err != nil || key.ID != "key-1" {
+		t.Errorf("Expected the API key hash to be restored, got %+v (%v)", key, err)
+	}
+	webhook, err := target.Webhooks.Get("webhook-1")
+	if err != nil || webhook.Secret != "secret" {
+		t.Errorf("Expected the webhook secret to be restored, got %+v (%v)", webhook, err)
+	}
+	if results, _, _ := target.Characters.Search("thorin", database.ListOptions{Page: 1, Limit: 10}); len(results) != 1 {
+		t.Errorf("Expected the restored character to be searchable, got %d hits", len(results))
+	}
+
+	// Restoring again skips every record
+	result, err = read.Restore(target)
+	if err != nil {
+		t.Fatalf("Second restore failed: %v", err)
+	}
+	for _, c := range result.Collections {
+		if c.Restored != 0 || c.Skipped != 1 {
+			t.Errorf("Expected %s to skip its record, got %+v", c.Name, c)
+		}
+	}
+}
+
+// TestRestoreSkipsTakenUsername tests that a user is not restored over another with the same username
+func TestRestoreSkipsTakenUsername(t *testing.T) {
+	source := newStores()
+	seed(t, source)
+	var archive bytes.Buffer
+	if _, err := Write(&archive, source, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	target := newStores()
+	if err := target.Users.Create(&models.User{ID: "someone-else", Username: "GANDALF"}); err != nil {
+		t.Fatal(err)
+	}
+	read, err := Read(&archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := read.Restore(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if users := result.Collections[0]; users.Name != "users" || users.Skipped != 1 {
+		t.Errorf("Expected the user to be skipped, got %+v", users)
+	}
+	if _, err := target.Users.Get("user-1"); err == nil {
+		t.Error("Expected the user with a taken username not to be restored")
+	}
+}
+
+// rewrite copies an archive, passing each entry through edit
+func rewrite(t *testing.T, archive []byte, edit func(name string, data []byte) []byte) []byte {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := tar.NewReader(gz)
+
+	var out bytes.Buffer
+	outGz := gzip.NewWriter(&out)
+	writer := tar.NewWriter(outGz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(reader)
+		data = edit(header.Name, data)
+		header.Size = int64(len(data))
+		writer.WriteHeader(header)
+		writer.Write(data)
+	}
+	writer.Close()
+	outGz.Close()
+	return out.Bytes()
+}
+
+// TestReadRejects tests that damaged and foreign archives are rejected before anything is restored
+func TestReadRejects(t *testing.T) {
+	source := newStores()
+	seed(t, source)
+	var archive bytes.Buffer
+	if _, err := Write(&archive, source, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		archive []byte
+	}{
+		{"not gzip", []byte("not an archive")},
+		{"tampered record", rewrite(t, archive.Bytes(), func(name string, data []byte) []byte {
+			if name == "characters.jsonl" {
+				return bytes.Replace(data, []byte("Thorin"), []byte("Thrain"), 1)
+			}
+			return data
+		})},
+		{"emptied file", rewrite(t, archive.Bytes(), func(name string, data []byte) []byte {
+			if name == "webhooks.jsonl" {
+				return nil
+			}
+			return data
+		})},
+		{"newer version", rewrite(t, archive.Bytes(), func(name string, data []byte) []byte {
+			if name == manifestName {
+				return bytes.Replace(data, []byte(`"version": 1`), []byte(`"version": 99`), 1)
+			}
+			return data
+		})},
+		{"other format", rewrite(t, archive.Bytes(), func(name string, data []byte) []byte {
+			if name == manifestName {
+				return bytes.Replace(data, []byte(Format), []byte("something-else"), 1)
+			}
+			return data
+		})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(bytes.NewReader(tt.archive))
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("Expected ErrInvalid, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Character event types
+const (
+	CharacterCreated = "character.created"
+	CharacterUpdated = "character.updated"
+	CharacterDeleted = "character.deleted"
+	// CharacterLeveledUp is published alongside CharacterUpdated when an update raises the total level
+	CharacterLeveledUp = "character.leveled_up"
+)
+
+// subscriberBuffer is how many events a slow subscriber may fall behind before events are dropped
+const subscriberBuffer = 64
+
+// Event describes a change to a character
+type Event struct {
+	ID          string            `json:"id" bson:"id"`
+	Type        string            `json:"type" bson:"type"`
+	CharacterID string            `json:"characterId" bson:"characterId"`
+	CampaignID  string            `json:"campaignId,omitempty" bson:"campaignId,omitempty"`
+	Character   *models.Character `json:"character,omitempty" bson:"character,omitempty"`
+	Timestamp   time.Time         `json:"timestamp" bson:"timestamp"`
+}
+
+// NewCharacterEvent builds an event of the given type for a character
+func NewCharacterEvent(eventType string, character *models.Character) Event {
+	snapshot := *character
+	event := Event{
+		ID:          uuid.New().String(),
+		Type:        eventType,
+		CharacterID: character.ID,
+		CampaignID:  character.CampaignID,
+		Timestamp:   time.Now(),
+	}
+	if eventType != CharacterDeleted {
+		event.Character = &snapshot
+	}
+	return event
+}
+
+// Filter selects which events a subscriber receives; empty fields match everything
+type Filter struct {
+	CharacterID string
+	CampaignID  string
+}
+
+// Matches reports whether the event passes the filter
+func (f Filter) Matches(event Event) bool {
+	if f.CharacterID != "" && event.CharacterID != f.CharacterID {
+		return false
+	}
+	if f.CampaignID != "" && event.CampaignID != f.CampaignID {
+		return false
+	}
+	return true
+}
+
+// Publisher accepts character change events
+type Publisher interface {
+	// Publish delivers an event to every matching subscriber
+	Publish(ctx context.Context, event Event) error
+}
+
+// Broker fans character change events out to subscribers
+type Broker interface {
+	Publisher
+	// Subscribe returns a channel of matching events, closed once ctx is done
+	Subscribe(ctx context.Context, filter Filter) (<-chan Event, error)
+}
+
+// MemoryBroker is an in-process Broker. It only reaches subscribers in the
+// same process, so it suits single-replica deployments and tests.
+type MemoryBroker struct {
+	subscribers map[*subscriber]struct{}
+	mutex       sync.RWMutex
+}
+
+// subscriber is a single Subscribe call
+type subscriber struct {
+	filter Filter
+	ch     chan Event
+}
+
+// NewMemoryBroker creates a new in-process broker
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Publish delivers an event to every matching subscriber without blocking;
+// subscribers whose buffer is full miss the event
+func (b *MemoryBroker) Publish(ctx context.Context, event Event) error {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for sub := range b.subscribers {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe registers a subscriber until ctx is done
+func (b *MemoryBroker) Subscribe(ctx context.Context, filter Filter) (<-chan Event, error) {
+	sub := &subscriber{
+		filter: filter,
+		ch:     make(chan Event, subscriberBuffer),
+	}
+
+	b.mutex.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mutex.Lock()
+		delete(b.subscribers, sub)
+		close(sub.ch)
+		b.mutex.Unlock()
+	}()
+
+	return sub.ch, nil
+}
+
+// multiPublisher publishes every event to each of its publishers in turn
+type multiPublisher []Publisher
+
+// Publishers combines several publishers into one. Every publisher sees every
+// event even if an earlier one fails; the errors are joined.
+func Publishers(publishers ...Publisher) Publisher {
+	return multiPublisher(publishers)
+}
+
+// Publish delivers the event to every publisher
+func (m multiPublisher) Publish(ctx context.Context, event Event) error {
+	var errs []error
+	for _, publisher := range m {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+)
+
+// TestMemoryBroker_Filter tests that subscribers only receive matching events
+func TestMemoryBroker_Filter(t *testing.T) {
+	broker := NewMemoryBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	campaignEvents, _ := broker.Subscribe(ctx, Filter{CampaignID: "campaign-1"})
+	characterEvents, _ := broker.Subscribe(ctx, Filter{CharacterID: "character-2"})
+
+	broker.Publish(ctx, NewCharacterEvent(CharacterCreated, &models.Character{ID: "character-1", CampaignID: "campaign-1"}))
+	broker.Publish(ctx, NewCharacterEvent(CharacterUpdated, &models.Character{ID: "character-2"}))
+
+	select {
+	case event := <-campaignEvents:
+		if event.CharacterID != "character-1" || event.Type != CharacterCreated {
+			t.Errorf("Unexpected campaign event: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a campaign event")
+	}
+
+	select {
+	case event := <-characterEvents:
+		if event.CharacterID != "character-2" {
+			t.Errorf("Unexpected character event: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a character event")
+	}
+
+	select {
+	case event := <-campaignEvents:
+		t.Errorf("Campaign subscriber received unrelated event: %+v", event)
+	default:
+	}
+}
+
+// TestMemoryBroker_Unsubscribe tests that cancelling the context closes the subscription
+func TestMemoryBroker_Unsubscribe(t *testing.T) {
+	broker := NewMemoryBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	subscription, _ := broker.Subscribe(ctx, Filter{})
+	cancel()
+
+	select {
+	case _, ok := <-subscription:
+		if ok {
+			t.Error("Expected subscription to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Subscription was not closed")
+	}
+
+	// Publishing after unsubscribe must not panic or block
+	broker.Publish(context.Background(), NewCharacterEvent(CharacterDeleted, &models.Character{ID: "gone"}))
+}
@@ -0,0 +1,111 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// eventRetention is how long published events are kept in MongoDB
+const eventRetention = 24 * time.Hour
+
+// MongoBroker shares events between server replicas through a MongoDB
+// collection. Every replica publishes by inserting into the collection and
+// watches it with a change stream, fanning inserts out to its local
+// subscribers. Change streams require MongoDB to run as a replica set.
+type MongoBroker struct {
+	collection *mongo.Collection
+	local      *MemoryBroker
+	onError    func(error)
+}
+
+// NewMongoBroker creates a broker over the given collection and starts
+// watching it until ctx is done. Watch failures are reported to onError and
+// the watch is restarted.
+func NewMongoBroker(ctx context.Context, database *mongo.Database, collectionName string, onError func(error)) (*MongoBroker, error) {
+	collection := database.Collection(collectionName)
+
+	indexCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Expire old events so the collection does not grow without bound
+	_, err := collection.Indexes().CreateOne(indexCtx, mongo.IndexModel{
+		Keys:    bson.M{"timestamp": 1},
+		Options: options.Index().SetExpireAfterSeconds(int32(eventRetention.Seconds())),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	broker := &MongoBroker{
+		collection: collection,
+		local:      NewMemoryBroker(),
+		onError:    onError,
+	}
+	go broker.watch(ctx)
+
+	return broker, nil
+}
+
+// Publish stores the event; every replica, including this one, delivers it from its change stream
+func (b *MongoBroker) Publish(ctx context.Context, event Event) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := b.collection.InsertOne(ctx, event)
+	return err
+}
+
+// Subscribe returns a channel of matching events, closed once ctx is done
+func (b *MongoBroker) Subscribe(ctx context.Context, filter Filter) (<-chan Event, error) {
+	return b.local.Subscribe(ctx, filter)
+}
+
+// watch follows inserts into the events collection until ctx is done, restarting after failures
+func (b *MongoBroker) watch(ctx context.Context) {
+	pipeline := mongo.Pipeline{bson.D{{Key: "$match", Value: bson.M{"operationType": "insert"}}}}
+
+	for ctx.Err() == nil {
+		stream, err := b.collection.Watch(ctx, pipeline)
+		if err != nil {
+			b.reportAndWait(ctx, err)
+			continue
+		}
+
+		for stream.Next(ctx) {
+			var change struct {
+				FullDocument Event `bson:"fullDocument"`
+			}
+			if err := stream.Decode(&change); err != nil {
+				b.report(err)
+				continue
+			}
+			_ = b.local.Publish(ctx, change.FullDocument)
+		}
+
+		if err := stream.Err(); err != nil && !errors.Is(err, context.Canceled) {
+			b.reportAndWait(ctx, err)
+		}
+		stream.Close(context.Background())
+	}
+}
+
+// report forwards a watch error to the error callback, if any
+func (b *MongoBroker) report(err error) {
+	if b.onError != nil {
+		b.onError(err)
+	}
+}
+
+// reportAndWait reports err and pauses before the watch is retried
+func (b *MongoBroker) reportAndWait(ctx context.Context, err error) {
+	b.report(err)
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+	}
+}
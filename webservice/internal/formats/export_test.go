@@ -0,0 +1,160 @@
+package formats
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	full := models.Character{
+		CharacterName: "Lyra Moonwhisper",
+		PlayerName:    "Sam",
+		Race:          "Elf",
+		Subrace:       "High Elf",
+		Class:         "Wizard",
+		Subclass:      "School of Evocation",
+		Level:         2,
+		Multiclass: []models.MulticlassEntry{
+			{Class: "Fighter", Subclass: "Champion", Level: 3},
+			{Class: "Rogue", Level: 1},
+		},
+		ExperiencePoints: 6500,
+		Background:       "Sage",
+		Alignment:        "Chaotic Good",
+		AbilityScores:    scores(8, 14, 13, 17, 12, 10),
+		Backstory:        "Raised in the archives of Candlekeep.\n\nLeft to find a lost tome & its <author>.",
+		Personality:      "Personality traits: I quote obscure texts.\nIdeals: Knowledge.\nIt is the path to power.\nFlaws: I overlook obvious solutions.",
+		Features:         []string{"Arcane Recovery", "Second Wind"},
+		Notes:            "Owes the Guild 50 gp.",
+	}
+
+	minimal := models.Character{
+		CharacterName: "Old Bram",
+		Race:          "Dwarf",
+		Subrace:       "Hill",
+		Class:         "Fighter",
+		Level:         1,
+		AbilityScores: scores(16, 12, 15, 9, 11, 8),
+		Personality:   "Personality traits: Gruff.",
+	}
+
+	plain := minimal
+	plain.Race, plain.Subrace, plain.Personality = "Human", "", "Gruff but kind."
+
+	for _, format := range Names() {
+		for _, character := range []models.Character{full, minimal, plain} {
+			t.Run(format+"/"+character.CharacterName, func(t *testing.T) {
+				// Stored fields that exports leave out
+				stored := character
+				stored.ID = "char-1"
+				stored.OwnerID = "user-1"
+				stored.CampaignID = "camp-1"
+				stored.SecretBackstory = "Is a spy."
+				stored.DMNotes = "Betrays the party."
+				stored.CreatedAt = time.Now()
+
+				data, err := Export(&stored, format)
+				if err != nil {
+					t.Fatalf("Export: %v", err)
+				}
+				result, err := Import(data, "")
+				if err != nil {
+					t.Fatalf("Import: %v", err)
+				}
+				if result.Format != format {
+					t.Errorf("Expected the export to be detected as %s, got %s", format, result.Format)
+				}
+				if !reflect.DeepEqual(result.Character, character) {
+					t.Errorf("Expected\n%+v\ngot\n%+v", character, result.Character)
+				}
+				if len(result.Warnings) != 0 {
+					t.Errorf("Expected no warnings, got %v", result.Warnings)
+				}
+			})
+		}
+	}
+}
+
+func TestExportDerivedStats(t *testing.T) {
+	character := &models.Character{
+		ID:            "char-1",
+		CharacterName: "Lyra",
+		Race:          "Elf",
+		Class:         "Wizard",
+		Level:         5,
+		Multiclass:    []models.MulticlassEntry{{Class: "Fighter", Level: 1}},
+		AbilityScores: scores(8, 14, 14, 17, 12, 10),
+	}
+	// Wizard: 6 at first level and 4 for the next four; Fighter: 6; Constitution +2 per level
+	const hp = 6 + 4*4 + 6 + 6*2
+
+	data, err := Export(character, "foundry")
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	var actor struct {
+		System struct {
+			Abilities  map[string]struct{ Value int }
+			Attributes struct{ HP struct{ Value, Max int } }
+		}
+		Items []struct {
+			ID     string `json:"_id"`
+			Type   string
+			System struct{ HitDice string }
+		}
+	}
+	if err := json.Unmarshal(data, &actor); err != nil {
+		t.Fatalf("Failed to unmarshal export: %v", err)
+	}
+	if actor.System.Attributes.HP.Max != hp || actor.System.Attributes.HP.Value != hp {
+		t.Errorf("Expected %d hit points, got %+v", hp, actor.System.Attributes.HP)
+	}
+	if actor.System.Abilities["int"].Value != 17 {
+		t.Errorf("Expected intelligence 17, got %+v", actor.System.Abilities)
+	}
+	if actor.Items[0].System.HitDice != "d6" || len(actor.Items[0].ID) != 16 {
+		t.Errorf("Expected a wizard class item with a d6 hit die, got %+v", actor.Items[0])
+	}
+
+	again, _ := Export(character, "foundry")
+	if string(again) != string(data) {
+		t.Error("Expected exporting twice to give the same file")
+	}
+
+	data, err = Export(character, "open5e")
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	var sheet open5eSheet
+	if err := json.Unmarshal(data, &sheet); err != nil {
+		t.Fatalf("Failed to unmarshal export: %v", err)
+	}
+	if sheet.ProficiencyBonus != 3 || sheet.TotalLevel != 6 || sheet.HitPoints != hp {
+		t.Errorf("Expected proficiency +3 at level 6 with %d hit points, got %+v", hp, sheet)
+	}
+	if sheet.AbilityScores["str"] != (open5eAbility{Score: 8, Modifier: -1}) || sheet.Classes[0].HitDice != "5d6" {
+		t.Errorf("Expected derived modifiers and hit dice, got %+v", sheet)
+	}
+}
+
+func TestExportRejectsUnknownFormat(t *testing.T) {
+	if _, err := Export(&models.Character{}, "fightclub"); err == nil {
+		t.Error("Expected an unknown format to be rejected")
+	}
+}
+
+func TestFileName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Lyra Moonwhisper": "lyra-moonwhisper.foundry.json",
+		"  Ö'Bram!! ":      "bram.foundry.json",
+		"":                 "character.foundry.json",
+	} {
+		if got := FileName(&models.Character{CharacterName: name}, "foundry"); got != expected {
+			t.Errorf("FileName(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+// Package formats converts characters between models.Character and the JSON
+// of other tools, reporting what could not be carried over on import. Exports
+// leave out the secret backstory and DM notes.
+package formats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"player-character/internal/models"
+)
+
+// ErrUnknownFormat is returned when a document matches no supported format
+var ErrUnknownFormat = errors.New("unrecognized character format")
+
+// Result is a character mapped from another format
+type Result struct {
+	Character models.Character
+	Format    string
+	// Unmapped lists the paths of values in the document that have no place
+	// in a character, such as hit points or spells. Empty values are left out.
+	Unmapped []string
+	// Warnings describe values that were mapped imperfectly or dropped
+	Warnings []models.ValidationError
+}
+
+// format maps one external format
+type format struct {
+	name string
+	// detect reports whether a decoded document looks like this format
+	detect func(doc *document) bool
+	// mapCharacter fills in the result from the document
+	mapCharacter func(doc *document, result *Result)
+	// export builds the document for a character, to be encoded as JSON
+	export func(character *models.Character) interface{}
+}
+
+// formats lists the supported formats in detection order
+var formats = []format{
+	{name: "foundry", detect: detectFoundry, mapCharacter: mapFoundry, export: exportFoundry},
+	{name: "open5e", detect: detectOpen5e, mapCharacter: mapOpen5e, export: exportOpen5e},
+}
+
+// personalityTraits are the parts of a personality, which Character.Personality
+// holds as labeled lines such as "Ideals: Knowledge."
+var personalityTraits = []struct{ key, label string }{
+	{"trait", "Personality traits"},
+	{"ideal", "Ideals"},
+	{"bond", "Bonds"},
+	{"flaw", "Flaws"},
+}
+
+// Names returns the supported format names
+func Names() []string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = f.name
+	}
+	return names
+}
+
+// lookupFormat returns the format with the name
+func lookupFormat(name string) (*format, error) {
+	for i := range formats {
+		if formats[i].name == name {
+			return &formats[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown format %q (expected one of %s)", name, strings.Join(Names(), ", "))
+}
+
+// Import maps a JSON document to a character. With an empty name the format
+// is detected from the document's shape.
+func Import(data []byte, name string) (*Result, error) {
+	var root interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if _, ok := root.(map[string]interface{}); !ok {
+		return nil, errors.New("expected a JSON object")
+	}
+	doc := &document{root: root, used: map[string]bool{}}
+
+	var chosen *format
+	if name != "" {
+		f, err := lookupFormat(name)
+		if err != nil {
+			return nil, err
+		}
+		chosen = f
+	}
+	for i := range formats {
+		if chosen == nil && formats[i].detect(doc) {
+			chosen = &formats[i]
+		}
+	}
+	if chosen == nil {
+		return nil, ErrUnknownFormat
+	}
+
+	result := &Result{Format: chosen.name}
+	chosen.mapCharacter(doc, result)
+	result.Unmapped = append(doc.unmapped(), result.Unmapped...)
+	if result.Unmapped == nil {
+		result.Unmapped = []string{}
+	}
+	sort.Strings(result.Unmapped)
+	if result.Warnings == nil {
+		result.Warnings = []models.ValidationError{}
+	}
+	return result, nil
+}
+
+// Export encodes a character in the named format
+func Export(character *models.Character, name string) ([]byte, error) {
+	f, err := lookupFormat(name)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(f.export(character), "", "  ")
+}
+
+// FileName suggests a file name for a character exported in the named format
+func FileName(character *models.Character, name string) string {
+	return character.Slug() + "." + name + ".json"
+}
+
+// warn records a value that could not be mapped as it was
+func (r *Result) warn(field, code, message string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, models.ValidationError{Field: field, Message: fmt.Sprintf(message, args...), Code: code})
+}
+
+// document is decoded JSON that remembers which values were read, so that
+// the rest can be reported as unmapped
+type document struct {
+	root interface{}
+	used map[string]bool
+}
+
+// lookup returns the value at a dotted path, where numbers index arrays, and marks it read
+func (d *document) lookup(path string) (interface{}, bool) {
+	value := d.root
+	for _, segment := range strings.Split(path, ".") {
+		switch node := value.(type) {
+		case map[string]interface{}:
+			next, ok := node[segment]
+			if !ok {
+				return nil, false
+			}
+			value = next
+		case []interface{}:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			value = node[i]
+		default:
+			return nil, false
+		}
+	}
+	d.used[path] = true
+	return value, true
+}
+
+// peek returns the value at a path without marking it read
+func (d *document) peek(path string) (interface{}, bool) {
+	read := d.used[path]
+	value, ok := d.lookup(path)
+	d.used[path] = read
+	return value, ok
+}
+
+// has reports whether a path exists, without marking it read
+func (d *document) has(path string) bool {
+	_, ok := d.peek(path)
+	return ok
+}
+
+// first returns the first of several alternative paths that exists
+func (d *document) first(paths ...string) string {
+	for _, path := range paths {
+		if d.has(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// string returns the text at a path, or "" when it is missing or not text
+func (d *document) string(path string) string {
+	value, _ := d.lookup(path)
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+// int returns the whole number at a path, accepting numeric strings
+func (d *document) int(path string) (int, bool) {
+	value, _ := d.lookup(path)
+	switch v := value.(type) {
+	case float64:
+		return int(v), true
+	case string:
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// array returns the array at a path. Its items still count as unread until
+// they are looked up themselves.
+func (d *document) array(path string) []interface{} {
+	value, _ := d.peek(path)
+	array, _ := value.([]interface{})
+	return array
+}
+
+// object returns the object at a path. Its fields still count as unread
+// until they are looked up themselves.
+func (d *document) object(path string) map[string]interface{} {
+	value, _ := d.peek(path)
+	object, _ := value.(map[string]interface{})
+	return object
+}
+
+// ignore marks paths as read without using them, for metadata that has no meaning in a character
+func (d *document) ignore(paths ...string) {
+	for _, path := range paths {
+		d.used[path] = true
+	}
+}
+
+// unmapped returns the paths of non-empty values that were never read. A
+// container none of whose contents were read is reported as a whole.
+func (d *document) unmapped() []string {
+	paths, _ := d.walk("", d.root)
+	return paths
+}
+
+// walk returns the unread paths under a value, and whether none of it was read
+func (d *document) walk(path string, value interface{}) ([]string, bool) {
+	if path != "" && d.used[path] {
+		return nil, false
+	}
+
+	var children map[string]interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		children = v
+	case []interface{}:
+		children = make(map[string]interface{}, len(v))
+		for i, item := range v {
+			children[strconv.Itoa(i)] = item
+		}
+	default:
+		if isEmpty(value) {
+			return nil, true
+		}
+		return []string{path}, true
+	}
+
+	var paths []string
+	untouched := true
+	for key, child := range children {
+		childPath := key
+		if path != "" {
+			childPath = path + "." + key
+		}
+		childPaths, childUntouched := d.walk(childPath, child)
+		paths = append(paths, childPaths...)
+		untouched = untouched && childUntouched
+	}
+	if untouched && len(paths) > 0 && path != "" {
+		return []string{path}, true
+	}
+	return paths, untouched
+}
+
+// isEmpty reports whether a JSON value is null, false, zero or blank
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return strings.TrimSpace(v) == ""
+	}
+	return false
+}
+
+// joinPersonality labels each part of a personality on its own line. A
+// personality traits part alone is kept as it is.
+func joinPersonality(parts map[string]string) string {
+	if len(parts) > 0 && parts["ideal"] == "" && parts["bond"] == "" && parts["flaw"] == "" {
+		return parts["trait"]
+	}
+	var lines []string
+	for _, trait := range personalityTraits {
+		if text := parts[trait.key]; text != "" {
+			lines = append(lines, trait.label+": "+text)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitPersonality undoes joinPersonality. Text that is not labeled
+// throughout is returned whole as the personality traits.
+func splitPersonality(personality string) map[string]string {
+	parts := map[string]string{}
+	key := ""
+	for _, line := range strings.Split(personality, "\n") {
+		labeled := false
+		for _, trait := range personalityTraits {
+			if text, ok := strings.CutPrefix(line, trait.label+": "); ok && parts[trait.key] == "" {
+				key, labeled = trait.key, true
+				parts[key] = text
+				break
+			}
+		}
+		switch {
+		case labeled:
+		case key == "":
+			return map[string]string{"trait": personality}
+		default:
+			parts[key] += "\n" + line
+		}
+	}
+	if parts["ideal"] == "" && parts["bond"] == "" && parts["flaw"] == "" {
+		return map[string]string{"trait": personality}
+	}
+	return parts
+}
+
+// multiclassEntry builds a multiclass entry
+func multiclassEntry(class, subclass string, level int) models.MulticlassEntry {
+	return models.MulticlassEntry{Class: class, Subclass: subclass, Level: level}
+}
+
+// setAbility sets an ability score by its JSON name
+func setAbility(scores *models.AbilityScores, ability string, score int) {
+	switch ability {
+	case "strength":
+		scores.Strength.Base = score
+	case "dexterity":
+		scores.Dexterity.Base = score
+	case "constitution":
+		scores.Constitution.Base = score
+	case "intelligence":
+		scores.Intelligence.Base = score
+	case "wisdom":
+		scores.Wisdom.Base = score
+	case "charisma":
+		scores.Charisma.Base = score
+	}
+}
@@ -0,0 +1,193 @@
+package formats
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"player-character/internal/models"
+	"player-character/internal/validation"
+)
+
+func importFile(t *testing.T, name, format string) *Result {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+	result, err := Import(data, format)
+	if err != nil {
+		t.Fatalf("Import %s: %v", name, err)
+	}
+	return result
+}
+
+func scores(str, dex, con, intelligence, wis, cha int) models.AbilityScores {
+	return models.AbilityScores{
+		Strength:     models.AbilityScore{Base: str},
+		Dexterity:    models.AbilityScore{Base: dex},
+		Constitution: models.AbilityScore{Base: con},
+		Intelligence: models.AbilityScore{Base: intelligence},
+		Wisdom:       models.AbilityScore{Base: wis},
+		Charisma:     models.AbilityScore{Base: cha},
+	}
+}
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		file     string
+		format   string
+		expected models.Character
+		unmapped []string
+	}{
+		{
+			file:   "foundry.json",
+			format: "foundry",
+			expected: models.Character{
+				CharacterName:    "Lyra Moonwhisper",
+				Race:             "Elf",
+				Subrace:          "High Elf",
+				Class:            "Wizard",
+				Subclass:         "School of Evocation",
+				Level:            5,
+				Multiclass:       []models.MulticlassEntry{{Class: "Fighter", Level: 1}},
+				ExperiencePoints: 6500,
+				Background:       "Sage",
+				Alignment:        "Chaotic Good",
+				AbilityScores:    scores(8, 14, 13, 17, 12, 10),
+				Backstory:        "Raised in the archives of Candlekeep.\nLeft to find a lost tome & its author.",
+				Personality:      "Personality traits: I quote obscure texts.\nIdeals: Knowledge.\nFlaws: I overlook obvious solutions.",
+				Features:         []string{"Arcane Recovery"},
+			},
+			unmapped: []string{
+				"items.6 (spell: Fireball)",
+				"items.7 (weapon: Quarterstaff)",
+				"system.abilities.int.proficient",
+				"system.abilities.wis.proficient",
+				"system.attributes",
+				"system.currency",
+				"system.skills",
+			},
+		},
+		{
+			file:   "foundry_legacy.json",
+			format: "foundry",
+			expected: models.Character{
+				CharacterName:    "Old Bram",
+				Race:             "Dwarf",
+				Subrace:          "Hill Dwarf",
+				Class:            "Fighter",
+				Subclass:         "Champion",
+				Level:            3,
+				ExperiencePoints: 900,
+				Background:       "Soldier",
+				Alignment:        "Lawful Neutral",
+				AbilityScores:    scores(16, 12, 15, 9, 11, 8),
+			},
+			unmapped: []string{},
+		},
+		{
+			file:   "open5e.json",
+			format: "open5e",
+			expected: models.Character{
+				CharacterName:    "Pip Tumblebrook",
+				PlayerName:       "Sam",
+				Race:             "Halfling",
+				Subrace:          "Lightfoot Halfling",
+				Class:            "Rogue",
+				Subclass:         "Thief",
+				Level:            4,
+				Multiclass:       []models.MulticlassEntry{{Class: "Bard", Level: 1}},
+				ExperiencePoints: 2700,
+				Background:       "Urchin",
+				Alignment:        "Chaotic Neutral",
+				AbilityScores:    scores(8, 17, 12, 13, 10, 14),
+				Features:         []string{"Sneak Attack", "Cunning Action"},
+				Personality:      "Personality traits: Fidgety.\nFlaws: Can't resist a locked door.",
+				Backstory:        "Grew up on the docks.",
+				Notes:            "Owes the Guild 50 gp.",
+			},
+			unmapped: []string{
+				"ability_scores.dexterity.modifier",
+				"classes.0.hit_dice",
+				"features.1.source",
+				"hit_points",
+				"speed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			// Detected and named formats map the same way
+			for _, format := range []string{"", tt.format} {
+				result := importFile(t, tt.file, format)
+				if result.Format != tt.format {
+					t.Errorf("Expected format %s, got %s", tt.format, result.Format)
+				}
+				if !reflect.DeepEqual(result.Character, tt.expected) {
+					t.Errorf("Expected\n%+v\ngot\n%+v", tt.expected, result.Character)
+				}
+				if !reflect.DeepEqual(result.Unmapped, tt.unmapped) {
+					t.Errorf("Expected unmapped %q, got %q", tt.unmapped, result.Unmapped)
+				}
+				if len(result.Warnings) != 0 {
+					t.Errorf("Expected no warnings, got %v", result.Warnings)
+				}
+				if errs := validation.ValidateCharacter(&result.Character); len(errs) != 0 {
+					t.Errorf("Expected a valid character, got %v", errs)
+				}
+			}
+		})
+	}
+}
+
+func TestImportWarnings(t *testing.T) {
+	result, err := Import([]byte(`{"name": "Zed", "race": "Warforged", "class": "Artificer", "level": 2, "alignment": "Unaligned"}`), "")
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	fields := map[string]bool{}
+	for _, w := range result.Warnings {
+		fields[w.Field] = true
+	}
+	for _, field := range []string{"race", "class", "alignment", "abilityScores"} {
+		if !fields[field] {
+			t.Errorf("Expected a warning for %s, got %v", field, result.Warnings)
+		}
+	}
+	if result.Character.Race != "Warforged" || result.Character.Alignment != "" {
+		t.Errorf("Expected unknown races kept and unknown alignments dropped, got %+v", result.Character)
+	}
+}
+
+func TestImportRejects(t *testing.T) {
+	for _, tt := range []struct {
+		data   string
+		format string
+	}{
+		{`not json`, ""},
+		{`[1, 2]`, ""},
+		{`{"hello": "world"}`, ""},
+		{`{"name": "Zed", "race": "Elf"}`, "fightclub"},
+	} {
+		if _, err := Import([]byte(tt.data), tt.format); err == nil {
+			t.Errorf("Expected %s (format %q) to be rejected", tt.data, tt.format)
+		}
+	}
+}
+
+func TestNormalizeAlignment(t *testing.T) {
+	for input, expected := range map[string]string{
+		"CG":               "Chaotic Good",
+		"n":                "True Neutral",
+		"Neutral":          "True Neutral",
+		"lawful-evil":      "Lawful Evil",
+		"  neutral  good ": "Neutral Good",
+	} {
+		if got, ok := normalizeAlignment(input); !ok || got != expected {
+			t.Errorf("normalizeAlignment(%q) = %q, %v; expected %q", input, got, ok, expected)
+		}
+	}
+}
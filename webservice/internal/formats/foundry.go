@@ -0,0 +1,357 @@
+package formats
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"player-character/internal/models"
+)
+
+// Foundry VTT dnd5e actor exports ("Export Data" on a character). Version 10
+// and later keep the game data under "system"; earlier versions under "data".
+// Classes, subclasses, the race, the background and features are embedded
+// items; everything else about the actor (hit points, skills, spells,
+// inventory) has no place in a character and is reported as unmapped.
+//
+// Our own exports keep what Foundry has no field for (the player name, notes
+// and a subrace the race item cannot name) in the actor's flags under
+// foundryFlagScope, and are read back from there.
+
+// foundryFlagScope is the flags key our exports keep extra fields under
+const foundryFlagScope = "player-character"
+
+// foundryAbilities maps Foundry's ability keys to our ability score fields
+var foundryAbilities = []struct{ key, field string }{
+	{"str", "strength"},
+	{"dex", "dexterity"},
+	{"con", "constitution"},
+	{"int", "intelligence"},
+	{"wis", "wisdom"},
+	{"cha", "charisma"},
+}
+
+// foundryMetadata lists the parts of an export that describe the document rather than the character
+var foundryMetadata = []string{"_id", "_stats", "flags", "folder", "sort", "ownership", "permission", "img", "type", "prototypeToken", "token"}
+
+func detectFoundry(doc *document) bool {
+	return doc.first("system.abilities", "data.abilities") != "" && doc.has("items")
+}
+
+// foundryClass is a class item with the subclass found for it
+type foundryClass struct {
+	id         string
+	name       string
+	identifier string
+	subclass   string
+	levels     int
+}
+
+func mapFoundry(doc *document, result *Result) {
+	c := &result.Character
+	doc.ignore(foundryMetadata...)
+
+	system := "system"
+	if !doc.has("system") {
+		system = "data"
+	}
+
+	c.CharacterName = doc.string("name")
+	flags := "flags." + foundryFlagScope
+	c.PlayerName = doc.string(flags + ".playerName")
+	c.Notes = doc.string(flags + ".notes")
+
+	for _, ability := range foundryAbilities {
+		path := fmt.Sprintf("%s.abilities.%s.value", system, ability.key)
+		score, ok := doc.int(path)
+		if !ok {
+			result.warn("abilityScores."+ability.field, "MISSING_VALUE", "No %s score in the export", ability.field)
+			continue
+		}
+		setAbility(&c.AbilityScores, ability.field, score)
+	}
+
+	details := system + ".details"
+	if xp, ok := doc.int(details + ".xp.value"); ok {
+		c.ExperiencePoints = xp
+	}
+	if alignment := doc.string(details + ".alignment"); alignment != "" {
+		if normalized, ok := normalizeAlignment(alignment); ok {
+			c.Alignment = normalized
+		} else {
+			result.warn("alignment", "UNRECOGNIZED_VALUE", "Alignment %q is not recognized and was left out", alignment)
+		}
+	}
+	c.Backstory = plainText(doc.string(details + ".biography.value"))
+
+	personality := map[string]string{}
+	for _, trait := range personalityTraits {
+		personality[trait.key] = plainText(doc.string(details + "." + trait.key))
+	}
+	c.Personality = joinPersonality(personality)
+
+	// Race and background were plain text before they became items
+	race := doc.string(details + ".race")
+	background := doc.string(details + ".background")
+
+	var classes []foundryClass
+	subclasses := map[string]string{} // class identifier -> subclass name
+	for i, value := range doc.array("items") {
+		item, _ := value.(map[string]interface{})
+		if item == nil {
+			continue
+		}
+		path := fmt.Sprintf("items.%d", i)
+		itemSystem := path + ".system"
+		if !doc.has(itemSystem) {
+			itemSystem = path + ".data"
+		}
+
+		name := doc.string(path + ".name")
+		kind := doc.string(path + ".type")
+		switch kind {
+		case "class":
+			levels, _ := doc.int(itemSystem + ".levels")
+			identifier := doc.string(itemSystem + ".identifier")
+			if identifier == "" {
+				identifier = foundryIdentifier(name)
+			}
+			classes = append(classes, foundryClass{
+				id:         doc.string(path + "._id"),
+				name:       name,
+				identifier: identifier,
+				subclass:   doc.string(itemSystem + ".subclass"),
+				levels:     levels,
+			})
+		case "subclass":
+			subclasses[doc.string(itemSystem+".classIdentifier")] = name
+		case "race":
+			race = name
+		case "background":
+			background = name
+		case "feat":
+			c.Features = append(c.Features, name)
+		default:
+			// Report other items (spells, weapons, gear) by name rather than by their contents
+			result.Unmapped = append(result.Unmapped, fmt.Sprintf("%s (%s: %s)", path, kind, name))
+		}
+		doc.ignore(path)
+	}
+
+	if race != "" {
+		if normalized, subrace, ok := normalizeRace(race); ok {
+			c.Race, c.Subrace = normalized, subrace
+		} else {
+			c.Race = race
+			result.warn("race", "UNRECOGNIZED_VALUE", "Race %q is not a supported race", race)
+		}
+	}
+	if subrace := doc.string(flags + ".subrace"); subrace != "" {
+		c.Subrace = subrace
+	}
+	c.Background = background
+
+	mapFoundryClasses(classes, subclasses, doc.string(details+".originalClass"), result)
+}
+
+// mapFoundryClasses makes the actor's original class, or else the class with
+// the most levels, the primary class and the rest multiclasses
+func mapFoundryClasses(classes []foundryClass, subclasses map[string]string, originalClass string, result *Result) {
+	c := &result.Character
+	if len(classes) == 0 {
+		result.warn("class", "MISSING_VALUE", "The export has no class item")
+		return
+	}
+
+	sort.SliceStable(classes, func(i, j int) bool {
+		iOriginal, jOriginal := originalClass != "" && classes[i].id == originalClass, originalClass != "" && classes[j].id == originalClass
+		if iOriginal != jOriginal {
+			return iOriginal
+		}
+		return classes[i].levels > classes[j].levels
+	})
+	for i, class := range classes {
+		name, ok := normalizeClass(class.name)
+		if !ok {
+			name = class.name
+			result.warn("class", "UNRECOGNIZED_VALUE", "Class %q is not a supported class", class.name)
+		}
+		subclass := class.subclass
+		if s, ok := subclasses[class.identifier]; ok {
+			subclass = s
+		}
+
+		if i == 0 {
+			c.Class, c.Subclass, c.Level = name, subclass, class.levels
+			continue
+		}
+		c.Multiclass = append(c.Multiclass, multiclassEntry(name, subclass, class.levels))
+	}
+}
+
+// foundryIdentifier derives a class identifier from its name, as Foundry does
+func foundryIdentifier(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "-")
+}
+
+// foundryActor is the part of a dnd5e character actor that exports fill in
+type foundryActor struct {
+	Name   string                            `json:"name"`
+	Type   string                            `json:"type"`
+	Img    string                            `json:"img"`
+	System foundrySystem                     `json:"system"`
+	Items  []foundryItem                     `json:"items"`
+	Flags  map[string]map[string]interface{} `json:"flags"`
+}
+
+type foundrySystem struct {
+	Abilities  map[string]foundryAbility `json:"abilities"`
+	Attributes foundryAttributes         `json:"attributes"`
+	Details    foundryDetails            `json:"details"`
+}
+
+type foundryAbility struct {
+	Value      int `json:"value"`
+	Proficient int `json:"proficient"`
+}
+
+type foundryAttributes struct {
+	HP foundryHP `json:"hp"`
+}
+
+type foundryHP struct {
+	Value int `json:"value"`
+	Max   int `json:"max"`
+}
+
+type foundryDetails struct {
+	Alignment     string           `json:"alignment"`
+	Biography     foundryBiography `json:"biography"`
+	Trait         string           `json:"trait"`
+	Ideal         string           `json:"ideal"`
+	Bond          string           `json:"bond"`
+	Flaw          string           `json:"flaw"`
+	XP            map[string]int   `json:"xp"`
+	OriginalClass string           `json:"originalClass"`
+}
+
+type foundryBiography struct {
+	Value  string `json:"value"`
+	Public string `json:"public"`
+}
+
+type foundryItem struct {
+	ID     string                 `json:"_id"`
+	Name   string                 `json:"name"`
+	Type   string                 `json:"type"`
+	System map[string]interface{} `json:"system"`
+}
+
+// exportFoundry builds a dnd5e actor, with hit points worked out from the
+// classes and Constitution since Foundry does not derive the maximum itself
+func exportFoundry(c *models.Character) interface{} {
+	actor := foundryActor{
+		Name: c.CharacterName,
+		Type: "character",
+		Img:  "icons/svg/mystery-man.svg",
+		System: foundrySystem{
+			Abilities: map[string]foundryAbility{},
+			Details: foundryDetails{
+				Alignment: c.Alignment,
+				Biography: foundryBiography{Value: htmlParagraphs(c.Backstory)},
+				XP:        map[string]int{"value": c.ExperiencePoints},
+			},
+		},
+		Items: []foundryItem{},
+		Flags: map[string]map[string]interface{}{},
+	}
+	hp := c.MaxHitPoints()
+	actor.System.Attributes.HP = foundryHP{Value: hp, Max: hp}
+
+	for _, ability := range foundryAbilities {
+		score, _ := c.AbilityScores.Get(ability.field)
+		actor.System.Abilities[ability.key] = foundryAbility{Value: score.Base}
+	}
+
+	personality := splitPersonality(c.Personality)
+	details := &actor.System.Details
+	details.Trait, details.Ideal, details.Bond, details.Flaw = personality["trait"], personality["ideal"], personality["bond"], personality["flaw"]
+
+	// Item IDs are derived from the character so that exporting twice gives the same file
+	item := func(kind, name string, system map[string]interface{}) foundryItem {
+		i := foundryItem{ID: foundryItemID(c.ID, len(actor.Items)), Name: name, Type: kind, System: system}
+		actor.Items = append(actor.Items, i)
+		return i
+	}
+	addClass := func(class, subclass string, level int) string {
+		identifier := foundryIdentifier(class)
+		id := item("class", class, map[string]interface{}{
+			"identifier":  identifier,
+			"levels":      level,
+			"hitDice":     fmt.Sprintf("d%d", models.HitDie(class)),
+			"hitDiceUsed": 0,
+		}).ID
+		if subclass != "" {
+			item("subclass", subclass, map[string]interface{}{
+				"identifier":      foundryIdentifier(subclass),
+				"classIdentifier": identifier,
+			})
+		}
+		return id
+	}
+	details.OriginalClass = addClass(c.Class, c.Subclass, c.Level)
+	for _, mc := range c.Multiclass {
+		addClass(mc.Class, mc.Subclass, mc.Level)
+	}
+
+	if c.Race != "" {
+		// A race item is named for the subrace, as in "High Elf", when that also names the race
+		name := c.Race
+		if c.Subrace != "" && containsWord(c.Subrace, c.Race) {
+			name = c.Subrace
+		}
+		item("race", name, map[string]interface{}{"identifier": foundryIdentifier(name)})
+	}
+	if c.Background != "" {
+		item("background", c.Background, map[string]interface{}{"identifier": foundryIdentifier(c.Background)})
+	}
+	for _, feature := range c.Features {
+		item("feat", feature, map[string]interface{}{"description": map[string]string{"value": ""}})
+	}
+
+	flags := map[string]interface{}{}
+	if c.PlayerName != "" {
+		flags["playerName"] = c.PlayerName
+	}
+	if c.Notes != "" {
+		flags["notes"] = c.Notes
+	}
+	if c.Subrace != "" && !containsWord(c.Subrace, c.Race) {
+		flags["subrace"] = c.Subrace
+	}
+	if len(flags) > 0 {
+		actor.Flags[foundryFlagScope] = flags
+	}
+	return actor
+}
+
+// foundryItemID derives a 16 character document ID, the length Foundry uses
+func foundryItemID(characterID string, index int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%d", characterID, index)))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// htmlParagraphs turns plain text into the HTML of a rich text field, a paragraph per line
+func htmlParagraphs(text string) string {
+	if text == "" {
+		return ""
+	}
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString("<p>" + html.EscapeString(line) + "</p>")
+	}
+	return b.String()
+}
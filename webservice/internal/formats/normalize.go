@@ -0,0 +1,83 @@
+package formats
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"player-character/internal/validation"
+)
+
+// normalizeRace splits a race such as "High Elf" into a supported race and
+// subrace. It returns ok false when no supported race is named.
+func normalizeRace(name string) (race, subrace string, ok bool) {
+	// Prefer the longest match, so that "Half-Elf" is not read as "Elf"
+	for _, candidate := range validation.Races() {
+		if containsWord(name, candidate) && len(candidate) > len(race) {
+			race = candidate
+		}
+	}
+	if race == "" {
+		return "", "", false
+	}
+	if !strings.EqualFold(strings.TrimSpace(name), race) {
+		subrace = strings.TrimSpace(name)
+	}
+	return race, subrace, true
+}
+
+// normalizeClass returns the supported class with the name, ignoring case
+func normalizeClass(name string) (string, bool) {
+	for _, class := range validation.Classes() {
+		if strings.EqualFold(strings.TrimSpace(name), class) {
+			return class, true
+		}
+	}
+	return "", false
+}
+
+// alignmentAbbreviations maps the usual two-letter alignments
+var alignmentAbbreviations = map[string]string{
+	"lg": "Lawful Good", "ng": "Neutral Good", "cg": "Chaotic Good",
+	"ln": "Lawful Neutral", "n": "True Neutral", "tn": "True Neutral", "cn": "Chaotic Neutral",
+	"le": "Lawful Evil", "ne": "Neutral Evil", "ce": "Chaotic Evil",
+	"neutral": "True Neutral",
+}
+
+// normalizeAlignment returns the supported alignment a name refers to
+func normalizeAlignment(name string) (string, bool) {
+	name = strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(name, "-", " "))), " ")
+	if alignment, ok := alignmentAbbreviations[name]; ok {
+		return alignment, true
+	}
+	for _, alignment := range validation.Alignments() {
+		if strings.ToLower(alignment) == name {
+			return alignment, true
+		}
+	}
+	return "", false
+}
+
+// containsWord reports whether text contains word, ignoring case, not as part of a longer word
+func containsWord(text, word string) bool {
+	pattern := `(?i)(^|[^\pL-])` + regexp.QuoteMeta(word) + `($|[^\pL-])`
+	return regexp.MustCompile(pattern).MatchString(text)
+}
+
+var (
+	blockTags = regexp.MustCompile(`(?i)<\s*(br|/p|/div|/li|/h[1-6])\s*/?>`)
+	anyTag    = regexp.MustCompile(`<[^>]*>`)
+	blankRuns = regexp.MustCompile(`\n{3,}`)
+)
+
+// plainText converts the HTML of rich text fields to plain text with line breaks
+func plainText(text string) string {
+	text = blockTags.ReplaceAllString(text, "\n")
+	text = anyTag.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(blankRuns.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
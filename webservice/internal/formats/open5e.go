@@ -0,0 +1,248 @@
+package formats
+
+import (
+	"fmt"
+
+	"player-character/internal/models"
+)
+
+// The open 5e format is the flat JSON character sheet written by open-source
+// 5e character builders:
+//
+//	{
+//	  "name": "Lyra", "player": "Sam",
+//	  "race": "High Elf", "background": "Sage", "alignment": "CG", "experience": 6500,
+//	  "classes": [{"name": "Wizard", "subclass": "School of Evocation", "level": 5}],
+//	  "ability_scores": {"str": 8, "dex": 14, "con": 13, "int": 17, "wis": 12, "cha": 10},
+//	  "features": ["Arcane Recovery", {"name": "Sculpt Spells"}],
+//	  "personality_traits": "...", "ideals": "...", "bonds": "...", "flaws": "...",
+//	  "backstory": "...", "notes": "..."
+//	}
+//
+// Tools disagree on details, so keys may be snake_case or camelCase, a single
+// "class" and "level" may stand in for "classes", and ability scores may be
+// numbers or objects with a "score", "value" or "base".
+
+// open5eAbilities lists the keys each ability score may be found under
+var open5eAbilities = []struct {
+	field string
+	keys  []string
+}{
+	{"strength", []string{"str", "strength"}},
+	{"dexterity", []string{"dex", "dexterity"}},
+	{"constitution", []string{"con", "constitution"}},
+	{"intelligence", []string{"int", "intelligence"}},
+	{"wisdom", []string{"wis", "wisdom"}},
+	{"charisma", []string{"cha", "charisma"}},
+}
+
+func detectOpen5e(doc *document) bool {
+	return doc.first("name", "character_name", "characterName") != "" &&
+		doc.first("race", "classes", "class") != ""
+}
+
+func mapOpen5e(doc *document, result *Result) {
+	c := &result.Character
+	text := func(paths ...string) string {
+		if path := doc.first(paths...); path != "" {
+			return doc.string(path)
+		}
+		return ""
+	}
+
+	c.CharacterName = text("name", "character_name", "characterName")
+	c.PlayerName = text("player", "player_name", "playerName")
+	c.Background = text("background")
+
+	// The race may be a name or an object with the subrace
+	race, subrace := text("race"), text("subrace")
+	if doc.object("race") != nil {
+		race, subrace = text("race.name"), text("race.subrace")
+	}
+	if race != "" {
+		if normalized, named, ok := normalizeRace(race); ok {
+			c.Race, c.Subrace = normalized, named
+		} else {
+			c.Race = race
+			result.warn("race", "UNRECOGNIZED_VALUE", "Race %q is not a supported race", race)
+		}
+		if subrace != "" {
+			c.Subrace = subrace
+		}
+	}
+
+	if alignment := text("alignment"); alignment != "" {
+		if normalized, ok := normalizeAlignment(alignment); ok {
+			c.Alignment = normalized
+		} else {
+			result.warn("alignment", "UNRECOGNIZED_VALUE", "Alignment %q is not recognized and was left out", alignment)
+		}
+	}
+	if path := doc.first("experience", "xp", "experience_points", "experiencePoints"); path != "" {
+		c.ExperiencePoints, _ = doc.int(path)
+	}
+
+	mapOpen5eClasses(doc, result)
+	mapOpen5eAbilities(doc, result)
+
+	if path := doc.first("features", "traits"); path != "" {
+		for i, feature := range doc.array(path) {
+			featurePath := fmt.Sprintf("%s.%d", path, i)
+			if _, ok := feature.(map[string]interface{}); ok {
+				featurePath += ".name"
+			}
+			if name := doc.string(featurePath); name != "" {
+				c.Features = append(c.Features, name)
+			}
+		}
+	}
+
+	c.Personality = joinPersonality(map[string]string{
+		"trait": text("personality_traits", "personalityTraits", "personality"),
+		"ideal": text("ideals"),
+		"bond":  text("bonds"),
+		"flaw":  text("flaws"),
+	})
+	c.Backstory = text("backstory", "biography")
+	c.Notes = text("notes")
+}
+
+// mapOpen5eClasses reads a list of classes, or a single class and level
+func mapOpen5eClasses(doc *document, result *Result) {
+	c := &result.Character
+	classes := doc.array("classes")
+	if classes == nil {
+		if name := doc.string("class"); name != "" {
+			level, _ := doc.int("level")
+			classes = []interface{}{map[string]interface{}{"name": name, "subclass": doc.string("subclass"), "level": float64(level)}}
+		}
+	}
+	if len(classes) == 0 {
+		result.warn("class", "MISSING_VALUE", "The sheet has no class")
+		return
+	}
+
+	for i, value := range classes {
+		entry, _ := value.(map[string]interface{})
+		name, _ := entry["name"].(string)
+		subclass, _ := entry["subclass"].(string)
+		level, _ := entry["level"].(float64)
+		doc.ignore(fmt.Sprintf("classes.%d.name", i), fmt.Sprintf("classes.%d.subclass", i), fmt.Sprintf("classes.%d.level", i))
+
+		normalized, ok := normalizeClass(name)
+		if !ok {
+			normalized = name
+			result.warn("class", "UNRECOGNIZED_VALUE", "Class %q is not a supported class", name)
+		}
+		if i == 0 {
+			c.Class, c.Subclass, c.Level = normalized, subclass, int(level)
+			continue
+		}
+		c.Multiclass = append(c.Multiclass, multiclassEntry(normalized, subclass, int(level)))
+	}
+}
+
+// mapOpen5eAbilities reads the ability scores, as numbers or objects
+func mapOpen5eAbilities(doc *document, result *Result) {
+	scores := doc.first("ability_scores", "abilityScores", "abilities", "stats")
+	if scores == "" {
+		result.warn("abilityScores", "MISSING_VALUE", "The sheet has no ability scores")
+		return
+	}
+
+	for _, ability := range open5eAbilities {
+		path := ""
+		for _, key := range ability.keys {
+			if p := scores + "." + key; doc.has(p) {
+				path = p
+				break
+			}
+		}
+		if path == "" {
+			result.warn("abilityScores."+ability.field, "MISSING_VALUE", "No %s score on the sheet", ability.field)
+			continue
+		}
+		if doc.object(path) != nil {
+			path = doc.first(path+".score", path+".value", path+".base")
+		}
+		score, ok := doc.int(path)
+		if !ok {
+			result.warn("abilityScores."+ability.field, "MISSING_VALUE", "The %s score is not a number", ability.field)
+			continue
+		}
+		setAbility(&result.Character.AbilityScores, ability.field, score)
+	}
+}
+
+// open5eSheet is the sheet open 5e exports write, with the derived stats
+// builders show alongside the scores
+type open5eSheet struct {
+	Name              string                   `json:"name"`
+	Player            string                   `json:"player,omitempty"`
+	Race              open5eRace               `json:"race"`
+	Classes           []open5eClass            `json:"classes"`
+	TotalLevel        int                      `json:"total_level"`
+	Background        string                   `json:"background,omitempty"`
+	Alignment         string                   `json:"alignment,omitempty"`
+	Experience        int                      `json:"experience"`
+	AbilityScores     map[string]open5eAbility `json:"ability_scores"`
+	ProficiencyBonus  int                      `json:"proficiency_bonus"`
+	HitPoints         int                      `json:"hit_points"`
+	Features          []string                 `json:"features"`
+	PersonalityTraits string                   `json:"personality_traits,omitempty"`
+	Ideals            string                   `json:"ideals,omitempty"`
+	Bonds             string                   `json:"bonds,omitempty"`
+	Flaws             string                   `json:"flaws,omitempty"`
+	Backstory         string                   `json:"backstory,omitempty"`
+	Notes             string                   `json:"notes,omitempty"`
+}
+
+type open5eRace struct {
+	Name    string `json:"name"`
+	Subrace string `json:"subrace,omitempty"`
+}
+
+type open5eClass struct {
+	Name     string `json:"name"`
+	Subclass string `json:"subclass,omitempty"`
+	Level    int    `json:"level"`
+	HitDice  string `json:"hit_dice"`
+}
+
+type open5eAbility struct {
+	Score    int `json:"score"`
+	Modifier int `json:"modifier"`
+}
+
+// exportOpen5e builds an open 5e sheet
+func exportOpen5e(c *models.Character) interface{} {
+	sheet := open5eSheet{
+		Name:             c.CharacterName,
+		Player:           c.PlayerName,
+		Race:             open5eRace{Name: c.Race, Subrace: c.Subrace},
+		TotalLevel:       c.TotalLevel(),
+		Background:       c.Background,
+		Alignment:        c.Alignment,
+		Experience:       c.ExperiencePoints,
+		AbilityScores:    map[string]open5eAbility{},
+		ProficiencyBonus: c.ProficiencyBonus(),
+		HitPoints:        c.MaxHitPoints(),
+		Features:         append([]string{}, c.Features...),
+		Backstory:        c.Backstory,
+		Notes:            c.Notes,
+	}
+
+	sheet.Classes = append(sheet.Classes, open5eClass{Name: c.Class, Subclass: c.Subclass, Level: c.Level, HitDice: fmt.Sprintf("%dd%d", c.Level, models.HitDie(c.Class))})
+	for _, mc := range c.Multiclass {
+		sheet.Classes = append(sheet.Classes, open5eClass{Name: mc.Class, Subclass: mc.Subclass, Level: mc.Level, HitDice: fmt.Sprintf("%dd%d", mc.Level, models.HitDie(mc.Class))})
+	}
+
+	for _, ability := range open5eAbilities {
+		score, _ := c.AbilityScores.Get(ability.field)
+		sheet.AbilityScores[ability.keys[0]] = open5eAbility{Score: score.Base, Modifier: score.Modifier()}
+	}
+
+	personality := splitPersonality(c.Personality)
+	sheet.PersonalityTraits, sheet.Ideals, sheet.Bonds, sheet.Flaws = personality["trait"], personality["ideal"], personality["bond"], personality["flaw"]
+	return sheet
+}
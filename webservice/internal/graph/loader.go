@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"sync"
+)
+
+// loader batches lookups made while resolving one level of a query. Resolvers
+// call load, which queues the key and returns a thunk; graphql-go runs the
+// thunks only after every sibling field has been resolved, so the first thunk
+// fetches all queued keys in a single call. Results are cached for the
+// lifetime of the loader, which is one request.
+type loader[K comparable, V any] struct {
+	fetch   func(keys []K) (map[K]V, error)
+	pending []K
+	queued  map[K]bool
+	results map[K]V
+	errs    map[K]error
+	mutex   sync.Mutex
+}
+
+func newLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:   fetch,
+		queued:  make(map[K]bool),
+		results: make(map[K]V),
+		errs:    make(map[K]error),
+	}
+}
+
+// load queues key and returns a thunk yielding its value, or nil when the fetch did not return it
+func (l *loader[K, V]) load(key K) func() (interface{}, error) {
+	l.mutex.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mutex.Unlock()
+
+	return func() (interface{}, error) {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		l.flush()
+		if err := l.errs[key]; err != nil {
+			return nil, err
+		}
+		value, ok := l.results[key]
+		if !ok {
+			return nil, nil
+		}
+		return value, nil
+	}
+}
+
+// flush fetches every pending key in one batch; the caller holds the mutex
+func (l *loader[K, V]) flush() {
+	if len(l.pending) == 0 {
+		return
+	}
+
+	keys := l.pending
+	l.pending = nil
+
+	results, err := l.fetch(keys)
+	for _, key := range keys {
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		if value, ok := results[key]; ok {
+			l.results[key] = value
+		}
+	}
+}
@@ -0,0 +1,582 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"player-character/internal/auth"
+	"player-character/internal/models"
+	"player-character/internal/service"
+	"player-character/pkg/database"
+	"player-character/pkg/filter"
+
+	"github.com/graphql-go/graphql"
+)
+
+// campaignCharactersPage is how many characters each query loading the
+// characters of the campaigns in a response reads
+var campaignCharactersPage = 1000
+
+// CharacterPage is one page of characters
+type CharacterPage struct {
+	Items      []models.Character `json:"items"`
+	Pagination models.Pagination  `json:"pagination"`
+}
+
+// CampaignPage is one page of campaigns
+type CampaignPage struct {
+	Items      []models.Campaign `json:"items"`
+	Pagination models.Pagination `json:"pagination"`
+}
+
+// partyNode is a party together with the campaign it belongs to, so that its
+// characters can be resolved
+type partyNode struct {
+	party      models.Party
+	campaignID string
+}
+
+func (n *partyNode) model() interface{} {
+	return &n.party
+}
+
+// Request is a GraphQL request body
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Schema executes GraphQL requests against the character and campaign services
+type Schema struct {
+	schema     graphql.Schema
+	characters *service.Characters
+	campaigns  *service.Campaigns
+}
+
+// requestState is what resolvers share while executing one request
+type requestState struct {
+	principal          *auth.Principal
+	campaigns          *loader[string, *models.Campaign]
+	campaignCharacters *loader[string, []models.Character]
+}
+
+type stateKey struct{}
+
+// state returns the per-request state stored by Execute
+func state(p graphql.ResolveParams) *requestState {
+	return p.Context.Value(stateKey{}).(*requestState)
+}
+
+// NewSchema builds the schema. Object and input types are generated from the
+// models; relations between them are resolved through batching loaders.
+func NewSchema(characters *service.Characters, campaigns *service.Campaigns) (*Schema, error) {
+	s := &Schema{
+		characters: characters,
+		campaigns:  campaigns,
+	}
+
+	types := newTypeBuilder()
+	types.setReadOnly(models.Character{}, "id", "ownerId", "createdAt", "updatedAt")
+	types.setReadOnly(models.Campaign{}, "id", "createdAt", "updatedAt")
+
+	characterType := types.object(models.Character{})
+	campaignType := types.object(models.Campaign{})
+	partyType := types.object(models.Party{})
+	characterPageType := types.object(CharacterPage{})
+	campaignPageType := types.object(CampaignPage{})
+
+	types.extend(models.Character{}, "campaign", &graphql.Field{
+		Type:        campaignType,
+		Description: "The campaign the character belongs to",
+		Resolve:     s.resolveCharacterCampaign,
+	})
+	types.extend(models.Campaign{}, "parties", &graphql.Field{
+		Type:    graphql.NewList(partyType),
+		Resolve: resolveCampaignParties,
+	})
+	types.extend(models.Campaign{}, "characters", &graphql.Field{
+		Type:        graphql.NewList(characterType),
+		Description: "Characters in the campaign that the caller may read",
+		Resolve:     s.resolveCampaignCharacters,
+	})
+	types.extend(models.Party{}, "characters", &graphql.Field{
+		Type:        graphql.NewList(characterType),
+		Description: "Characters in the party that the caller may read",
+		Resolve:     s.resolvePartyCharacters,
+	})
+
+	sortField := graphql.NewEnum(graphql.EnumConfig{
+		Name: "CharacterSortField",
+		Values: graphql.EnumValueConfigMap{
+			"characterName": {Value: "characterName"},
+			"level":         {Value: "level"},
+			"race":          {Value: "race"},
+			"class":         {Value: "class"},
+			"createdAt":     {Value: "createdAt"},
+		},
+	})
+	sortOrder := graphql.NewEnum(graphql.EnumConfig{
+		Name: "SortOrder",
+		Values: graphql.EnumValueConfigMap{
+			"asc":  {Value: "asc"},
+			"desc": {Value: "desc"},
+		},
+	})
+
+	id := graphql.NewNonNull(graphql.ID)
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"character": &graphql.Field{
+				Type:    characterType,
+				Args:    graphql.FieldConfigArgument{"id": {Type: id}},
+				Resolve: s.resolveCharacter,
+			},
+			"characters": &graphql.Field{
+				Type: characterPageType,
+				Args: graphql.FieldConfigArgument{
+					"page":       {Type: graphql.Int, DefaultValue: 1},
+					"limit":      {Type: graphql.Int, DefaultValue: 20},
+					"sortBy":     {Type: sortField, DefaultValue: "createdAt"},
+					"sortOrder":  {Type: sortOrder, DefaultValue: "desc"},
+					"search":     {Type: graphql.String},
+					"filter":     {Type: graphql.String, Description: "Structured filter, e.g. level>=5 AND class in (Wizard,Sorcerer)"},
+					"cursor":     {Type: graphql.String, Description: "Continue after a previous page's pagination.nextCursor instead of using page"},
+					"campaignId": {Type: graphql.ID},
+					"partyId":    {Type: graphql.ID},
+					"ownerId":    {Type: graphql.ID},
+				},
+				Resolve: s.resolveCharacters,
+			},
+			"campaign": &graphql.Field{
+				Type:    campaignType,
+				Args:    graphql.FieldConfigArgument{"id": {Type: id}},
+				Resolve: s.resolveCampaign,
+			},
+			"campaigns": &graphql.Field{
+				Type: campaignPageType,
+				Args: graphql.FieldConfigArgument{
+					"page":  {Type: graphql.Int, DefaultValue: 1},
+					"limit": {Type: graphql.Int, DefaultValue: 20},
+				},
+				Resolve: s.resolveCampaigns,
+			},
+		},
+	})
+
+	characterInput := graphql.NewNonNull(types.input(models.Character{}))
+	campaignInput := graphql.NewNonNull(types.input(models.Campaign{}))
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createCharacter": &graphql.Field{
+				Type:    characterType,
+				Args:    graphql.FieldConfigArgument{"input": {Type: characterInput}},
+				Resolve: s.createCharacter,
+			},
+			"updateCharacter": &graphql.Field{
+				Type:        characterType,
+				Description: "Update the fields given in input, leaving the rest unchanged",
+				Args:        graphql.FieldConfigArgument{"id": {Type: id}, "input": {Type: characterInput}},
+				Resolve:     s.updateCharacter,
+			},
+			"deleteCharacter": &graphql.Field{
+				Type:    graphql.Boolean,
+				Args:    graphql.FieldConfigArgument{"id": {Type: id}},
+				Resolve: s.deleteCharacter,
+			},
+			"createCampaign": &graphql.Field{
+				Type:    campaignType,
+				Args:    graphql.FieldConfigArgument{"input": {Type: campaignInput}},
+				Resolve: s.createCampaign,
+			},
+			"updateCampaign": &graphql.Field{
+				Type:        campaignType,
+				Description: "Update the fields given in input, leaving the rest unchanged",
+				Args:        graphql.FieldConfigArgument{"id": {Type: id}, "input": {Type: campaignInput}},
+				Resolve:     s.updateCampaign,
+			},
+			"deleteCampaign": &graphql.Field{
+				Type:    graphql.Boolean,
+				Args:    graphql.FieldConfigArgument{"id": {Type: id}},
+				Resolve: s.deleteCampaign,
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// Execute runs a request on behalf of the principal
+func (s *Schema) Execute(ctx context.Context, principal *auth.Principal, request Request) *graphql.Result {
+	state := &requestState{principal: principal}
+	state.campaigns = newLoader(func(ids []string) (map[string]*models.Campaign, error) {
+		return s.campaigns.GetMany(ctx, principal, ids)
+	})
+	state.campaignCharacters = newLoader(func(campaignIDs []string) (map[string][]models.Character, error) {
+		byCampaign := make(map[string][]models.Character, len(campaignIDs))
+		for _, campaignID := range campaignIDs {
+			byCampaign[campaignID] = []models.Character{}
+		}
+
+		// Page through every campaign's characters, so that none come back incomplete
+		opts := database.ListOptions{
+			Page:        1,
+			Limit:       campaignCharactersPage,
+			SkipCount:   true,
+			Lookahead:   true,
+			SortBy:      "characterName",
+			SortOrder:   "asc",
+			CampaignIDs: campaignIDs,
+		}
+		for {
+			characters, _, err := s.characters.List(ctx, principal, opts)
+			if err != nil {
+				return nil, err
+			}
+			more := len(characters) > opts.Limit
+			if more {
+				characters = characters[:opts.Limit]
+			}
+			for _, character := range characters {
+				byCampaign[character.CampaignID] = append(byCampaign[character.CampaignID], character)
+			}
+			if !more {
+				return byCampaign, nil
+			}
+			opts.After = database.NewCursor(opts, &characters[len(characters)-1])
+		}
+	})
+
+	return graphql.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  request.Query,
+		VariableValues: request.Variables,
+		OperationName:  request.OperationName,
+		Context:        context.WithValue(ctx, stateKey{}, state),
+	})
+}
+
+// resolverError exposes a service error's kind and validation details as GraphQL error extensions
+type resolverError struct {
+	err *service.Error
+}
+
+func (e resolverError) Error() string {
+	return e.err.Error()
+}
+
+func (e resolverError) Extensions() map[string]interface{} {
+	codes := map[service.Kind]string{
+		service.KindInvalid:   "BAD_USER_INPUT",
+		service.KindForbidden: "FORBIDDEN",
+		service.KindNotFound:  "NOT_FOUND",
+		service.KindInternal:  "INTERNAL_SERVER_ERROR",
+	}
+	extensions := map[string]interface{}{"code": codes[e.err.Kind]}
+	if len(e.err.Validation) > 0 {
+		extensions["validationErrors"] = e.err.Validation
+	}
+	return extensions
+}
+
+// wrapError converts service errors so their details reach the client
+func wrapError(err error) error {
+	var serviceErr *service.Error
+	if errors.As(err, &serviceErr) {
+		return resolverError{err: serviceErr}
+	}
+	return err
+}
+
+// errForbiddenScope is returned to API keys without the write scope when they attempt a mutation
+var errForbiddenScope = resolverError{err: &service.Error{Kind: service.KindForbidden, Message: "API key lacks the write scope"}}
+
+// canWrite reports whether the caller may run mutations
+func canWrite(p graphql.ResolveParams) bool {
+	principal := state(p).principal
+	return principal == nil || principal.HasScope(models.ScopeWrite)
+}
+
+// decodeInput copies an input object argument onto target, leaving fields absent from the input untouched
+func decodeInput(input interface{}, target interface{}) error {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// pageArgs reads and checks the page and limit arguments
+func pageArgs(p graphql.ResolveParams) (int, int, error) {
+	page, _ := p.Args["page"].(int)
+	limit, _ := p.Args["limit"].(int)
+	if page < 1 {
+		return 0, 0, errors.New("page must be at least 1")
+	}
+	if limit < 1 || limit > 100 {
+		return 0, 0, errors.New("limit must be between 1 and 100")
+	}
+	return page, limit, nil
+}
+
+func pagination(page, limit, total int) models.Pagination {
+	totalPages := (total + limit - 1) / limit
+	return models.Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
+
+func (s *Schema) resolveCharacter(p graphql.ResolveParams) (interface{}, error) {
+	character, err := s.characters.Get(p.Context, state(p).principal, p.Args["id"].(string))
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return character, nil
+}
+
+func (s *Schema) resolveCharacters(p graphql.ResolveParams) (interface{}, error) {
+	page, limit, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := database.ListOptions{
+		Page:      page,
+		Limit:     limit,
+		SortBy:    p.Args["sortBy"].(string),
+		SortOrder: p.Args["sortOrder"].(string),
+	}
+	opts.Search, _ = p.Args["search"].(string)
+	opts.CampaignID, _ = p.Args["campaignId"].(string)
+	opts.PartyID, _ = p.Args["partyId"].(string)
+	opts.OwnerID, _ = p.Args["ownerId"].(string)
+
+	query, _ := p.Args["filter"].(string)
+	if opts.Filter, err = filter.Parse(query); err != nil {
+		return nil, resolverError{err: &service.Error{Kind: service.KindInvalid, Message: err.Error()}}
+	}
+
+	if token, _ := p.Args["cursor"].(string); token != "" {
+		if opts.After, err = database.DecodeCursor(token, opts.SortBy, opts.SortOrder); err != nil {
+			return nil, resolverError{err: &service.Error{Kind: service.KindInvalid, Message: "cursor must come from a listing with the same sortBy and sortOrder"}}
+		}
+	}
+
+	result, err := s.characters.Page(p.Context, state(p).principal, opts)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	info := pagination(page, limit, result.Total)
+	info.HasNext = result.HasNext
+	info.NextCursor = result.NextCursor
+	if opts.After != nil {
+		info.HasPrev = true
+	}
+	return &CharacterPage{Items: result.Characters, Pagination: info}, nil
+}
+
+func (s *Schema) resolveCampaign(p graphql.ResolveParams) (interface{}, error) {
+	campaign, err := s.campaigns.Get(p.Context, state(p).principal, p.Args["id"].(string))
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return campaign, nil
+}
+
+func (s *Schema) resolveCampaigns(p graphql.ResolveParams) (interface{}, error) {
+	page, limit, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	campaigns, total, err := s.campaigns.List(p.Context, state(p).principal, page, limit)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &CampaignPage{Items: campaigns, Pagination: pagination(page, limit, total)}, nil
+}
+
+// sourceCampaign returns the campaign a Campaign field is being resolved on
+func sourceCampaign(p graphql.ResolveParams) *models.Campaign {
+	switch source := p.Source.(type) {
+	case *models.Campaign:
+		return source
+	case models.Campaign:
+		return &source
+	}
+	return nil
+}
+
+// sourceCharacter returns the character a Character field is being resolved on
+func sourceCharacter(p graphql.ResolveParams) *models.Character {
+	switch source := p.Source.(type) {
+	case *models.Character:
+		return source
+	case models.Character:
+		return &source
+	}
+	return nil
+}
+
+func (s *Schema) resolveCharacterCampaign(p graphql.ResolveParams) (interface{}, error) {
+	character := sourceCharacter(p)
+	if character == nil || character.CampaignID == "" {
+		return nil, nil
+	}
+	return state(p).campaigns.load(character.CampaignID), nil
+}
+
+func resolveCampaignParties(p graphql.ResolveParams) (interface{}, error) {
+	campaign := sourceCampaign(p)
+	if campaign == nil {
+		return nil, nil
+	}
+
+	parties := make([]*partyNode, len(campaign.Parties))
+	for i, party := range campaign.Parties {
+		parties[i] = &partyNode{party: party, campaignID: campaign.ID}
+	}
+	return parties, nil
+}
+
+func (s *Schema) resolveCampaignCharacters(p graphql.ResolveParams) (interface{}, error) {
+	campaign := sourceCampaign(p)
+	if campaign == nil {
+		return nil, nil
+	}
+	return state(p).campaignCharacters.load(campaign.ID), nil
+}
+
+func (s *Schema) resolvePartyCharacters(p graphql.ResolveParams) (interface{}, error) {
+	party, ok := p.Source.(*partyNode)
+	if !ok {
+		return nil, nil
+	}
+
+	thunk := state(p).campaignCharacters.load(party.campaignID)
+	return func() (interface{}, error) {
+		value, err := thunk()
+		if err != nil || value == nil {
+			return value, err
+		}
+		members := []models.Character{}
+		for _, character := range value.([]models.Character) {
+			if character.PartyID == party.party.ID {
+				members = append(members, character)
+			}
+		}
+		return members, nil
+	}, nil
+}
+
+func (s *Schema) createCharacter(p graphql.ResolveParams) (interface{}, error) {
+	if !canWrite(p) {
+		return nil, errForbiddenScope
+	}
+
+	var character models.Character
+	if err := decodeInput(p.Args["input"], &character); err != nil {
+		return nil, err
+	}
+	if err := s.characters.Create(p.Context, state(p).principal, &character); err != nil {
+		return nil, wrapError(err)
+	}
+	return &character, nil
+}
+
+func (s *Schema) updateCharacter(p graphql.ResolveParams) (interface{}, error) {
+	if !canWrite(p) {
+		return nil, errForbiddenScope
+	}
+
+	principal := state(p).principal
+	id := p.Args["id"].(string)
+
+	// Start from what the caller can see; hidden fields are preserved by the service
+	character, err := s.characters.Get(p.Context, principal, id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	if err := decodeInput(p.Args["input"], character); err != nil {
+		return nil, err
+	}
+	if err := s.characters.Update(p.Context, principal, id, character); err != nil {
+		return nil, wrapError(err)
+	}
+	return character, nil
+}
+
+func (s *Schema) deleteCharacter(p graphql.ResolveParams) (interface{}, error) {
+	if !canWrite(p) {
+		return nil, errForbiddenScope
+	}
+
+	if err := s.characters.Delete(p.Context, state(p).principal, p.Args["id"].(string)); err != nil {
+		return nil, wrapError(err)
+	}
+	return true, nil
+}
+
+func (s *Schema) createCampaign(p graphql.ResolveParams) (interface{}, error) {
+	if !canWrite(p) {
+		return nil, errForbiddenScope
+	}
+
+	var campaign models.Campaign
+	if err := decodeInput(p.Args["input"], &campaign); err != nil {
+		return nil, err
+	}
+	if err := s.campaigns.Create(p.Context, state(p).principal, &campaign); err != nil {
+		return nil, wrapError(err)
+	}
+	return &campaign, nil
+}
+
+func (s *Schema) updateCampaign(p graphql.ResolveParams) (interface{}, error) {
+	if !canWrite(p) {
+		return nil, errForbiddenScope
+	}
+
+	principal := state(p).principal
+	id := p.Args["id"].(string)
+
+	campaign, err := s.campaigns.Get(p.Context, principal, id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	if err := decodeInput(p.Args["input"], campaign); err != nil {
+		return nil, err
+	}
+	if err := s.campaigns.Update(p.Context, principal, id, campaign); err != nil {
+		return nil, wrapError(err)
+	}
+	return campaign, nil
+}
+
+func (s *Schema) deleteCampaign(p graphql.ResolveParams) (interface{}, error) {
+	if !canWrite(p) {
+		return nil, errForbiddenScope
+	}
+
+	if err := s.campaigns.Delete(p.Context, state(p).principal, p.Args["id"].(string)); err != nil {
+		return nil, wrapError(err)
+	}
+	return true, nil
+}
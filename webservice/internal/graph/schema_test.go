@@ -0,0 +1,307 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"player-character/internal/auth"
+	"player-character/internal/authz"
+	"player-character/internal/models"
+	"player-character/internal/service"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+)
+
+// countingCampaignStore counts store calls so tests can check that lookups are batched
+type countingCampaignStore struct {
+	*database.MemoryCampaignStore
+	gets     int
+	getManys int
+}
+
+func (s *countingCampaignStore) Get(id string) (*models.Campaign, error) {
+	s.gets++
+	return s.MemoryCampaignStore.Get(id)
+}
+
+func (s *countingCampaignStore) GetMany(ids []string) ([]models.Campaign, error) {
+	s.getManys++
+	return s.MemoryCampaignStore.GetMany(ids)
+}
+
+// countingCharacterStore counts List calls
+type countingCharacterStore struct {
+	*database.MemoryStore
+	lists int
+}
+
+func (s *countingCharacterStore) List(opts database.ListOptions) ([]models.Character, int, error) {
+	s.lists++
+	return s.MemoryStore.List(opts)
+}
+
+type fixture struct {
+	schema     *Schema
+	characters *countingCharacterStore
+	campaigns  *countingCampaignStore
+	campaign   models.Campaign
+}
+
+func setup(t *testing.T) *fixture {
+	t.Helper()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	characters := &countingCharacterStore{MemoryStore: database.NewMemoryStore()}
+	campaigns := &countingCampaignStore{MemoryCampaignStore: database.NewMemoryCampaignStore()}
+	policy := authz.NewPolicy(characters, campaigns)
+
+	schema, err := NewSchema(
+		service.NewCharacters(characters, campaigns, policy, nil, logger),
+		service.NewCampaigns(campaigns, policy, logger))
+	if err != nil {
+		t.Fatalf("Failed to build schema: %v", err)
+	}
+
+	campaign := models.Campaign{
+		Name:    "Rime of the Frostmaiden",
+		DMID:    "dm",
+		Players: []string{"alice", "bob"},
+		Parties: []models.Party{{Name: "Bryn Shander"}, {Name: "Targos"}},
+	}
+	other := models.Campaign{Name: "Curse of Strahd", DMID: "dm", Players: []string{"alice"}}
+	for _, c := range []*models.Campaign{&campaign, &other} {
+		if err := campaigns.Create(c); err != nil {
+			t.Fatalf("Failed to create campaign: %v", err)
+		}
+	}
+
+	scores := models.AbilityScores{
+		Strength:     models.AbilityScore{Base: 15},
+		Dexterity:    models.AbilityScore{Base: 14},
+		Constitution: models.AbilityScore{Base: 13},
+		Intelligence: models.AbilityScore{Base: 12},
+		Wisdom:       models.AbilityScore{Base: 10},
+		Charisma:     models.AbilityScore{Base: 8},
+	}
+	seed := []models.Character{
+		{CharacterName: "Aelar", Race: "Elf", Class: "Ranger", Level: 3, AbilityScores: scores, OwnerID: "alice", CampaignID: campaign.ID, PartyID: campaign.Parties[0].ID},
+		{CharacterName: "Borin", Race: "Dwarf", Class: "Cleric", Level: 3, AbilityScores: scores, OwnerID: "bob", CampaignID: campaign.ID, PartyID: campaign.Parties[1].ID, SecretBackstory: "Owes the Zhentarim"},
+		{CharacterName: "Ireena", Race: "Human", Class: "Fighter", Level: 2, AbilityScores: scores, OwnerID: "alice", CampaignID: other.ID},
+	}
+	for i := range seed {
+		if err := characters.Create(&seed[i]); err != nil {
+			t.Fatalf("Failed to create character: %v", err)
+		}
+	}
+
+	return &fixture{schema: schema, characters: characters, campaigns: campaigns, campaign: campaign}
+}
+
+// run executes a request as userID and decodes the result
+func (f *fixture) run(t *testing.T, userID, query string, variables map[string]interface{}) (map[string]interface{}, []map[string]interface{}) {
+	t.Helper()
+	principal := &auth.Principal{UserID: userID, Role: models.RoleUser}
+	result := f.schema.Execute(context.Background(), principal, Request{Query: query, Variables: variables})
+
+	data, _ := json.Marshal(result)
+	var decoded struct {
+		Data   map[string]interface{}   `json:"data"`
+		Errors []map[string]interface{} `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+	return decoded.Data, decoded.Errors
+}
+
+func TestQuery_CharactersWithCampaignsAreBatched(t *testing.T) {
+	f := setup(t)
+
+	data, errs := f.run(t, "dm", `{
+		characters(sortBy: characterName, sortOrder: asc) {
+			items { characterName campaign { name } }
+			pagination { total hasNext }
+		}
+	}`, nil)
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+
+	page := data["characters"].(map[string]interface{})
+	items := page["items"].([]interface{})
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 characters, got %d", len(items))
+	}
+	first := items[0].(map[string]interface{})
+	if first["characterName"] != "Aelar" || first["campaign"].(map[string]interface{})["name"] != "Rime of the Frostmaiden" {
+		t.Errorf("Unexpected first item: %v", first)
+	}
+	if _, selected := first["race"]; selected {
+		t.Error("Expected only selected fields in the response")
+	}
+
+	// Resolving campaign on every character takes one store call; the other
+	// lookups are redaction's per-campaign permission checks
+	if f.campaigns.getManys != 1 || f.campaigns.gets > 2 {
+		t.Errorf("Expected 1 batched campaign lookup, got %d GetMany and %d Get calls", f.campaigns.getManys, f.campaigns.gets)
+	}
+}
+
+func TestQuery_PartyViewIsBatched(t *testing.T) {
+	f := setup(t)
+
+	data, errs := f.run(t, "alice", `query($id: ID!) {
+		campaign(id: $id) {
+			name
+			parties { name characters { characterName secretBackstory } }
+		}
+	}`, map[string]interface{}{"id": f.campaign.ID})
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+
+	parties := data["campaign"].(map[string]interface{})["parties"].([]interface{})
+	if len(parties) != 2 {
+		t.Fatalf("Expected 2 parties, got %d", len(parties))
+	}
+	for i, want := range []string{"Aelar", ""} {
+		members := parties[i].(map[string]interface{})["characters"].([]interface{})
+		if want == "" {
+			// alice is not in the second party, so bob's character is hidden from her
+			if len(members) != 0 {
+				t.Errorf("Expected no visible members in party %d, got %v", i, members)
+			}
+			continue
+		}
+		if len(members) != 1 || members[0].(map[string]interface{})["characterName"] != want {
+			t.Errorf("Expected %s in party %d, got %v", want, i, members)
+		}
+	}
+
+	// Both parties' characters come from one list query
+	if f.characters.lists != 1 {
+		t.Errorf("Expected 1 character list query, got %d", f.characters.lists)
+	}
+}
+
+func TestQuery_CampaignCharactersArePaged(t *testing.T) {
+	defer func(page int) { campaignCharactersPage = page }(campaignCharactersPage)
+	campaignCharactersPage = 2
+
+	f := setup(t)
+	campaigns, _ := f.campaigns.All()
+	want := map[string][]string{}
+	for _, campaign := range campaigns {
+		for _, name := range []string{"Vex", "Wren", "Yorick"} {
+			character := models.Character{CharacterName: name, Race: "Human", Class: "Rogue", Level: 1, OwnerID: "alice", CampaignID: campaign.ID}
+			if err := f.characters.Create(&character); err != nil {
+				t.Fatalf("Failed to create character: %v", err)
+			}
+		}
+		characters, _, _ := f.characters.MemoryStore.List(database.ListOptions{Page: 1, Limit: 100, SortBy: "characterName", SortOrder: "asc", CampaignID: campaign.ID})
+		for _, character := range characters {
+			want[campaign.Name] = append(want[campaign.Name], character.CharacterName)
+		}
+	}
+
+	data, errs := f.run(t, "dm", `{
+		campaigns { items { name characters { characterName } } }
+	}`, nil)
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+
+	// 9 characters across the two campaigns take 5 pages of 2, with lookahead
+	items := data["campaigns"].(map[string]interface{})["items"].([]interface{})
+	for _, item := range items {
+		campaign := item.(map[string]interface{})
+		var got []string
+		for _, character := range campaign["characters"].([]interface{}) {
+			got = append(got, character.(map[string]interface{})["characterName"].(string))
+		}
+		if name := campaign["name"].(string); !slices.Equal(got, want[name]) {
+			t.Errorf("Expected %v in %s, got %v", want[name], name, got)
+		}
+	}
+	if len(items) != 2 || f.characters.lists != 5 {
+		t.Errorf("Expected 2 campaigns loaded in 5 list queries, got %d campaigns and %d queries", len(items), f.characters.lists)
+	}
+}
+
+func TestMutation_CharacterLifecycle(t *testing.T) {
+	f := setup(t)
+
+	createQuery := `mutation($input: CharacterInput!) {
+		createCharacter(input: $input) { id characterName ownerId level }
+	}`
+	input := map[string]interface{}{
+		"characterName": "Wren",
+		"race":          "Halfling",
+		"class":         "Rogue",
+		"level":         1,
+		"abilityScores": map[string]interface{}{
+			"strength":     map[string]interface{}{"base": 8},
+			"dexterity":    map[string]interface{}{"base": 16},
+			"constitution": map[string]interface{}{"base": 12},
+			"intelligence": map[string]interface{}{"base": 13},
+			"wisdom":       map[string]interface{}{"base": 10},
+			"charisma":     map[string]interface{}{"base": 14},
+		},
+	}
+	data, errs := f.run(t, "carol", createQuery, map[string]interface{}{"input": input})
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+	created := data["createCharacter"].(map[string]interface{})
+	if created["ownerId"] != "carol" {
+		t.Errorf("Expected the caller to own the character, got %v", created["ownerId"])
+	}
+	id := created["id"].(string)
+
+	// Validation failures carry the same details as the REST API
+	input["level"] = 25
+	_, errs = f.run(t, "carol", createQuery, map[string]interface{}{"input": input})
+	if len(errs) != 1 || errs[0]["extensions"].(map[string]interface{})["code"] != "BAD_USER_INPUT" {
+		t.Fatalf("Expected a BAD_USER_INPUT error, got %v", errs)
+	}
+
+	// Updates only touch the given fields
+	data, errs = f.run(t, "carol", `mutation($id: ID!) {
+		updateCharacter(id: $id, input: {level: 2}) { characterName level }
+	}`, map[string]interface{}{"id": id})
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+	updated := data["updateCharacter"].(map[string]interface{})
+	if updated["characterName"] != "Wren" || updated["level"] != float64(2) {
+		t.Errorf("Unexpected update result: %v", updated)
+	}
+
+	// Other users are refused
+	_, errs = f.run(t, "bob", `mutation($id: ID!) { deleteCharacter(id: $id) }`, map[string]interface{}{"id": id})
+	if len(errs) != 1 || errs[0]["extensions"].(map[string]interface{})["code"] != "FORBIDDEN" {
+		t.Fatalf("Expected a FORBIDDEN error, got %v", errs)
+	}
+
+	data, errs = f.run(t, "carol", `mutation($id: ID!) { deleteCharacter(id: $id) }`, map[string]interface{}{"id": id})
+	if len(errs) > 0 || data["deleteCharacter"] != true {
+		t.Fatalf("Expected deletion to succeed, got %v %v", data, errs)
+	}
+}
+
+func TestMutation_RequiresWriteScope(t *testing.T) {
+	f := setup(t)
+
+	principal := &auth.Principal{UserID: "alice", Role: models.RoleUser, APIKeyID: "key", Scopes: []string{models.ScopeRead}}
+	result := f.schema.Execute(context.Background(), principal, Request{
+		Query: `mutation { createCampaign(input: {name: "Read Only"}) { id } }`,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected the mutation to be refused, got %+v", result)
+	}
+}
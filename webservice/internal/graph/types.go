@@ -0,0 +1,237 @@
+package graph
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// DateTime is an RFC 3339 timestamp
+var DateTime = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "DateTime",
+	Description: "An RFC 3339 timestamp",
+	Serialize: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case time.Time:
+			return v.Format(time.RFC3339Nano)
+		case *time.Time:
+			if v == nil {
+				return nil
+			}
+			return v.Format(time.RFC3339Nano)
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		if s, ok := value.(string); ok {
+			if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+				return t
+			}
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if s, ok := valueAST.(*ast.StringValue); ok {
+			if t, err := time.Parse(time.RFC3339Nano, s.Value); err == nil {
+				return t
+			}
+		}
+		return nil
+	},
+})
+
+// modelSource is implemented by resolver values that wrap a model with extra
+// context, so generated field resolvers can still reach the model's fields
+type modelSource interface {
+	model() interface{}
+}
+
+// typeBuilder generates GraphQL object and input types from Go model structs,
+// naming fields after their json tags so the schema matches the REST API
+type typeBuilder struct {
+	outputs  map[reflect.Type]*graphql.Object
+	inputs   map[reflect.Type]*graphql.InputObject
+	readOnly map[reflect.Type]map[string]bool
+	extra    map[reflect.Type]graphql.Fields
+}
+
+func newTypeBuilder() *typeBuilder {
+	return &typeBuilder{
+		outputs:  make(map[reflect.Type]*graphql.Object),
+		inputs:   make(map[reflect.Type]*graphql.InputObject),
+		readOnly: make(map[reflect.Type]map[string]bool),
+		extra:    make(map[reflect.Type]graphql.Fields),
+	}
+}
+
+// extend adds a field that is not generated from the model, such as a
+// relation, or replaces a generated one. It must be called before the schema is built.
+func (b *typeBuilder) extend(model interface{}, name string, field *graphql.Field) {
+	t := reflect.TypeOf(model)
+	if b.extra[t] == nil {
+		b.extra[t] = graphql.Fields{}
+	}
+	b.extra[t][name] = field
+}
+
+// setReadOnly leaves the named fields out of the model's input type
+func (b *typeBuilder) setReadOnly(model interface{}, fields ...string) {
+	set := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		set[field] = true
+	}
+	b.readOnly[reflect.TypeOf(model)] = set
+}
+
+// jsonName returns the field's json name, or "" when it is not serialized
+func jsonName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// object returns the output type for a model struct
+func (b *typeBuilder) object(model interface{}) *graphql.Object {
+	return b.outputObject(reflect.TypeOf(model))
+}
+
+// input returns the input type for a model struct
+func (b *typeBuilder) input(model interface{}) *graphql.InputObject {
+	return b.inputObject(reflect.TypeOf(model))
+}
+
+func (b *typeBuilder) outputObject(t reflect.Type) *graphql.Object {
+	if object, ok := b.outputs[t]; ok {
+		return object
+	}
+
+	// Fields are built lazily so that types may refer to each other
+	object := graphql.NewObject(graphql.ObjectConfig{
+		Name: t.Name(),
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := graphql.Fields{}
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+				name := jsonName(field)
+				if name == "" {
+					continue
+				}
+				fields[name] = &graphql.Field{
+					Type:    b.outputType(field.Type),
+					Resolve: fieldResolver(field.Index),
+				}
+			}
+			for name, field := range b.extra[t] {
+				fields[name] = field
+			}
+			return fields
+		}),
+	})
+	b.outputs[t] = object
+	return object
+}
+
+func (b *typeBuilder) outputType(t reflect.Type) graphql.Output {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return b.outputType(t.Elem())
+	case reflect.Slice:
+		return graphql.NewList(b.outputType(t.Elem()))
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return DateTime
+		}
+		return b.outputObject(t)
+	}
+	return scalarFor(t)
+}
+
+func (b *typeBuilder) inputObject(t reflect.Type) *graphql.InputObject {
+	if input, ok := b.inputs[t]; ok {
+		return input
+	}
+
+	readOnly := b.readOnly[t]
+	input := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: t.Name() + "Input",
+		Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+			fields := graphql.InputObjectConfigFieldMap{}
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+				name := jsonName(field)
+				if name == "" || readOnly[name] {
+					continue
+				}
+				fields[name] = &graphql.InputObjectFieldConfig{Type: b.inputType(field.Type)}
+			}
+			return fields
+		}),
+	})
+	b.inputs[t] = input
+	return input
+}
+
+func (b *typeBuilder) inputType(t reflect.Type) graphql.Input {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return b.inputType(t.Elem())
+	case reflect.Slice:
+		return graphql.NewList(b.inputType(t.Elem()))
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return DateTime
+		}
+		return b.inputObject(t)
+	}
+	return scalarFor(t)
+}
+
+// scalarFor maps a Go basic kind to its GraphQL scalar
+func scalarFor(t reflect.Type) *graphql.Scalar {
+	switch t.Kind() {
+	case reflect.String:
+		return graphql.String
+	case reflect.Bool:
+		return graphql.Boolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return graphql.Int
+	case reflect.Float32, reflect.Float64:
+		return graphql.Float
+	}
+	panic(fmt.Sprintf("graph: no GraphQL type for Go type %s", t))
+}
+
+// fieldResolver reads a struct field by index from the source model
+func fieldResolver(index []int) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		source := p.Source
+		if wrapped, ok := source.(modelSource); ok {
+			source = wrapped.model()
+		}
+
+		value := reflect.ValueOf(source)
+		for value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return nil, nil
+			}
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			return nil, nil
+		}
+		return value.FieldByIndex(index).Interface(), nil
+	}
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// API key scopes
+const (
+	ScopeRead  = "read"  // read characters and campaigns
+	ScopeRoll  = "roll"  // roll dice against characters
+	ScopeWrite = "write" // full access, including create, update and delete
+)
+
+// APIKey is a long-lived credential for bots and integrations, acting on behalf of a user
+type APIKey struct {
+	ID          string     `json:"id" bson:"id" swaggo:"unique"`
+	Name        string     `json:"name" bson:"name"`
+	UserID      string     `json:"userId" bson:"userId"`
+	Prefix      string     `json:"prefix" bson:"prefix"`
+	KeyHash     string     `json:"-" bson:"keyHash"`
+	Scopes      []string   `json:"scopes" bson:"scopes"`
+	CampaignIDs []string   `json:"campaignIds" bson:"campaignIds,omitempty"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty" bson:"lastUsedAt,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty" bson:"expiresAt,omitempty"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty" bson:"revokedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt" bson:"createdAt"`
+}
+
+// Active reports whether the key is neither revoked nor expired at the given time
+func (k *APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// CreateAPIKeyRequest represents a request to mint an API key
+type CreateAPIKeyRequest struct {
+	Name        string     `json:"name" validate:"required,max=64" swaggo:"required"`
+	Scopes      []string   `json:"scopes" validate:"required,min=1,dive,oneof=read roll write" swaggo:"required"`
+	CampaignIDs []string   `json:"campaignIds"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+}
+
+// CreateAPIKeyResponse carries a newly minted key; the plaintext key is only ever returned here
+type CreateAPIKeyResponse struct {
+	Key    string `json:"key"`
+	APIKey APIKey `json:"apiKey"`
+}
@@ -0,0 +1,35 @@
+package models
+
+// BulkRequest is a batch of character writes
+type BulkRequest struct {
+	// Atomic applies every operation or, if any fails, none of them
+	Atomic     bool            `json:"atomic"`
+	Operations []BulkOperation `json:"operations" binding:"required"`
+}
+
+// BulkOperation is one create, update or delete in a batch
+type BulkOperation struct {
+	Op        string     `json:"op" enums:"create,update,delete" example:"update"`
+	ID        string     `json:"id,omitempty"`        // the character to update or delete
+	Character *Character `json:"character,omitempty"` // the new character, for create and update
+}
+
+// BulkResult is the outcome of one operation. Status is the HTTP status the
+// operation would have had on its own; 424 means it was valid but not applied
+// because another operation in an atomic batch failed.
+type BulkResult struct {
+	Index  int               `json:"index"`
+	Op     string            `json:"op"`
+	ID     string            `json:"id,omitempty"`
+	Status int               `json:"status"`
+	Data   *Character        `json:"data,omitempty"`
+	Error  string            `json:"error,omitempty"`
+	Errors []ValidationError `json:"errors,omitempty"`
+}
+
+// BulkResponse reports the outcome of a batch
+type BulkResponse struct {
+	Success bool         `json:"success"` // every operation was applied
+	Applied int          `json:"applied"`
+	Results []BulkResult `json:"results"`
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"time"
+)
+
+// Leveling modes supported by campaign settings
+const (
+	LevelingXP        = "xp"
+	LevelingMilestone = "milestone"
+)
+
+// Ability score generation methods supported by campaign settings
+const (
+	AbilityMethodManual        = "manual"
+	AbilityMethodStandardArray = "standard_array"
+	AbilityMethodPointBuy      = "point_buy"
+	AbilityMethodRoll          = "roll"
+)
+
+// Campaign represents a D&D campaign run by a DM with member players
+type Campaign struct {
+	ID          string           `json:"id" bson:"id" swaggo:"unique"`
+	Name        string           `json:"name" bson:"name" validate:"required" swaggo:"required"`
+	Description string           `json:"description" bson:"description,omitempty"`
+	DMID        string           `json:"dmId" bson:"dmId" validate:"required" swaggo:"required"`
+	Players     []string         `json:"players" bson:"players,omitempty"`
+	Viewers     []string         `json:"viewers" bson:"viewers,omitempty"`
+	Parties     []Party          `json:"parties" bson:"parties,omitempty" validate:"dive"`
+	Settings    CampaignSettings `json:"settings" bson:"settings"`
+	CreatedAt   time.Time        `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Party represents a group of adventurers within a campaign
+type Party struct {
+	ID   string `json:"id" bson:"id"`
+	Name string `json:"name" bson:"name" validate:"required"`
+}
+
+// CampaignSettings holds the rules a campaign applies to its characters
+type CampaignSettings struct {
+	AllowedRaces       []string          `json:"allowedRaces" bson:"allowedRaces,omitempty"`
+	AllowedClasses     []string          `json:"allowedClasses" bson:"allowedClasses,omitempty"`
+	Leveling           string            `json:"leveling" bson:"leveling,omitempty" validate:"omitempty,oneof=xp milestone"`
+	AbilityScoreMethod string            `json:"abilityScoreMethod" bson:"abilityScoreMethod,omitempty" validate:"omitempty,oneof=manual standard_array point_buy roll"`
+	Templates          CampaignTemplates `json:"templates" bson:"templates,omitempty"`
+}
+
+// CampaignTemplates replaces the default templates characters in the campaign
+// and its roster are rendered with. Empty templates keep the default.
+type CampaignTemplates struct {
+	CharacterMarkdown string `json:"characterMarkdown,omitempty" bson:"characterMarkdown,omitempty" validate:"max=20000"`
+	CharacterHTML     string `json:"characterHtml,omitempty" bson:"characterHtml,omitempty" validate:"max=20000"`
+	RosterMarkdown    string `json:"rosterMarkdown,omitempty" bson:"rosterMarkdown,omitempty" validate:"max=20000"`
+	RosterHTML        string `json:"rosterHtml,omitempty" bson:"rosterHtml,omitempty" validate:"max=20000"`
+}
+
+// Template returns the campaign's template for a kind of rendering
+// ("character" or "roster") in a format ("markdown" or "html"), or "" for the default
+func (t CampaignTemplates) Template(kind, format string) string {
+	switch kind + "/" + format {
+	case "character/markdown":
+		return t.CharacterMarkdown
+	case "character/html":
+		return t.CharacterHTML
+	case "roster/markdown":
+		return t.RosterMarkdown
+	case "roster/html":
+		return t.RosterHTML
+	}
+	return ""
+}
+
+// HasParty reports whether the campaign contains a party with the given ID
+func (c *Campaign) HasParty(partyID string) bool {
+	for _, party := range c.Parties {
+		if party.ID == partyID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMember reports whether the user is the DM or one of the players
+func (c *Campaign) IsMember(userID string) bool {
+	if c.DMID == userID {
+		return true
+	}
+	for _, player := range c.Players {
+		if player == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsViewer reports whether the user has read-only access to the campaign
+func (c *Campaign) IsViewer(userID string) bool {
+	for _, viewer := range c.Viewers {
+		if viewer == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -9,6 +12,7 @@ type Character struct {
 	ID               string            `json:"id" bson:"id" swaggo:"unique"`
 	CharacterName    string            `json:"characterName" bson:"characterName" validate:"required" swaggo:"required"`
 	PlayerName       string            `json:"playerName" bson:"playerName,omitempty"`
+	OwnerID          string            `json:"ownerId,omitempty" bson:"ownerId,omitempty"`
 	Race             string            `json:"race" bson:"race" validate:"required" swaggo:"required"`
 	Subrace          string            `json:"subrace" bson:"subrace,omitempty"`
 	Class            string            `json:"class" bson:"class" validate:"required" swaggo:"required"`
@@ -19,10 +23,76 @@ type Character struct {
 	Background       string            `json:"background" bson:"background,omitempty"`
 	Alignment        string            `json:"alignment" bson:"alignment,omitempty" validate:"omitempty,alignment"`
 	AbilityScores    AbilityScores     `json:"abilityScores" bson:"abilityScores" validate:"required" swaggo:"required"`
+	Backstory        string            `json:"backstory" bson:"backstory,omitempty" validate:"max=20000"`
+	Personality      string            `json:"personality" bson:"personality,omitempty" validate:"max=5000"`
+	Features         []string          `json:"features" bson:"features,omitempty" validate:"max=100,dive,max=1000"`
+	Notes            string            `json:"notes" bson:"notes,omitempty" validate:"max=20000"`
+	CampaignID       string            `json:"campaignId,omitempty" bson:"campaignId,omitempty"`
+	PartyID          string            `json:"partyId,omitempty" bson:"partyId,omitempty"`
+	SecretBackstory  string            `json:"secretBackstory,omitempty" bson:"secretBackstory,omitempty"`
+	DMNotes          string            `json:"dmNotes,omitempty" bson:"dmNotes,omitempty"`
 	CreatedAt        time.Time         `json:"createdAt" bson:"createdAt"`
 	UpdatedAt        time.Time         `json:"updatedAt" bson:"updatedAt"`
 }
 
+// TotalLevel returns the character's level across its primary class and all multiclasses
+func (c *Character) TotalLevel() int {
+	total := c.Level
+	for _, mc := range c.Multiclass {
+		total += mc.Level
+	}
+	return total
+}
+
+// ClassSummary describes the character's classes, e.g. "Wizard 5 (School of Evocation) / Fighter 1"
+func (c *Character) ClassSummary() string {
+	describe := func(class, subclass string, level int) string {
+		text := fmt.Sprintf("%s %d", class, level)
+		if subclass != "" {
+			text += " (" + subclass + ")"
+		}
+		return text
+	}
+	parts := []string{describe(c.Class, c.Subclass, c.Level)}
+	for _, mc := range c.Multiclass {
+		parts = append(parts, describe(mc.Class, mc.Subclass, mc.Level))
+	}
+	return strings.Join(parts, " / ")
+}
+
+// RaceName names the character's race with its subrace, e.g. "High Elf" or "Hill Dwarf"
+func (c *Character) RaceName() string {
+	switch {
+	case c.Subrace == "":
+		return c.Race
+	case strings.Contains(c.Subrace, c.Race):
+		return c.Subrace
+	default:
+		return c.Subrace + " " + c.Race
+	}
+}
+
+// Slug returns the character's name in lower case with dashes, for file
+// names, or "character" when nothing of the name is left
+func (c *Character) Slug() string {
+	slug := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(c.CharacterName), "-"), "-")
+	if slug == "" {
+		return "character"
+	}
+	return slug
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ProficiencyBonus returns the proficiency bonus for the character's total level
+func (c *Character) ProficiencyBonus() int {
+	level := c.TotalLevel()
+	if level < 1 {
+		level = 1
+	}
+	return 2 + (level-1)/4
+}
+
 // MulticlassEntry represents a multiclass entry
 type MulticlassEntry struct {
 	Class    string `json:"class" bson:"class" validate:"required"`
@@ -45,6 +115,49 @@ type AbilityScore struct {
 	Base int `json:"base" bson:"base" validate:"min=1,max=20"`
 }
 
+// Modifier returns the ability modifier for the score
+func (a AbilityScore) Modifier() int {
+	// Round down, including for scores below 10
+	if a.Base < 10 {
+		return (a.Base - 11) / 2
+	}
+	return (a.Base - 10) / 2
+}
+
+// Get returns the score for an ability by its JSON name, e.g. "dexterity"
+func (s AbilityScores) Get(ability string) (AbilityScore, bool) {
+	switch ability {
+	case "strength":
+		return s.Strength, true
+	case "dexterity":
+		return s.Dexterity, true
+	case "constitution":
+		return s.Constitution, true
+	case "intelligence":
+		return s.Intelligence, true
+	case "wisdom":
+		return s.Wisdom, true
+	case "charisma":
+		return s.Charisma, true
+	}
+	return AbilityScore{}, false
+}
+
+// RollRequest asks for an ability check against a character
+type RollRequest struct {
+	Ability string `json:"ability" validate:"required" swaggo:"required"`
+}
+
+// RollResult is the outcome of an ability check
+type RollResult struct {
+	CharacterID   string `json:"characterId"`
+	CharacterName string `json:"characterName"`
+	Ability       string `json:"ability"`
+	Roll          int    `json:"roll"`
+	Modifier      int    `json:"modifier"`
+	Total         int    `json:"total"`
+}
+
 // PaginationResponse represents a paginated response
 type PaginationResponse struct {
 	Data       []Character `json:"data"`
@@ -53,12 +166,13 @@ type PaginationResponse struct {
 
 // Pagination represents pagination metadata
 type Pagination struct {
-	Page       int  `json:"page"`
-	Limit      int  `json:"limit"`
-	Total      int  `json:"total"`
-	TotalPages int  `json:"totalPages"`
-	HasNext    bool `json:"hasNext"`
-	HasPrev    bool `json:"hasPrev"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	Total      int    `json:"total"`
+	TotalPages int    `json:"totalPages"`
+	HasNext    bool   `json:"hasNext"`
+	HasPrev    bool   `json:"hasPrev"`
+	NextCursor string `json:"nextCursor,omitempty"` // continues after this page with keyset pagination
 }
 
 // ValidationError represents a validation error
@@ -0,0 +1,126 @@
+package models
+
+// hitDice maps each class to the size of its hit die
+var hitDice = map[string]int{
+	"Barbarian": 12,
+	"Fighter":   10, "Paladin": 10, "Ranger": 10,
+	"Bard": 8, "Cleric": 8, "Druid": 8, "Monk": 8, "Rogue": 8, "Warlock": 8,
+	"Sorcerer": 6, "Wizard": 6,
+}
+
+// HitDie returns the size of a class's hit die
+func HitDie(class string) int {
+	if die, ok := hitDice[class]; ok {
+		return die
+	}
+	return 8
+}
+
+// MaxHitPoints returns the character's hit point maximum with fixed hit
+// points: the whole hit die of the primary class at first level and the
+// rounded-up average of the class's die for every level after, each plus the
+// Constitution modifier and at least 1.
+func (c *Character) MaxHitPoints() int {
+	constitution := c.AbilityScores.Constitution.Modifier()
+	perLevel := func(hp int) int {
+		return max(hp+constitution, 1)
+	}
+
+	total := perLevel(HitDie(c.Class))
+	for level := 2; level <= c.Level; level++ {
+		total += perLevel(HitDie(c.Class)/2 + 1)
+	}
+	for _, mc := range c.Multiclass {
+		total += mc.Level * perLevel(HitDie(mc.Class)/2+1)
+	}
+	return total
+}
+
+// Skill is a skill and the ability it is checked with
+type Skill struct {
+	Name    string
+	Ability string // JSON name of the ability, e.g. "dexterity"
+}
+
+// Skills lists the 5e skills in alphabetical order
+var Skills = []Skill{
+	{"Acrobatics", "dexterity"},
+	{"Animal Handling", "wisdom"},
+	{"Arcana", "intelligence"},
+	{"Athletics", "strength"},
+	{"Deception", "charisma"},
+	{"History", "intelligence"},
+	{"Insight", "wisdom"},
+	{"Intimidation", "charisma"},
+	{"Investigation", "intelligence"},
+	{"Medicine", "wisdom"},
+	{"Nature", "intelligence"},
+	{"Perception", "wisdom"},
+	{"Performance", "charisma"},
+	{"Persuasion", "charisma"},
+	{"Religion", "intelligence"},
+	{"Sleight of Hand", "dexterity"},
+	{"Stealth", "dexterity"},
+	{"Survival", "wisdom"},
+}
+
+// savingThrows maps each class to the saving throws it is proficient in
+var savingThrows = map[string][]string{
+	"Barbarian": {"strength", "constitution"},
+	"Bard":      {"dexterity", "charisma"},
+	"Cleric":    {"wisdom", "charisma"},
+	"Druid":     {"intelligence", "wisdom"},
+	"Fighter":   {"strength", "constitution"},
+	"Monk":      {"strength", "dexterity"},
+	"Paladin":   {"wisdom", "charisma"},
+	"Ranger":    {"strength", "dexterity"},
+	"Rogue":     {"dexterity", "intelligence"},
+	"Sorcerer":  {"constitution", "charisma"},
+	"Warlock":   {"wisdom", "charisma"},
+	"Wizard":    {"intelligence", "wisdom"},
+}
+
+// SavingThrowProficient reports whether the character is proficient in an
+// ability's saving throw, which only the primary class grants
+func (c *Character) SavingThrowProficient(ability string) bool {
+	for _, a := range savingThrows[c.Class] {
+		if a == ability {
+			return true
+		}
+	}
+	return false
+}
+
+// SavingThrow returns the character's saving throw bonus for an ability
+func (c *Character) SavingThrow(ability string) int {
+	score, _ := c.AbilityScores.Get(ability)
+	if c.SavingThrowProficient(ability) {
+		return score.Modifier() + c.ProficiencyBonus()
+	}
+	return score.Modifier()
+}
+
+// spellcasting maps the classes and subclasses that cast spells to their spellcasting ability
+var spellcasting = map[string]string{
+	"Bard": "charisma", "Cleric": "wisdom", "Druid": "wisdom", "Paladin": "charisma",
+	"Ranger": "wisdom", "Sorcerer": "charisma", "Warlock": "charisma", "Wizard": "intelligence",
+	"Eldritch Knight": "intelligence", "Arcane Trickster": "intelligence",
+}
+
+// SpellcastingAbility returns the ability the character's primary class
+// casts spells with, or "" when it casts none
+func (c *Character) SpellcastingAbility() string {
+	if ability, ok := spellcasting[c.Class]; ok {
+		return ability
+	}
+	return spellcasting[c.Subclass]
+}
+
+// Speed returns the character's walking speed in feet
+func (c *Character) Speed() int {
+	switch c.Race {
+	case "Dwarf", "Halfling", "Gnome":
+		return 25
+	}
+	return 30
+}
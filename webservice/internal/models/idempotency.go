@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// IdempotencyRecord remembers the request made with an Idempotency-Key and,
+// once it completes, the response to replay on retries
+type IdempotencyRecord struct {
+	Key         string    `bson:"key"`         // the client's key, scoped to the caller
+	Fingerprint string    `bson:"fingerprint"` // hash of the method, URI and body
+	Completed   bool      `bson:"completed"`
+	Status      int       `bson:"status,omitempty"`
+	ContentType string    `bson:"contentType,omitempty"`
+	Body        []byte    `bson:"body,omitempty"`
+	CreatedAt   time.Time `bson:"createdAt"`
+	ExpiresAt   time.Time `bson:"expiresAt"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// ImportResponse reports a character imported from another tool's format
+type ImportResponse struct {
+	Data   Character `json:"data"`
+	Format string    `json:"format"` // the format the document was read as
+	DryRun bool      `json:"dryRun"`
+	Saved  bool      `json:"saved"`
+	// Unmapped lists the parts of the document with no place in a character
+	Unmapped []string `json:"unmapped"`
+	// Warnings covers values that could not be mapped and validation problems
+	// with the mapped character, which prevent saving it
+	Warnings []ValidationError `json:"warnings"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// SearchHit is a character matched by full-text search
+type SearchHit struct {
+	Character  Character   `json:"character"`
+	Score      float64     `json:"score"`
+	Highlights []Highlight `json:"highlights"`
+}
+
+// Highlight is an excerpt of a matched field. Snippet is HTML-escaped text
+// with each matched term wrapped in <mark></mark>.
+type Highlight struct {
+	Field   string `json:"field"`
+	Snippet string `json:"snippet"`
+}
+
+// SearchResponse represents a page of search results
+type SearchResponse struct {
+	Data       []SearchHit `json:"data"`
+	Pagination Pagination  `json:"pagination"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// User roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// User represents an account that can own characters and run campaigns
+type User struct {
+	ID           string    `json:"id" bson:"id" swaggo:"unique"`
+	Username     string    `json:"username" bson:"username"`
+	Email        string    `json:"email" bson:"email,omitempty"`
+	PasswordHash string    `json:"-" bson:"passwordHash"`
+	Role         string    `json:"role" bson:"role"`
+	CreatedAt    time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// RegisterRequest represents a new account registration
+type RegisterRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=32,alphanum" swaggo:"required"`
+	Email    string `json:"email" validate:"omitempty,email"`
+	Password string `json:"password" validate:"required,min=8,max=72" swaggo:"required"`
+}
+
+// LoginRequest represents a username and password login
+type LoginRequest struct {
+	Username string `json:"username" validate:"required" swaggo:"required"`
+	Password string `json:"password" validate:"required" swaggo:"required"`
+}
+
+// TokenResponse is returned after a successful login
+type TokenResponse struct {
+	Token     string    `json:"token"`
+	TokenType string    `json:"tokenType"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	User      User      `json:"user"`
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+)
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryDead      = "dead" // retries exhausted; kept as a dead-letter record
+)
+
+// Webhook is an endpoint that receives signed event payloads, for one campaign or globally
+type Webhook struct {
+	ID         string    `json:"id" bson:"id" swaggo:"unique"`
+	URL        string    `json:"url" bson:"url"`
+	Secret     string    `json:"-" bson:"secret"`
+	OwnerID    string    `json:"ownerId" bson:"ownerId"`
+	CampaignID string    `json:"campaignId,omitempty" bson:"campaignId,omitempty"`
+	Events     []string  `json:"events" bson:"events,omitempty"`
+	Active     bool      `json:"active" bson:"active"`
+	CreatedAt  time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+// Subscribes reports whether the webhook wants events of the given type
+func (w *Webhook) Subscribes(eventType string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range w.Events {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery records one event's delivery to one webhook, including every retry
+type WebhookDelivery struct {
+	ID             string     `json:"id" bson:"id"`
+	WebhookID      string     `json:"webhookId" bson:"webhookId"`
+	EventID        string     `json:"eventId" bson:"eventId"`
+	EventType      string     `json:"eventType" bson:"eventType"`
+	Payload        string     `json:"payload" bson:"payload"`
+	Status         string     `json:"status" bson:"status"`
+	Attempts       int        `json:"attempts" bson:"attempts"`
+	LastError      string     `json:"lastError,omitempty" bson:"lastError,omitempty"`
+	ResponseStatus int        `json:"responseStatus,omitempty" bson:"responseStatus,omitempty"`
+	NextAttemptAt  time.Time  `json:"nextAttemptAt" bson:"nextAttemptAt"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty" bson:"deliveredAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt" bson:"updatedAt"`
+}
+
+// CreateWebhookRequest represents a request to register a webhook endpoint
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url" swaggo:"required"`
+	CampaignID string   `json:"campaignId"`
+	Events     []string `json:"events" validate:"dive,oneof=character.created character.updated character.deleted character.leveled_up"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16"`
+}
+
+// CreateWebhookResponse carries a newly registered webhook and its signing secret
+type CreateWebhookResponse struct {
+	Secret  string  `json:"secret"`
+	Webhook Webhook `json:"webhook"`
+}
@@ -0,0 +1,244 @@
+// Package render writes characters and campaign rosters as Markdown or HTML
+// from templates, for wikis and chat posts. Campaigns may replace the default
+// templates in their settings.
+//
+// Character templates are executed with a Character: every field of
+// models.Character (.CharacterName, .Level, .Features, ...), its derived
+// values (.TotalLevel, .ProficiencyBonus, .MaxHitPoints, .Speed,
+// .SpellcastingAbility, .ClassSummary, .RaceName), .Abilities, a list of
+// {Name, Short, Score, Modifier}, and .CampaignName. Roster templates are
+// executed with a Roster: .Campaign, .Party (nil for the whole campaign),
+// .Characters, a list of Character, and .More, the number of characters left
+// off the roster.
+//
+// Besides the built-in functions, templates may call signed (a bonus with
+// its sign), join (a list with a separator), md (text escaped for Markdown)
+// and upper and lower.
+package render
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	texttemplate "text/template"
+
+	"player-character/internal/models"
+)
+
+// Formats of rendering
+const (
+	Markdown = "markdown"
+	HTML     = "html"
+)
+
+// Kinds of template
+const (
+	KindCharacter = "character"
+	KindRoster    = "roster"
+)
+
+// maxOutput bounds what a template may write, so a campaign's template cannot produce runaway output
+const maxOutput = 1 << 20
+
+// ErrTooLarge is returned when a template writes more than maxOutput bytes
+var ErrTooLarge = errors.New("rendered output is too large")
+
+// TemplateError is a campaign's template failing to parse or run
+type TemplateError struct {
+	Err error
+}
+
+func (e *TemplateError) Error() string {
+	return "campaign template: " + e.Err.Error()
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+//go:embed templates
+var defaults embed.FS
+
+// Formats returns the supported formats
+func Formats() []string {
+	return []string{Markdown, HTML}
+}
+
+// ContentType returns the media type of a format
+func ContentType(format string) string {
+	if format == HTML {
+		return "text/html; charset=utf-8"
+	}
+	return "text/markdown; charset=utf-8"
+}
+
+// Ability is one ability score of a Character
+type Ability struct {
+	Name     string // e.g. "Strength"
+	Short    string // e.g. "STR"
+	Score    int
+	Modifier int
+}
+
+// Character is the data character templates are executed with
+type Character struct {
+	*models.Character
+	Abilities    []Ability
+	CampaignName string
+}
+
+// Roster is the data roster templates are executed with
+type Roster struct {
+	Campaign   *models.Campaign
+	Party      *models.Party
+	Characters []Character
+	More       int
+}
+
+// abilities lists the abilities in stat block order
+var abilities = []struct{ field, name, short string }{
+	{"strength", "Strength", "STR"},
+	{"dexterity", "Dexterity", "DEX"},
+	{"constitution", "Constitution", "CON"},
+	{"intelligence", "Intelligence", "INT"},
+	{"wisdom", "Wisdom", "WIS"},
+	{"charisma", "Charisma", "CHA"},
+}
+
+// newCharacter builds the template data for a character
+func newCharacter(c *models.Character, campaign *models.Campaign) Character {
+	view := Character{Character: c}
+	for _, ability := range abilities {
+		score, _ := c.AbilityScores.Get(ability.field)
+		view.Abilities = append(view.Abilities, Ability{Name: ability.name, Short: ability.short, Score: score.Base, Modifier: score.Modifier()})
+	}
+	if campaign != nil {
+		view.CampaignName = campaign.Name
+	}
+	return view
+}
+
+// RenderCharacter writes a character in a format, using the campaign's
+// template when it has one. The campaign may be nil.
+func RenderCharacter(w io.Writer, c *models.Character, campaign *models.Campaign, format string) error {
+	tmpl, custom, err := load(KindCharacter, format, campaign)
+	if err != nil {
+		return err
+	}
+	return execute(w, tmpl, custom, newCharacter(c, campaign))
+}
+
+// RenderRoster writes a campaign's characters, or one party's, in a format.
+// total is the number of characters in the roster, of which characters may
+// hold only the first.
+func RenderRoster(w io.Writer, campaign *models.Campaign, party *models.Party, characters []models.Character, total int, format string) error {
+	tmpl, custom, err := load(KindRoster, format, campaign)
+	if err != nil {
+		return err
+	}
+	roster := Roster{Campaign: campaign, Party: party, More: max(total-len(characters), 0)}
+	for i := range characters {
+		roster.Characters = append(roster.Characters, newCharacter(&characters[i], campaign))
+	}
+	return execute(w, tmpl, custom, roster)
+}
+
+// Check parses a template, to reject broken templates before they are saved
+func Check(kind, format, text string) error {
+	_, err := parse(kind, format, text)
+	return err
+}
+
+// executor is a parsed text or HTML template
+type executor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// load parses the campaign's template for a kind and format, or the
+// default, and reports whether it is the campaign's
+func load(kind, format string, campaign *models.Campaign) (executor, bool, error) {
+	if format != Markdown && format != HTML {
+		return nil, false, fmt.Errorf("unknown format %q (expected one of %s)", format, strings.Join(Formats(), ", "))
+	}
+	if campaign != nil {
+		if text := campaign.Settings.Templates.Template(kind, format); text != "" {
+			tmpl, err := parse(kind, format, text)
+			if err != nil {
+				return nil, true, &TemplateError{Err: err}
+			}
+			return tmpl, true, nil
+		}
+	}
+
+	extension := map[string]string{Markdown: "md", HTML: "html"}[format]
+	text, err := defaults.ReadFile("templates/" + kind + "." + extension + ".tmpl")
+	if err != nil {
+		return nil, false, fmt.Errorf("unknown template kind %q", kind)
+	}
+	tmpl, err := parse(kind, format, string(text))
+	return tmpl, false, err
+}
+
+// parse parses a template, escaping values for HTML in the HTML format
+func parse(kind, format, text string) (executor, error) {
+	switch format {
+	case Markdown:
+		return texttemplate.New(kind).Funcs(funcs).Parse(text)
+	case HTML:
+		return htmltemplate.New(kind).Funcs(htmltemplate.FuncMap(funcs)).Parse(text)
+	}
+	return nil, fmt.Errorf("unknown format %q (expected one of %s)", format, strings.Join(Formats(), ", "))
+}
+
+// execute runs a template, failing rather than writing more than maxOutput.
+// Nothing is written when it fails.
+func execute(w io.Writer, tmpl executor, custom bool, data interface{}) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&limitedBuffer{buf: &buf}, data); err != nil {
+		if custom {
+			return &TemplateError{Err: err}
+		}
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// limitedBuffer is a buffer that refuses to grow past maxOutput
+type limitedBuffer struct {
+	buf *bytes.Buffer
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.buf.Len()+len(p) > maxOutput {
+		return 0, ErrTooLarge
+	}
+	return b.buf.Write(p)
+}
+
+var funcs = texttemplate.FuncMap{
+	"signed": func(n int) string { return fmt.Sprintf("%+d", n) },
+	"join":   func(items []string, sep string) string { return strings.Join(items, sep) },
+	"md":     escapeMarkdown,
+	"upper":  strings.ToUpper,
+	"lower":  strings.ToLower,
+}
+
+// markdownSpecial lists the characters that format inline Markdown, including table cell separators
+const markdownSpecial = "\\`*_[]<>#|~"
+
+// escapeMarkdown escapes text so that it shows as written in Markdown
+func escapeMarkdown(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune(markdownSpecial, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,140 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"player-character/internal/models"
+)
+
+func testCharacter() *models.Character {
+	return &models.Character{
+		CharacterName: "Lyra_Moonwhisper",
+		Race:          "Elf",
+		Subrace:       "High Elf",
+		Class:         "Wizard",
+		Subclass:      "School of Evocation",
+		Level:         5,
+		Multiclass:    []models.MulticlassEntry{{Class: "Fighter", Level: 1}},
+		AbilityScores: models.AbilityScores{
+			Strength:     models.AbilityScore{Base: 8},
+			Dexterity:    models.AbilityScore{Base: 14},
+			Constitution: models.AbilityScore{Base: 14},
+			Intelligence: models.AbilityScore{Base: 17},
+			Wisdom:       models.AbilityScore{Base: 12},
+			Charisma:     models.AbilityScore{Base: 10},
+		},
+		Features: []string{"Arcane Recovery", "Second Wind"},
+	}
+}
+
+func TestRenderCharacterDefaults(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderCharacter(&buf, testCharacter(), nil, Markdown); err != nil {
+		t.Fatalf("RenderCharacter: %v", err)
+	}
+	for _, expected := range []string{
+		`## Lyra\_Moonwhisper`,
+		"*High Elf Wizard 5 (School of Evocation) / Fighter 1*",
+		"| STR | DEX | CON | INT | WIS | CHA |",
+		"| 8 (-1) | 14 (+2) | 14 (+2) | 17 (+3) | 12 (+1) | 10 (+0) |",
+		"**Level** 6 · **Hit Points** 40 · **Speed** 30 ft. · **Proficiency Bonus** +3 · **Spellcasting** intelligence",
+		"**Features.** Arcane Recovery, Second Wind",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected the stat block to contain %q, got:\n%s", expected, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := RenderCharacter(&buf, testCharacter(), nil, HTML); err != nil {
+		t.Fatalf("RenderCharacter: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<th title="Strength">STR</th>`) || !strings.Contains(buf.String(), "<li>Second Wind</li>") {
+		t.Errorf("Expected an HTML stat block, got:\n%s", buf.String())
+	}
+}
+
+func TestRenderCampaignTemplates(t *testing.T) {
+	campaign := &models.Campaign{Name: "Phandelver"}
+
+	render := func(text string) (string, error) {
+		campaign.Settings.Templates.CharacterHTML = text
+		var buf bytes.Buffer
+		err := RenderCharacter(&buf, testCharacter(), campaign, HTML)
+		return buf.String(), err
+	}
+
+	out, err := render(`<b>{{.CharacterName}}</b> of {{.CampaignName}}{{range .Abilities}} {{.Short}}{{signed .Modifier}}{{end}}`)
+	if err != nil || out != "<b>Lyra_Moonwhisper</b> of Phandelver STR-1 DEX&#43;2 CON&#43;2 INT&#43;3 WIS&#43;1 CHA&#43;0" {
+		t.Errorf("Expected the campaign template, got %q, %v", out, err)
+	}
+
+	// Values are still escaped in a campaign's HTML template
+	campaign.Name = "<script>"
+	if out, _ := render("{{.CampaignName}}"); out != "&lt;script&gt;" {
+		t.Errorf("Expected the campaign name escaped, got %q", out)
+	}
+
+	var templateErr *TemplateError
+	if _, err := render("{{.Nickname}}"); !errors.As(err, &templateErr) {
+		t.Errorf("Expected a template error for a missing field, got %v", err)
+	}
+	if _, err := render("{{.CharacterName"); !errors.As(err, &templateErr) {
+		t.Errorf("Expected a template error for a broken template, got %v", err)
+	}
+
+	// Output is capped, however the template multiplies it
+	big := "{{range $.Abilities}}{{range $.Abilities}}{{range $.Abilities}}{{range $.Abilities}}{{range $.Abilities}}{{range $.Abilities}}{{$.Backstory}}{{end}}{{end}}{{end}}{{end}}{{end}}{{end}}"
+	character := testCharacter()
+	character.Backstory = strings.Repeat("x", 100)
+	campaign.Settings.Templates.CharacterMarkdown = big
+	var buf bytes.Buffer
+	if err := RenderCharacter(&buf, character, campaign, Markdown); !errors.Is(err, ErrTooLarge) || buf.Len() != 0 {
+		t.Errorf("Expected the output to be capped with nothing written, got %v and %d bytes", err, buf.Len())
+	}
+}
+
+func TestRenderRoster(t *testing.T) {
+	campaign := &models.Campaign{Name: "Phandelver", Parties: []models.Party{{ID: "p1", Name: "Blades"}}}
+	characters := []models.Character{*testCharacter()}
+
+	var buf bytes.Buffer
+	if err := RenderRoster(&buf, campaign, &campaign.Parties[0], characters, 3, Markdown); err != nil {
+		t.Fatalf("RenderRoster: %v", err)
+	}
+	for _, expected := range []string{
+		"## Phandelver: Blades",
+		`| Lyra\_Moonwhisper |  | High Elf | Wizard 5 (School of Evocation) / Fighter 1 | 6 | 40 |`,
+		"*…and 2 more*",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected the roster to contain %q, got:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, tt := range []struct {
+		kind, format, text string
+		valid              bool
+	}{
+		{KindCharacter, Markdown, "{{.CharacterName}}", true},
+		{KindRoster, HTML, "{{range .Characters}}{{.CharacterName}}{{end}}", true},
+		{KindCharacter, Markdown, "{{.CharacterName", false},
+		{KindCharacter, Markdown, "{{nope .CharacterName}}", false},
+		{KindCharacter, "pdf", "{{.CharacterName}}", false},
+	} {
+		if err := Check(tt.kind, tt.format, tt.text); (err == nil) != tt.valid {
+			t.Errorf("Check(%q, %q, %q) = %v, expected valid %v", tt.kind, tt.format, tt.text, err, tt.valid)
+		}
+	}
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	if got := escapeMarkdown("*Bold* | [link] `code` Ünïcode"); got != "\\*Bold\\* \\| \\[link\\] \\`code\\` Ünïcode" {
+		t.Errorf("escapeMarkdown = %q", got)
+	}
+}
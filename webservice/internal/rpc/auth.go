@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"player-character/internal/auth"
+	"player-character/internal/models"
+	"player-character/pkg/database"
+	pb "player-character/pkg/pb/characterv1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodScopes lists the API key scopes accepted for each method, mirroring the REST routes
+var methodScopes = map[string][]string{
+	pb.CharacterService_CreateCharacter_FullMethodName: {models.ScopeWrite},
+	pb.CharacterService_GetCharacter_FullMethodName:    {models.ScopeRead, models.ScopeWrite},
+	pb.CharacterService_UpdateCharacter_FullMethodName: {models.ScopeWrite},
+	pb.CharacterService_DeleteCharacter_FullMethodName: {models.ScopeWrite},
+	pb.CharacterService_ListCharacters_FullMethodName:  {models.ScopeRead, models.ScopeWrite},
+	pb.CharacterService_WatchCharacters_FullMethodName: {models.ScopeRead, models.ScopeWrite},
+}
+
+// authenticator checks the credentials in call metadata, the way auth.Middleware does for HTTP
+type authenticator struct {
+	tokens  *auth.TokenManager
+	apiKeys database.APIKeyStore
+}
+
+// authenticate returns ctx carrying the caller's principal, or an Unauthenticated
+// or PermissionDenied status
+func (a *authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var credential string
+	if values := md.Get("authorization"); len(values) > 0 {
+		credential, _ = strings.CutPrefix(values[0], "Bearer ")
+	}
+	if credential == "" {
+		if values := md.Get("x-api-key"); len(values) > 0 {
+			credential = values[0]
+		}
+	}
+
+	principal, err := auth.Authenticate(a.tokens, a.apiKeys, credential)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if scopes, ok := methodScopes[method]; ok && !principal.HasScope(scopes...) {
+		return nil, status.Error(codes.PermissionDenied, "API key is missing the required scope: "+strings.Join(scopes, " or "))
+	}
+
+	return auth.NewContext(ctx, principal), nil
+}
+
+// UnaryAuth returns an interceptor requiring a valid session token or API key on unary calls
+func UnaryAuth(tokens *auth.TokenManager, apiKeys database.APIKeyStore) grpc.UnaryServerInterceptor {
+	a := &authenticator{tokens: tokens, apiKeys: apiKeys}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth returns an interceptor requiring a valid session token or API key on streaming calls
+func StreamAuth(tokens *auth.TokenManager, apiKeys database.APIKeyStore) grpc.StreamServerInterceptor {
+	a := &authenticator{tokens: tokens, apiKeys: apiKeys}
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticatedStream overrides the stream context with one carrying the principal
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,115 @@
+package rpc
+
+import (
+	"player-character/internal/events"
+	"player-character/internal/models"
+	pb "player-character/pkg/pb/characterv1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toProto converts a character to its protobuf message
+func toProto(character *models.Character) *pb.Character {
+	message := &pb.Character{
+		Id:               character.ID,
+		CharacterName:    character.CharacterName,
+		PlayerName:       character.PlayerName,
+		OwnerId:          character.OwnerID,
+		Race:             character.Race,
+		Subrace:          character.Subrace,
+		Class:            character.Class,
+		Subclass:         character.Subclass,
+		Level:            int32(character.Level),
+		ExperiencePoints: int32(character.ExperiencePoints),
+		Background:       character.Background,
+		Alignment:        character.Alignment,
+		AbilityScores: &pb.AbilityScores{
+			Strength:     &pb.AbilityScore{Base: int32(character.AbilityScores.Strength.Base)},
+			Dexterity:    &pb.AbilityScore{Base: int32(character.AbilityScores.Dexterity.Base)},
+			Constitution: &pb.AbilityScore{Base: int32(character.AbilityScores.Constitution.Base)},
+			Intelligence: &pb.AbilityScore{Base: int32(character.AbilityScores.Intelligence.Base)},
+			Wisdom:       &pb.AbilityScore{Base: int32(character.AbilityScores.Wisdom.Base)},
+			Charisma:     &pb.AbilityScore{Base: int32(character.AbilityScores.Charisma.Base)},
+		},
+		Backstory:       character.Backstory,
+		Personality:     character.Personality,
+		Features:        character.Features,
+		Notes:           character.Notes,
+		CampaignId:      character.CampaignID,
+		PartyId:         character.PartyID,
+		SecretBackstory: character.SecretBackstory,
+		DmNotes:         character.DMNotes,
+		CreatedAt:       timestamppb.New(character.CreatedAt),
+		UpdatedAt:       timestamppb.New(character.UpdatedAt),
+	}
+	for _, mc := range character.Multiclass {
+		message.Multiclass = append(message.Multiclass, &pb.MulticlassEntry{
+			Class:    mc.Class,
+			Subclass: mc.Subclass,
+			Level:    int32(mc.Level),
+		})
+	}
+	return message
+}
+
+// fromProto converts a protobuf message to a character. Server-managed fields
+// (owner and timestamps) are ignored.
+func fromProto(message *pb.Character) *models.Character {
+	if message == nil {
+		return &models.Character{}
+	}
+
+	scores := message.GetAbilityScores()
+	character := &models.Character{
+		ID:               message.GetId(),
+		CharacterName:    message.GetCharacterName(),
+		PlayerName:       message.GetPlayerName(),
+		Race:             message.GetRace(),
+		Subrace:          message.GetSubrace(),
+		Class:            message.GetClass(),
+		Subclass:         message.GetSubclass(),
+		Level:            int(message.GetLevel()),
+		ExperiencePoints: int(message.GetExperiencePoints()),
+		Background:       message.GetBackground(),
+		Alignment:        message.GetAlignment(),
+		AbilityScores: models.AbilityScores{
+			Strength:     models.AbilityScore{Base: int(scores.GetStrength().GetBase())},
+			Dexterity:    models.AbilityScore{Base: int(scores.GetDexterity().GetBase())},
+			Constitution: models.AbilityScore{Base: int(scores.GetConstitution().GetBase())},
+			Intelligence: models.AbilityScore{Base: int(scores.GetIntelligence().GetBase())},
+			Wisdom:       models.AbilityScore{Base: int(scores.GetWisdom().GetBase())},
+			Charisma:     models.AbilityScore{Base: int(scores.GetCharisma().GetBase())},
+		},
+		Backstory:       message.GetBackstory(),
+		Personality:     message.GetPersonality(),
+		Features:        message.GetFeatures(),
+		Notes:           message.GetNotes(),
+		CampaignID:      message.GetCampaignId(),
+		PartyID:         message.GetPartyId(),
+		SecretBackstory: message.GetSecretBackstory(),
+		DMNotes:         message.GetDmNotes(),
+	}
+	for _, mc := range message.GetMulticlass() {
+		character.Multiclass = append(character.Multiclass, models.MulticlassEntry{
+			Class:    mc.GetClass(),
+			Subclass: mc.GetSubclass(),
+			Level:    int(mc.GetLevel()),
+		})
+	}
+	return character
+}
+
+// eventToProto converts a change event to its protobuf message
+func eventToProto(event events.Event) *pb.WatchCharactersResponse {
+	message := &pb.WatchCharactersResponse{
+		EventId:     event.ID,
+		Type:        event.Type,
+		CharacterId: event.CharacterID,
+		CampaignId:  event.CampaignID,
+		Timestamp:   timestamppb.New(event.Timestamp),
+	}
+	if event.Character != nil {
+		message.Character = toProto(event.Character)
+	}
+	return message
+}
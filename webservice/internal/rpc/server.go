@@ -0,0 +1,253 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"player-character/internal/auth"
+	"player-character/internal/events"
+	"player-character/internal/models"
+	"player-character/internal/service"
+	"player-character/pkg/database"
+	"player-character/pkg/filter"
+	"player-character/pkg/logging"
+	pb "player-character/pkg/pb/characterv1"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultBatchSize = 100
+	maxBatchSize     = 100
+)
+
+// validSortFields are the fields characters can be listed by, as in the REST API
+var validSortFields = map[string]bool{
+	"characterName": true,
+	"level":         true,
+	"race":          true,
+	"class":         true,
+	"createdAt":     true,
+}
+
+// CharacterServer implements the gRPC character service on top of the same
+// services as the REST handlers, so both transports share validation,
+// authorization and events
+type CharacterServer struct {
+	pb.UnimplementedCharacterServiceServer
+
+	characters *service.Characters
+	campaigns  *service.Campaigns
+	broker     events.Broker
+	logger     *logging.Logger
+}
+
+// NewCharacterServer creates a new character server. A nil broker disables WatchCharacters.
+func NewCharacterServer(characters *service.Characters, campaigns *service.Campaigns, broker events.Broker, logger *logging.Logger) *CharacterServer {
+	return &CharacterServer{
+		characters: characters,
+		campaigns:  campaigns,
+		broker:     broker,
+		logger:     logger,
+	}
+}
+
+// CreateCharacter creates a character owned by the caller
+func (s *CharacterServer) CreateCharacter(ctx context.Context, req *pb.CreateCharacterRequest) (*pb.CreateCharacterResponse, error) {
+	character := fromProto(req.GetCharacter())
+	if err := s.characters.Create(ctx, auth.FromContext(ctx), character); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.CreateCharacterResponse{Character: toProto(character)}, nil
+}
+
+// GetCharacter returns one character
+func (s *CharacterServer) GetCharacter(ctx context.Context, req *pb.GetCharacterRequest) (*pb.GetCharacterResponse, error) {
+	character, err := s.characters.Get(ctx, auth.FromContext(ctx), req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.GetCharacterResponse{Character: toProto(character)}, nil
+}
+
+// UpdateCharacter replaces a character
+func (s *CharacterServer) UpdateCharacter(ctx context.Context, req *pb.UpdateCharacterRequest) (*pb.UpdateCharacterResponse, error) {
+	character := fromProto(req.GetCharacter())
+	if err := s.characters.Update(ctx, auth.FromContext(ctx), req.GetId(), character); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.UpdateCharacterResponse{Character: toProto(character)}, nil
+}
+
+// DeleteCharacter removes a character
+func (s *CharacterServer) DeleteCharacter(ctx context.Context, req *pb.DeleteCharacterRequest) (*pb.DeleteCharacterResponse, error) {
+	if err := s.characters.Delete(ctx, auth.FromContext(ctx), req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DeleteCharacterResponse{}, nil
+}
+
+// ListCharacters streams every character the caller may read, reading the
+// store one batch at a time. Batches continue from a cursor on the last
+// character sent, so changes made while streaming do not shift later batches.
+func (s *CharacterServer) ListCharacters(req *pb.ListCharactersRequest, stream pb.CharacterService_ListCharactersServer) error {
+	ctx := stream.Context()
+	principal := auth.FromContext(ctx)
+
+	opts, err := listOptions(req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		characters, _, err := s.characters.List(ctx, principal, opts)
+		if err != nil {
+			return toStatus(err)
+		}
+
+		for i := range characters {
+			if err := stream.Send(&pb.ListCharactersResponse{Character: toProto(&characters[i])}); err != nil {
+				return err
+			}
+		}
+
+		if len(characters) < opts.Limit {
+			return nil
+		}
+		opts.After = database.NewCursor(opts, &characters[len(characters)-1])
+	}
+}
+
+// listOptions validates a list request and converts it to store options for the first batch
+func listOptions(req *pb.ListCharactersRequest) (database.ListOptions, error) {
+	opts := database.ListOptions{
+		Page:       1,
+		Limit:      int(req.GetBatchSize()),
+		SortBy:     req.GetSortBy(),
+		SortOrder:  req.GetSortOrder(),
+		Search:     req.GetSearch(),
+		CampaignID: req.GetCampaignId(),
+		PartyID:    req.GetPartyId(),
+		OwnerID:    req.GetOwnerId(),
+		SkipCount:  true,
+	}
+	if opts.Limit == 0 {
+		opts.Limit = defaultBatchSize
+	}
+	if opts.SortBy == "" {
+		opts.SortBy = "createdAt"
+	}
+	if opts.SortOrder == "" {
+		opts.SortOrder = "desc"
+	}
+
+	expr, err := filter.Parse(req.GetFilter())
+	if err != nil {
+		return opts, status.Error(codes.InvalidArgument, err.Error())
+	}
+	opts.Filter = expr
+
+	if opts.Limit < 1 || opts.Limit > maxBatchSize {
+		return opts, status.Error(codes.InvalidArgument, "Invalid batch_size (1-100)")
+	}
+	if !validSortFields[opts.SortBy] {
+		return opts, status.Error(codes.InvalidArgument, "Invalid sort_by")
+	}
+	if opts.SortOrder != "asc" && opts.SortOrder != "desc" {
+		return opts, status.Error(codes.InvalidArgument, "Invalid sort_order (must be 'asc' or 'desc')")
+	}
+	return opts, nil
+}
+
+// WatchCharacters streams change events for one character, one campaign, or
+// every character the caller may read. Each event is checked against the
+// caller's current access and redacted before sending.
+func (s *CharacterServer) WatchCharacters(req *pb.WatchCharactersRequest, stream pb.CharacterService_WatchCharactersServer) error {
+	if s.broker == nil {
+		return status.Error(codes.Unimplemented, "Change events are not enabled")
+	}
+
+	ctx := stream.Context()
+	principal := auth.FromContext(ctx)
+	filter := events.Filter{CharacterID: req.GetCharacterId(), CampaignID: req.GetCampaignId()}
+
+	// Scoped watches need read access to their target up front, as with the SSE endpoints
+	if filter.CharacterID != "" {
+		if _, err := s.characters.Get(ctx, principal, filter.CharacterID); err != nil {
+			return toStatus(err)
+		}
+	}
+	if filter.CampaignID != "" {
+		if _, err := s.campaigns.Get(ctx, principal, filter.CampaignID); err != nil {
+			return toStatus(err)
+		}
+	}
+	scoped := filter.CharacterID != "" || filter.CampaignID != ""
+
+	subscription, err := s.broker.Subscribe(ctx, filter)
+	if err != nil {
+		s.logger.ErrorWithContext(ctx, "Failed to subscribe to events", err)
+		return status.Error(codes.Internal, "Failed to subscribe to events")
+	}
+
+	// Send headers now so clients can tell when the watch is live
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-subscription:
+			if !ok {
+				return nil
+			}
+			// Deletion events carry no character to check, so an unscoped
+			// watch only passes them on to admins
+			if event.Character == nil && !scoped && (principal == nil || principal.Role != models.RoleAdmin) {
+				continue
+			}
+			event, visible := s.characters.VisibleEvent(principal, event)
+			if !visible {
+				continue
+			}
+			if err := stream.Send(eventToProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toStatus maps a service error to a gRPC status, the way respondWithError does for HTTP
+func toStatus(err error) error {
+	var serviceErr *service.Error
+	if !errors.As(err, &serviceErr) {
+		return status.Error(codes.Internal, "Internal server error")
+	}
+
+	switch serviceErr.Kind {
+	case service.KindInvalid:
+		st := status.New(codes.InvalidArgument, serviceErr.Message)
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range serviceErr.Validation {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Message,
+			})
+		}
+		if detailed, err := st.WithDetails(badRequest); err == nil {
+			st = detailed
+		}
+		return st.Err()
+	case service.KindForbidden:
+		return status.Error(codes.PermissionDenied, serviceErr.Message)
+	case service.KindNotFound:
+		return status.Error(codes.NotFound, serviceErr.Message)
+	default:
+		return status.Error(codes.Internal, serviceErr.Message)
+	}
+}
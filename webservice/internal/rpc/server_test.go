@@ -0,0 +1,327 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"player-character/internal/auth"
+	"player-character/internal/authz"
+	"player-character/internal/events"
+	"player-character/internal/models"
+	"player-character/internal/service"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+	pb "player-character/pkg/pb/characterv1"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fixture struct {
+	client  pb.CharacterServiceClient
+	tokens  *auth.TokenManager
+	apiKeys *database.MemoryAPIKeyStore
+	store   *database.MemoryStore
+}
+
+func setup(t *testing.T) *fixture {
+	t.Helper()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+
+	tokens, err := auth.NewTokenManager([]byte("test-secret-that-is-at-least-32-bytes"), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create token manager: %v", err)
+	}
+	apiKeys := database.NewMemoryAPIKeyStore()
+	store := database.NewMemoryStore()
+	campaigns := database.NewMemoryCampaignStore()
+	policy := authz.NewPolicy(store, campaigns)
+	broker := events.NewMemoryBroker()
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryAuth(tokens, apiKeys)),
+		grpc.StreamInterceptor(StreamAuth(tokens, apiKeys)),
+	)
+	pb.RegisterCharacterServiceServer(server, NewCharacterServer(
+		service.NewCharacters(store, campaigns, policy, broker, logger),
+		service.NewCampaigns(campaigns, policy, logger),
+		broker, logger))
+
+	listener := bufconn.Listen(1024 * 1024)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &fixture{
+		client:  pb.NewCharacterServiceClient(conn),
+		tokens:  tokens,
+		apiKeys: apiKeys,
+		store:   store,
+	}
+}
+
+// as returns a context authenticated with a session token for the user
+func (f *fixture) as(t *testing.T, userID, role string) context.Context {
+	t.Helper()
+	token, _, err := f.tokens.Issue(&models.User{ID: userID, Username: userID, Role: role})
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// withKey returns a context authenticated with a new API key for the user
+func (f *fixture) withKey(t *testing.T, userID string, scopes ...string) context.Context {
+	t.Helper()
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("Failed to generate API key: %v", err)
+	}
+	if err := f.apiKeys.Create(&models.APIKey{Name: "test", UserID: userID, Prefix: prefix, KeyHash: hash, Scopes: scopes}); err != nil {
+		t.Fatalf("Failed to store API key: %v", err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+}
+
+func newCharacter(name string) *pb.Character {
+	return &pb.Character{
+		CharacterName: name,
+		PlayerName:    "Player",
+		Race:          "Human",
+		Class:         "Fighter",
+		Level:         1,
+		Background:    "Soldier",
+		Alignment:     "Lawful Good",
+		AbilityScores: &pb.AbilityScores{
+			Strength:     &pb.AbilityScore{Base: 15},
+			Dexterity:    &pb.AbilityScore{Base: 14},
+			Constitution: &pb.AbilityScore{Base: 13},
+			Intelligence: &pb.AbilityScore{Base: 12},
+			Wisdom:       &pb.AbilityScore{Base: 10},
+			Charisma:     &pb.AbilityScore{Base: 8},
+		},
+	}
+}
+
+func TestCRUD(t *testing.T) {
+	f := setup(t)
+	ctx := f.as(t, "alice", models.RoleUser)
+
+	created, err := f.client.CreateCharacter(ctx, &pb.CreateCharacterRequest{Character: newCharacter("Aragorn")})
+	if err != nil {
+		t.Fatalf("CreateCharacter failed: %v", err)
+	}
+	id := created.GetCharacter().GetId()
+	if id == "" || created.GetCharacter().GetOwnerId() != "alice" {
+		t.Fatalf("Expected an ID and owner alice, got %+v", created.GetCharacter())
+	}
+
+	got, err := f.client.GetCharacter(ctx, &pb.GetCharacterRequest{Id: id})
+	if err != nil {
+		t.Fatalf("GetCharacter failed: %v", err)
+	}
+	if got.GetCharacter().GetCharacterName() != "Aragorn" {
+		t.Errorf("Expected Aragorn, got %s", got.GetCharacter().GetCharacterName())
+	}
+
+	update := newCharacter("Strider")
+	update.Level = 5
+	updated, err := f.client.UpdateCharacter(ctx, &pb.UpdateCharacterRequest{Id: id, Character: update})
+	if err != nil {
+		t.Fatalf("UpdateCharacter failed: %v", err)
+	}
+	if updated.GetCharacter().GetLevel() != 5 || updated.GetCharacter().GetCharacterName() != "Strider" {
+		t.Errorf("Expected Strider at level 5, got %+v", updated.GetCharacter())
+	}
+
+	if _, err := f.client.DeleteCharacter(ctx, &pb.DeleteCharacterRequest{Id: id}); err != nil {
+		t.Fatalf("DeleteCharacter failed: %v", err)
+	}
+	_, err = f.client.GetCharacter(ctx, &pb.GetCharacterRequest{Id: id})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound after delete, got %v", err)
+	}
+}
+
+func TestValidationDetails(t *testing.T) {
+	f := setup(t)
+	character := newCharacter("")
+	character.Level = 25
+
+	_, err := f.client.CreateCharacter(f.as(t, "alice", models.RoleUser), &pb.CreateCharacterRequest{Character: character})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument, got %v", err)
+	}
+
+	fields := map[string]bool{}
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range badRequest.GetFieldViolations() {
+				fields[v.GetField()] = true
+			}
+		}
+	}
+	if len(fields) == 0 {
+		t.Fatal("Expected field violations in the status details")
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	f := setup(t)
+
+	_, err := f.client.GetCharacter(context.Background(), &pb.GetCharacterRequest{Id: "x"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without credentials, got %v", err)
+	}
+
+	bad := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer nonsense")
+	_, err = f.client.GetCharacter(bad, &pb.GetCharacterRequest{Id: "x"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated with a bad token, got %v", err)
+	}
+
+	readOnly := f.withKey(t, "alice", models.ScopeRead)
+	_, err = f.client.CreateCharacter(readOnly, &pb.CreateCharacterRequest{Character: newCharacter("Gimli")})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for a read-only key, got %v", err)
+	}
+
+	writer := f.withKey(t, "alice", models.ScopeWrite)
+	if _, err := f.client.CreateCharacter(writer, &pb.CreateCharacterRequest{Character: newCharacter("Gimli")}); err != nil {
+		t.Errorf("Expected a write key to create, got %v", err)
+	}
+}
+
+func TestAccessControl(t *testing.T) {
+	f := setup(t)
+	created, err := f.client.CreateCharacter(f.as(t, "alice", models.RoleUser), &pb.CreateCharacterRequest{Character: newCharacter("Aragorn")})
+	if err != nil {
+		t.Fatalf("CreateCharacter failed: %v", err)
+	}
+
+	_, err = f.client.DeleteCharacter(f.as(t, "bob", models.RoleUser), &pb.DeleteCharacterRequest{Id: created.GetCharacter().GetId()})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for another user's character, got %v", err)
+	}
+}
+
+func TestListCharactersStreamsAllBatches(t *testing.T) {
+	f := setup(t)
+	alice := f.as(t, "alice", models.RoleUser)
+	for _, name := range []string{"Aragorn", "Legolas", "Gimli", "Boromir", "Frodo"} {
+		if _, err := f.client.CreateCharacter(alice, &pb.CreateCharacterRequest{Character: newCharacter(name)}); err != nil {
+			t.Fatalf("CreateCharacter failed: %v", err)
+		}
+	}
+	if _, err := f.client.CreateCharacter(f.as(t, "bob", models.RoleUser), &pb.CreateCharacterRequest{Character: newCharacter("Sam")}); err != nil {
+		t.Fatalf("CreateCharacter failed: %v", err)
+	}
+
+	stream, err := f.client.ListCharacters(alice, &pb.ListCharactersRequest{SortBy: "characterName", SortOrder: "asc", BatchSize: 2})
+	if err != nil {
+		t.Fatalf("ListCharacters failed: %v", err)
+	}
+	var names []string
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv failed: %v", err)
+		}
+		names = append(names, msg.GetCharacter().GetCharacterName())
+	}
+
+	expected := []string{"Aragorn", "Boromir", "Frodo", "Gimli", "Legolas"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Expected %s at %d, got %s", expected[i], i, names[i])
+		}
+	}
+
+	stream, err = f.client.ListCharacters(alice, &pb.ListCharactersRequest{BatchSize: 500})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an oversized batch, got %v", err)
+	}
+}
+
+func TestWatchCharacters(t *testing.T) {
+	f := setup(t)
+	alice := f.as(t, "alice", models.RoleUser)
+	created, err := f.client.CreateCharacter(alice, &pb.CreateCharacterRequest{Character: newCharacter("Aragorn")})
+	if err != nil {
+		t.Fatalf("CreateCharacter failed: %v", err)
+	}
+	id := created.GetCharacter().GetId()
+
+	// Others cannot watch a character they cannot read
+	watch, err := f.client.WatchCharacters(f.as(t, "bob", models.RoleUser), &pb.WatchCharactersRequest{CharacterId: id})
+	if err == nil {
+		_, err = watch.Recv()
+	}
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied watching another user's character, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(alice, 5*time.Second)
+	defer cancel()
+	watch, err = f.client.WatchCharacters(ctx, &pb.WatchCharactersRequest{CharacterId: id})
+	if err != nil {
+		t.Fatalf("WatchCharacters failed: %v", err)
+	}
+
+	// Wait until the subscription is in place before changing the character
+	waitForStream(t, watch)
+
+	update := newCharacter("Strider")
+	if _, err := f.client.UpdateCharacter(alice, &pb.UpdateCharacterRequest{Id: id, Character: update}); err != nil {
+		t.Fatalf("UpdateCharacter failed: %v", err)
+	}
+
+	msg, err := watch.Recv()
+	if err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+	if msg.GetType() != events.CharacterUpdated || msg.GetCharacter().GetCharacterName() != "Strider" {
+		t.Errorf("Expected an update to Strider, got %+v", msg)
+	}
+}
+
+// waitForStream blocks until the server has subscribed, so
+// that events published afterwards are not missed
+func waitForStream(t *testing.T, stream grpc.ClientStream) {
+	t.Helper()
+	if _, err := stream.Header(); err != nil {
+		t.Fatalf("Failed to read stream headers: %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"player-character/internal/auth"
+	"player-character/internal/authz"
+	"player-character/internal/events"
+	"player-character/internal/models"
+	"player-character/pkg/database"
+)
+
+// MaxBulkOperations is the most operations one batch may hold
+const MaxBulkOperations = 100
+
+// BulkResult is the outcome of one operation of a batch. Err is set when the
+// operation was rejected or failed; an operation with neither Err nor Applied
+// was valid but skipped because an atomic batch failed elsewhere.
+type BulkResult struct {
+	Op        string
+	ID        string
+	Character *models.Character // the stored character with hidden fields stripped, for create and update
+	Applied   bool
+	Err       error
+}
+
+// bulkItem is an operation that passed its checks and awaits writing
+type bulkItem struct {
+	index    int
+	write    database.BulkWrite
+	existing *models.Character // before the update or delete
+	access   authz.CharacterAccess
+}
+
+// Bulk runs a batch of creates, updates and deletes, checking and validating
+// every operation before writing any. Otherwise the valid operations are
+// applied one by one; when atomic, any rejected operation or failed write
+// leaves the store untouched.
+func (s *Characters) Bulk(ctx context.Context, principal *auth.Principal, operations []models.BulkOperation, atomic bool) ([]BulkResult, error) {
+	if len(operations) == 0 || len(operations) > MaxBulkOperations {
+		return nil, invalid([]models.ValidationError{{
+			Field:   "operations",
+			Message: fmt.Sprintf("A batch must hold between 1 and %d operations", MaxBulkOperations),
+			Code:    "INVALID_BATCH_SIZE",
+		}})
+	}
+
+	results := make([]BulkResult, len(operations))
+	items := make([]bulkItem, 0, len(operations))
+	touched := make(map[string]int) // character ID -> index of the operation changing it
+	rejected := false
+	for i, op := range operations {
+		results[i] = BulkResult{Op: op.Op, ID: op.ID}
+		item, err := s.prepareBulk(ctx, principal, i, op, touched)
+		if err != nil {
+			results[i].Err = err
+			rejected = true
+			continue
+		}
+		items = append(items, item)
+	}
+
+	if atomic {
+		if rejected {
+			return results, nil
+		}
+		writes := make([]database.BulkWrite, len(items))
+		for i, item := range items {
+			writes[i] = item.write
+		}
+		if err := s.store.Bulk(writes); err != nil {
+			s.logger.ErrorWithContext(ctx, "Failed to apply character batch", err, "operations", len(writes))
+			var bulkErr *database.BulkError
+			if errors.As(err, &bulkErr) {
+				item := items[bulkErr.Index]
+				results[item.index].Err = writeError(string(item.write.Op), bulkErr.Err)
+			} else {
+				for _, item := range items {
+					results[item.index].Err = internal("Failed to apply batch", err)
+				}
+			}
+			return results, nil
+		}
+		for _, item := range items {
+			s.finishBulk(ctx, item, &results[item.index])
+		}
+		return results, nil
+	}
+
+	for _, item := range items {
+		if err := s.writeBulk(item.write); err != nil {
+			s.logger.ErrorWithContext(ctx, "Failed to apply batch operation", err,
+				"operation", item.write.Op,
+				"character_id", item.write.ID)
+			results[item.index].Err = err
+			continue
+		}
+		s.finishBulk(ctx, item, &results[item.index])
+	}
+	return results, nil
+}
+
+// prepareBulk checks one operation against the store as it was before the batch
+func (s *Characters) prepareBulk(ctx context.Context, principal *auth.Principal, index int, op models.BulkOperation, touched map[string]int) (bulkItem, error) {
+	item := bulkItem{index: index, write: database.BulkWrite{Op: database.BulkOp(op.Op), ID: op.ID, Character: op.Character}}
+
+	switch item.write.Op {
+	case database.BulkCreate, database.BulkUpdate:
+		if op.Character == nil {
+			return item, invalid([]models.ValidationError{{Field: "character", Message: "character is required for " + op.Op, Code: "REQUIRED"}})
+		}
+	case database.BulkDelete:
+	default:
+		return item, invalid([]models.ValidationError{{Field: "op", Message: "op must be one of create, update, delete", Code: "INVALID_OPERATION"}})
+	}
+
+	// Each operation sees the store as it was before the batch, so a character
+	// may only be changed once per batch
+	id := op.ID
+	if item.write.Op == database.BulkCreate {
+		id = op.Character.ID
+	} else if id == "" {
+		return item, invalid([]models.ValidationError{{Field: "id", Message: "id is required for " + op.Op, Code: "REQUIRED"}})
+	}
+	if id != "" {
+		if earlier, ok := touched[id]; ok {
+			return item, invalid([]models.ValidationError{{
+				Field:   "id",
+				Message: fmt.Sprintf("Character is already changed by operation %d", earlier),
+				Code:    "DUPLICATE_ID",
+			}})
+		}
+		touched[id] = index
+	}
+
+	var err error
+	switch item.write.Op {
+	case database.BulkCreate:
+		err = s.prepareCreate(ctx, principal, op.Character)
+	case database.BulkUpdate:
+		item.existing, item.access, err = s.prepareUpdate(ctx, principal, op.ID, op.Character)
+	case database.BulkDelete:
+		item.existing, err = s.prepareDelete(ctx, principal, op.ID)
+	}
+	return item, err
+}
+
+// writeBulk applies a single operation of a non-atomic batch
+func (s *Characters) writeBulk(w database.BulkWrite) error {
+	switch w.Op {
+	case database.BulkCreate:
+		if err := s.store.Create(w.Character); err != nil {
+			return internal("Failed to create character", err)
+		}
+	case database.BulkUpdate:
+		if err := s.store.Update(w.ID, w.Character); err != nil {
+			return writeError("update", err)
+		}
+	case database.BulkDelete:
+		if err := s.store.Delete(w.ID); err != nil {
+			return writeError("delete", err)
+		}
+	}
+	return nil
+}
+
+// finishBulk announces an applied operation and records its result
+func (s *Characters) finishBulk(ctx context.Context, item bulkItem, result *BulkResult) {
+	result.Applied = true
+	switch item.write.Op {
+	case database.BulkCreate:
+		s.created(ctx, item.write.Character)
+		result.ID = item.write.Character.ID
+		result.Character = item.write.Character
+	case database.BulkUpdate:
+		s.updated(ctx, item.existing, item.write.Character, item.access)
+		result.Character = item.write.Character
+	case database.BulkDelete:
+		s.publish(ctx, events.CharacterDeleted, item.existing)
+	}
+}
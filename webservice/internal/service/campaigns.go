@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+
+	"player-character/internal/auth"
+	"player-character/internal/authz"
+	"player-character/internal/models"
+	"player-character/internal/validation"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+)
+
+// Campaigns applies validation and authorization to campaign operations
+type Campaigns struct {
+	store  database.CampaignStore
+	policy *authz.Policy
+	logger *logging.Logger
+}
+
+// NewCampaigns creates a campaign service. A nil policy disables access checks.
+func NewCampaigns(store database.CampaignStore, policy *authz.Policy, logger *logging.Logger) *Campaigns {
+	return &Campaigns{
+		store:  store,
+		policy: policy,
+		logger: logger,
+	}
+}
+
+// allowed reports whether check passes for the principal's access to the campaign
+func (s *Campaigns) allowed(principal *auth.Principal, campaign *models.Campaign, check func(authz.CampaignAccess) bool) bool {
+	return s.policy == nil || check(s.policy.CampaignAccess(principal, campaign))
+}
+
+// getAuthorized loads a campaign and checks the principal's access to it
+func (s *Campaigns) getAuthorized(principal *auth.Principal, id string, check func(authz.CampaignAccess) bool) (*models.Campaign, error) {
+	campaign, err := s.store.Get(id)
+	if err != nil {
+		return nil, notFound("Campaign not found")
+	}
+
+	if !s.allowed(principal, campaign, check) {
+		return nil, forbidden("You do not have permission to access this campaign")
+	}
+
+	return campaign, nil
+}
+
+// Create validates and stores a new campaign run by the principal
+func (s *Campaigns) Create(ctx context.Context, principal *auth.Principal, campaign *models.Campaign) error {
+	// The creator runs the campaign; only admins may create campaigns on behalf of another DM
+	if principal != nil {
+		if principal.Role != models.RoleAdmin || campaign.DMID == "" {
+			campaign.DMID = principal.UserID
+		}
+	}
+
+	if validationErrors := validation.ValidateCampaign(campaign); len(validationErrors) > 0 {
+		return invalid(validationErrors)
+	}
+
+	if err := s.store.Create(campaign); err != nil {
+		s.logger.ErrorWithContext(ctx, "Failed to create campaign", err,
+			"campaign_name", campaign.Name)
+		return internal("Failed to create campaign", err)
+	}
+
+	s.logger.Info("Campaign created successfully",
+		"campaign_id", campaign.ID,
+		"campaign_name", campaign.Name)
+
+	return nil
+}
+
+// Get retrieves a campaign the principal may read
+func (s *Campaigns) Get(ctx context.Context, principal *auth.Principal, id string) (*models.Campaign, error) {
+	return s.getAuthorized(principal, id, func(a authz.CampaignAccess) bool { return a.Read })
+}
+
+// GetMany retrieves, in one store call, the campaigns with the given IDs that
+// the principal may read. Missing and unreadable campaigns are left out.
+func (s *Campaigns) GetMany(ctx context.Context, principal *auth.Principal, ids []string) (map[string]*models.Campaign, error) {
+	campaigns, err := s.store.GetMany(ids)
+	if err != nil {
+		return nil, internal("Failed to retrieve campaigns", err)
+	}
+
+	readable := make(map[string]*models.Campaign, len(campaigns))
+	for i := range campaigns {
+		if s.allowed(principal, &campaigns[i], func(a authz.CampaignAccess) bool { return a.Read }) {
+			readable[campaigns[i].ID] = &campaigns[i]
+		}
+	}
+	return readable, nil
+}
+
+// List returns one page of campaigns, newest first: every campaign for admins,
+// otherwise the ones the principal runs, plays in or views
+func (s *Campaigns) List(ctx context.Context, principal *auth.Principal, page, limit int) ([]models.Campaign, int, error) {
+	var campaigns []models.Campaign
+	var total int
+	var err error
+	if s.policy == nil || (principal != nil && principal.Role == models.RoleAdmin) {
+		campaigns, total, err = s.store.List(page, limit)
+	} else {
+		campaigns, total, err = s.listMemberCampaigns(principal, page, limit)
+	}
+	if err != nil {
+		return nil, 0, internal("Failed to retrieve campaigns", nil)
+	}
+
+	return campaigns, total, nil
+}
+
+// listMemberCampaigns returns one page of the campaigns the principal belongs to
+func (s *Campaigns) listMemberCampaigns(principal *auth.Principal, page, limit int) ([]models.Campaign, int, error) {
+	if principal == nil {
+		return []models.Campaign{}, 0, nil
+	}
+
+	members, err := s.store.ListByMember(principal.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// API keys limited to some campaigns only see those
+	campaigns := []models.Campaign{}
+	for _, campaign := range members {
+		if principal.AllowsCampaign(campaign.ID) {
+			campaigns = append(campaigns, campaign)
+		}
+	}
+
+	total := len(campaigns)
+	start := (page - 1) * limit
+	if start >= total {
+		return []models.Campaign{}, total, nil
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+	return campaigns[start:end], total, nil
+}
+
+// Update replaces a campaign the principal may manage
+func (s *Campaigns) Update(ctx context.Context, principal *auth.Principal, id string, campaign *models.Campaign) error {
+	if _, err := s.getAuthorized(principal, id, func(a authz.CampaignAccess) bool { return a.Manage }); err != nil {
+		return err
+	}
+
+	if validationErrors := validation.ValidateCampaign(campaign); len(validationErrors) > 0 {
+		return invalid(validationErrors)
+	}
+
+	if err := s.store.Update(id, campaign); err != nil {
+		if err.Error() == "campaign not found" {
+			return notFound("Campaign not found")
+		}
+		return internal("Failed to update campaign", err)
+	}
+
+	return nil
+}
+
+// Delete removes a campaign the principal may manage
+func (s *Campaigns) Delete(ctx context.Context, principal *auth.Principal, id string) error {
+	if _, err := s.getAuthorized(principal, id, func(a authz.CampaignAccess) bool { return a.Manage }); err != nil {
+		return err
+	}
+
+	if err := s.store.Delete(id); err != nil {
+		if err.Error() == "campaign not found" {
+			return notFound("Campaign not found")
+		}
+		return internal("Failed to delete campaign", err)
+	}
+
+	return nil
+}
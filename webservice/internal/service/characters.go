@@ -0,0 +1,437 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"player-character/internal/auth"
+	"player-character/internal/authz"
+	"player-character/internal/events"
+	"player-character/internal/models"
+	"player-character/internal/validation"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+)
+
+// fullAccess is what every caller gets when no policy is configured
+var fullAccess = authz.CharacterAccess{Read: true, Edit: true, Delete: true, SeeSecrets: true, SeeDMNotes: true}
+
+// Characters applies validation, authorization and change events to character
+// operations. Every transport (REST, GraphQL, gRPC) goes through it so the rules live in one place.
+type Characters struct {
+	store     database.CharacterStore
+	campaigns database.CampaignStore
+	policy    *authz.Policy
+	events    events.Publisher
+	logger    *logging.Logger
+}
+
+// NewCharacters creates a character service. campaigns, policy and publisher
+// are optional; without them campaign validation, access checks and events are skipped.
+func NewCharacters(store database.CharacterStore, campaigns database.CampaignStore, policy *authz.Policy, publisher events.Publisher, logger *logging.Logger) *Characters {
+	return &Characters{
+		store:     store,
+		campaigns: campaigns,
+		policy:    policy,
+		events:    publisher,
+		logger:    logger,
+	}
+}
+
+// Validate runs schema, business rule and campaign validation for a character
+func (s *Characters) Validate(character *models.Character) []models.ValidationError {
+	validationErrors := validation.ValidateCharacter(character)
+
+	if character.PartyID != "" && character.CampaignID == "" {
+		validationErrors = append(validationErrors, models.ValidationError{
+			Field:   "partyId",
+			Message: "partyId requires campaignId to be set",
+			Code:    "INVALID_PARTY",
+		})
+	}
+
+	if character.CampaignID == "" || s.campaigns == nil {
+		return validationErrors
+	}
+
+	campaign, err := s.campaigns.Get(character.CampaignID)
+	if err != nil {
+		return append(validationErrors, models.ValidationError{
+			Field:   "campaignId",
+			Message: "Campaign '" + character.CampaignID + "' does not exist",
+			Code:    "INVALID_CAMPAIGN",
+		})
+	}
+
+	return append(validationErrors, validation.ValidateCharacterForCampaign(character, campaign)...)
+}
+
+// publish sends a change event for the character, if an event publisher is configured.
+// Publishing failures are logged but do not fail the operation that made the change.
+func (s *Characters) publish(ctx context.Context, eventType string, character *models.Character) {
+	if s.events == nil {
+		return
+	}
+
+	if err := s.events.Publish(ctx, events.NewCharacterEvent(eventType, character)); err != nil {
+		s.logger.ErrorWithContext(ctx, "Failed to publish character event", err,
+			"event_type", eventType,
+			"character_id", character.ID)
+	}
+}
+
+// access returns the principal's access to a character, or an error when check rejects it
+func (s *Characters) access(ctx context.Context, principal *auth.Principal, character *models.Character, check func(authz.CharacterAccess) bool) (authz.CharacterAccess, error) {
+	if s.policy == nil {
+		return fullAccess, nil
+	}
+
+	access, err := s.policy.CharacterAccess(principal, character)
+	if err != nil {
+		s.logger.ErrorWithContext(ctx, "Failed to check character permissions", err, "character_id", character.ID)
+		return access, internal("Failed to check permissions", nil)
+	}
+	if !check(access) {
+		return access, forbidden("You do not have permission to access this character")
+	}
+	return access, nil
+}
+
+// checkCampaign returns an error when the principal may not put a character in the campaign
+func (s *Characters) checkCampaign(ctx context.Context, principal *auth.Principal, campaignID string) error {
+	if s.policy == nil {
+		return nil
+	}
+
+	allowed, err := s.policy.CanJoinCampaign(principal, campaignID)
+	if err != nil {
+		s.logger.ErrorWithContext(ctx, "Failed to check campaign permissions", err, "campaign_id", campaignID)
+		return internal("Failed to check permissions", nil)
+	}
+	if !allowed {
+		return forbidden("You are not allowed to add characters to this campaign")
+	}
+	return nil
+}
+
+// Create validates and stores a new character owned by the principal
+func (s *Characters) Create(ctx context.Context, principal *auth.Principal, character *models.Character) error {
+	if err := s.prepareCreate(ctx, principal, character); err != nil {
+		return err
+	}
+
+	// Create character
+	if err := s.store.Create(character); err != nil {
+		s.logger.ErrorWithContext(ctx, "Failed to create character", err,
+			"character_name", character.CharacterName,
+			"character_id", character.ID)
+		return internal("Failed to create character", err)
+	}
+
+	s.created(ctx, character)
+	return nil
+}
+
+// prepareCreate assigns the owner, then checks and validates a new character without storing it
+func (s *Characters) prepareCreate(ctx context.Context, principal *auth.Principal, character *models.Character) error {
+	// The authenticated caller always owns the characters they create
+	character.OwnerID = ""
+	if principal != nil {
+		character.OwnerID = principal.UserID
+	}
+
+	if err := s.checkCampaign(ctx, principal, character.CampaignID); err != nil {
+		return err
+	}
+
+	// Only the campaign DM may write DM notes
+	if s.policy != nil {
+		access, err := s.policy.CharacterAccess(principal, character)
+		if err != nil {
+			return internal("Failed to check permissions", err)
+		}
+		if !access.SeeDMNotes {
+			character.DMNotes = ""
+		}
+	}
+
+	// Validate character
+	if validationErrors := s.Validate(character); len(validationErrors) > 0 {
+		s.logger.Warn("Character validation failed",
+			"character_name", character.CharacterName,
+			"validation_errors", validationErrors)
+		return invalid(validationErrors)
+	}
+	return nil
+}
+
+// created logs and announces a stored character
+func (s *Characters) created(ctx context.Context, character *models.Character) {
+	s.logger.Info("Character created successfully",
+		"character_id", character.ID,
+		"character_name", character.CharacterName,
+		"character_class", character.Class)
+
+	s.publish(ctx, events.CharacterCreated, character)
+}
+
+// Get retrieves a character the principal may read, with hidden fields stripped
+func (s *Characters) Get(ctx context.Context, principal *auth.Principal, id string) (*models.Character, error) {
+	character, err := s.store.Get(id)
+	if err != nil {
+		return nil, notFound("Character not found")
+	}
+
+	access, err := s.access(ctx, principal, character, func(a authz.CharacterAccess) bool { return a.Read })
+	if err != nil {
+		return nil, err
+	}
+	authz.Redact(character, access)
+
+	return character, nil
+}
+
+// List runs a list query. With a policy, only characters visible to the
+// principal are listed and hidden fields are stripped.
+func (s *Characters) List(ctx context.Context, principal *auth.Principal, opts database.ListOptions) ([]models.Character, int, error) {
+	if len(opts.Fields) > 0 {
+		opts.Fields = withRequiredFields(opts.Fields, opts.SortBy)
+	}
+
+	if s.policy != nil {
+		visibility, err := s.policy.Visibility(principal)
+		if err != nil {
+			s.logger.ErrorWithContext(ctx, "Failed to check character visibility", err)
+			return nil, 0, internal("Failed to check permissions", nil)
+		}
+		opts.Visibility = visibility
+	}
+
+	characters, total, err := s.store.List(opts)
+	if err != nil {
+		return nil, 0, internal("Failed to retrieve characters", nil)
+	}
+
+	if s.policy != nil {
+		s.policy.RedactAll(principal, characters)
+	}
+
+	return characters, total, nil
+}
+
+// maxSearchLength bounds the length of a full-text query
+const maxSearchLength = 500
+
+// Search runs a full-text query over the characters visible to the principal,
+// most relevant first, with hidden fields stripped
+func (s *Characters) Search(ctx context.Context, principal *auth.Principal, query string, opts database.ListOptions) ([]models.SearchHit, int, error) {
+	if strings.TrimSpace(query) == "" || len(query) > maxSearchLength {
+		return nil, 0, invalid([]models.ValidationError{{
+			Field:   "q",
+			Message: fmt.Sprintf("Search query is required and must be at most %d characters", maxSearchLength),
+		}})
+	}
+
+	if s.policy != nil {
+		visibility, err := s.policy.Visibility(principal)
+		if err != nil {
+			s.logger.ErrorWithContext(ctx, "Failed to check character visibility", err)
+			return nil, 0, internal("Failed to check permissions", nil)
+		}
+		opts.Visibility = visibility
+	}
+
+	hits, total, err := s.store.Search(query, opts)
+	if err != nil {
+		s.logger.ErrorWithContext(ctx, "Failed to search characters", err)
+		return nil, 0, internal("Failed to search characters", nil)
+	}
+
+	if s.policy != nil {
+		characters := make([]models.Character, len(hits))
+		for i := range hits {
+			characters[i] = hits[i].Character
+		}
+		s.policy.RedactAll(principal, characters)
+		for i := range hits {
+			hits[i].Character = characters[i]
+		}
+	}
+
+	return hits, total, nil
+}
+
+// Page is one page of a character listing
+type Page struct {
+	Characters []models.Character
+	Total      int // -1 when the listing was not counted
+	HasNext    bool
+	NextCursor string // continues after this page; set when HasNext
+}
+
+// Page lists one page of characters and works out whether another follows.
+// Without a total to go by (cursor pages and uncounted listings) it reads
+// one extra character to find out.
+func (s *Characters) Page(ctx context.Context, principal *auth.Principal, opts database.ListOptions) (*Page, error) {
+	limit := opts.Limit
+	probe := opts.After != nil || opts.SkipCount
+	opts.Lookahead = probe
+
+	characters, total, err := s.List(ctx, principal, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Characters: characters, Total: total}
+	if probe {
+		page.HasNext = len(characters) > limit
+		if page.HasNext {
+			page.Characters = characters[:limit]
+		}
+	} else {
+		page.HasNext = opts.Page*limit < total
+	}
+
+	if page.HasNext && len(page.Characters) > 0 {
+		page.NextCursor = database.NewCursor(opts, &page.Characters[len(page.Characters)-1]).Encode()
+	}
+	return page, nil
+}
+
+// withRequiredFields adds the fields redaction and cursors rely on to a sparse
+// fieldset. Callers trim the extra fields from their responses.
+func withRequiredFields(fields []string, sortBy string) []string {
+	if sortBy == "" {
+		sortBy = "createdAt"
+	}
+	result := append([]string(nil), fields...)
+	for _, required := range []string{"id", "ownerId", "campaignId", "partyId", sortBy} {
+		if !slices.Contains(result, required) {
+			result = append(result, required)
+		}
+	}
+	return result
+}
+
+// Update replaces a character the principal may edit. On success character
+// holds the stored values with hidden fields stripped.
+func (s *Characters) Update(ctx context.Context, principal *auth.Principal, id string, character *models.Character) error {
+	existing, access, err := s.prepareUpdate(ctx, principal, id, character)
+	if err != nil {
+		return err
+	}
+
+	// Update character
+	if err := s.store.Update(id, character); err != nil {
+		return writeError("update", err)
+	}
+
+	s.updated(ctx, existing, character, access)
+	return nil
+}
+
+// prepareUpdate checks and validates a replacement character without storing
+// it. It returns the stored character and the principal's access to it.
+func (s *Characters) prepareUpdate(ctx context.Context, principal *auth.Principal, id string, character *models.Character) (*models.Character, authz.CharacterAccess, error) {
+	existing, err := s.store.Get(id)
+	if err != nil {
+		return nil, authz.CharacterAccess{}, notFound("Character not found")
+	}
+
+	access, err := s.access(ctx, principal, existing, func(a authz.CharacterAccess) bool { return a.Edit })
+	if err != nil {
+		return nil, access, err
+	}
+
+	// Ownership cannot be changed through an update
+	character.OwnerID = existing.OwnerID
+
+	// Hidden fields the editor cannot see are kept as they were
+	if !access.SeeSecrets {
+		character.SecretBackstory = existing.SecretBackstory
+	}
+	if !access.SeeDMNotes {
+		character.DMNotes = existing.DMNotes
+	}
+
+	if character.CampaignID != existing.CampaignID {
+		if err := s.checkCampaign(ctx, principal, character.CampaignID); err != nil {
+			return nil, access, err
+		}
+	}
+
+	// Validate character
+	if validationErrors := s.Validate(character); len(validationErrors) > 0 {
+		return nil, access, invalid(validationErrors)
+	}
+	return existing, access, nil
+}
+
+// updated announces a stored update and strips the fields the editor may not see
+func (s *Characters) updated(ctx context.Context, existing, character *models.Character, access authz.CharacterAccess) {
+	s.publish(ctx, events.CharacterUpdated, character)
+	if character.TotalLevel() > existing.TotalLevel() {
+		s.publish(ctx, events.CharacterLeveledUp, character)
+	}
+
+	authz.Redact(character, access)
+}
+
+// Delete removes a character the principal may delete
+func (s *Characters) Delete(ctx context.Context, principal *auth.Principal, id string) error {
+	existing, err := s.prepareDelete(ctx, principal, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.Delete(id); err != nil {
+		return writeError("delete", err)
+	}
+
+	s.publish(ctx, events.CharacterDeleted, existing)
+	return nil
+}
+
+// prepareDelete checks the principal may delete a character and returns it
+func (s *Characters) prepareDelete(ctx context.Context, principal *auth.Principal, id string) (*models.Character, error) {
+	existing, err := s.store.Get(id)
+	if err != nil {
+		return nil, notFound("Character not found")
+	}
+
+	if _, err := s.access(ctx, principal, existing, func(a authz.CharacterAccess) bool { return a.Delete }); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// writeError maps a failed store update or delete to a service error
+func writeError(action string, err error) error {
+	if err.Error() == "character not found" {
+		return notFound("Character not found")
+	}
+	return internal("Failed to "+action+" character", err)
+}
+
+// VisibleEvent checks a change event against the principal's current access
+// to its character. It returns the event with hidden fields stripped from a
+// copy of the character, or false when the principal may not see it.
+// Deletion events carry no character and are always visible.
+func (s *Characters) VisibleEvent(principal *auth.Principal, event events.Event) (events.Event, bool) {
+	if event.Character == nil || s.policy == nil {
+		return event, true
+	}
+
+	access, err := s.policy.CharacterAccess(principal, event.Character)
+	if err != nil || !access.Read {
+		return event, false
+	}
+
+	// Events are shared between subscribers, so redact a copy
+	character := *event.Character
+	authz.Redact(&character, access)
+	event.Character = &character
+	return event, true
+}
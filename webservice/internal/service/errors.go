@@ -0,0 +1,56 @@
+package service
+
+import (
+	"player-character/internal/models"
+)
+
+// Kind classifies a service error so each transport can map it to its own status codes
+type Kind int
+
+const (
+	// KindInvalid means the input failed validation
+	KindInvalid Kind = iota + 1
+	// KindForbidden means the caller may not perform the operation
+	KindForbidden
+	// KindNotFound means the target does not exist
+	KindNotFound
+	// KindInternal means a store or other dependency failed
+	KindInternal
+)
+
+// Error is returned by every service operation that fails
+type Error struct {
+	Kind       Kind
+	Message    string
+	Validation []models.ValidationError
+	Err        error
+}
+
+// Error returns the message, followed by the cause when there is one
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the underlying cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func invalid(validationErrors []models.ValidationError) *Error {
+	return &Error{Kind: KindInvalid, Message: "Validation failed", Validation: validationErrors}
+}
+
+func forbidden(message string) *Error {
+	return &Error{Kind: KindForbidden, Message: message}
+}
+
+func notFound(message string) *Error {
+	return &Error{Kind: KindNotFound, Message: message}
+}
+
+func internal(message string, err error) *Error {
+	return &Error{Kind: KindInternal, Message: message, Err: err}
+}
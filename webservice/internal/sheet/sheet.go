@@ -0,0 +1,389 @@
+// Package sheet renders printable 5e character sheets as PDF. The first page
+// holds the numbers (abilities, saving throws, skills, combat and attacks) and
+// the pages after it the features, personality, backstory, inventory and
+// spells. Values the service derives, such as modifiers, saving throws, hit
+// points and spell save DCs, are filled in; what characters do not record,
+// such as equipment, skill proficiencies and prepared spells, is left as
+// ruled space to write in at the table.
+package sheet
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"player-character/internal/models"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// layout is a sheet template
+type layout struct {
+	name     string
+	pageSize string
+	fontSize float64 // body text, in points
+	line     float64 // line height, in millimetres
+	shaded   bool    // section headings on a grey band
+	// blankRows is the number of write-in rows for attacks, inventory and spells
+	blankRows int
+}
+
+// layouts lists the templates, the default first
+var layouts = []layout{
+	{name: "classic", pageSize: "Letter", fontSize: 10, line: 6, shaded: true, blankRows: 10},
+	{name: "compact", pageSize: "A4", fontSize: 8, line: 4.5, blankRows: 6},
+}
+
+// Layouts returns the template names, the default first
+func Layouts() []string {
+	names := make([]string, len(layouts))
+	for i, l := range layouts {
+		names[i] = l.name
+	}
+	return names
+}
+
+// abilities lists the abilities in sheet order with their abbreviations
+var abilities = []struct{ field, short string }{
+	{"strength", "STR"},
+	{"dexterity", "DEX"},
+	{"constitution", "CON"},
+	{"intelligence", "INT"},
+	{"wisdom", "WIS"},
+	{"charisma", "CHA"},
+}
+
+// Render writes a character's sheet as PDF using the named layout, or the
+// default layout when the name is empty
+func Render(w io.Writer, c *models.Character, layoutName string) error {
+	chosen := layouts[0]
+	if layoutName != "" {
+		found := false
+		for _, l := range layouts {
+			if l.name == layoutName {
+				chosen, found = l, true
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown layout %q (expected one of %s)", layoutName, strings.Join(Layouts(), ", "))
+		}
+	}
+
+	pdf := fpdf.New("P", "mm", chosen.pageSize, "")
+	r := &renderer{pdf: pdf, layout: chosen, c: c, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+	r.setup()
+	r.firstPage()
+	r.detailPages()
+	return pdf.Output(w)
+}
+
+// renderer draws one sheet
+type renderer struct {
+	pdf    *fpdf.Fpdf
+	layout layout
+	c      *models.Character
+	tr     func(string) string // UTF-8 to the core fonts' encoding
+	left   float64
+	width  float64 // between the margins
+}
+
+func (r *renderer) setup() {
+	const margin = 12
+	r.pdf.SetMargins(margin, margin, margin)
+	r.pdf.SetAutoPageBreak(true, margin+4)
+	pageWidth, _ := r.pdf.GetPageSize()
+	r.left, r.width = margin, pageWidth-2*margin
+
+	r.pdf.SetTitle(r.c.CharacterName, true)
+	r.pdf.SetCreator("player-character", true)
+	// Date the file by the character rather than the clock, so an unchanged character renders the same file
+	if !r.c.UpdatedAt.IsZero() {
+		r.pdf.SetCreationDate(r.c.UpdatedAt)
+		r.pdf.SetModificationDate(r.c.UpdatedAt)
+	}
+	r.pdf.SetCatalogSort(true)
+	r.pdf.AliasNbPages("")
+	r.pdf.SetFooterFunc(func() {
+		r.pdf.SetY(-12)
+		r.font("I", -2)
+		r.pdf.CellFormat(0, 4, r.tr(fmt.Sprintf("%s - page %d of {nb}", r.c.CharacterName, r.pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+}
+
+// font sets the body font, larger or smaller by points
+func (r *renderer) font(style string, points float64) {
+	r.pdf.SetFont("Helvetica", style, r.layout.fontSize+points)
+}
+
+// firstPage draws the header, ability scores and the two columns of numbers
+func (r *renderer) firstPage() {
+	r.pdf.AddPage()
+	r.header()
+	r.abilityScores()
+
+	top := r.pdf.GetY()
+	leftWidth := r.width * 0.42
+	gap := 4.0
+
+	r.pdf.SetXY(r.left, top)
+	r.savingThrows(r.left, leftWidth)
+	r.skills(r.left, leftWidth)
+	leftBottom := r.pdf.GetY()
+
+	r.pdf.SetXY(r.left+leftWidth+gap, top)
+	right, rightWidth := r.left+leftWidth+gap, r.width-leftWidth-gap
+	r.combat(right, rightWidth)
+	r.spellcasting(right, rightWidth)
+	r.blankTable(right, rightWidth, "Attacks", r.layout.blankRows, []string{"Name", "Attack bonus", "Damage / type"}, []float64{0.45, 0.2, 0.35})
+
+	r.pdf.SetXY(r.left, max(leftBottom, r.pdf.GetY()))
+}
+
+// header names the character and the details of the top of a sheet
+func (r *renderer) header() {
+	c := r.c
+	r.font("B", 8)
+	r.pdf.CellFormat(r.width, r.layout.line*1.6, r.tr(c.CharacterName), "B", 1, "L", false, 0, "")
+	r.pdf.Ln(1)
+
+	fields := [][2]string{
+		{"Class & level", c.ClassSummary()},
+		{"Race", c.RaceName()},
+		{"Background", c.Background},
+		{"Alignment", c.Alignment},
+		{"Player", c.PlayerName},
+		{"Experience points", fmt.Sprint(c.ExperiencePoints)},
+	}
+	// Two rows of three labeled fields
+	third := r.width / 3
+	for row := 0; row < 2; row++ {
+		y := r.pdf.GetY()
+		for col := 0; col < 3; col++ {
+			field := fields[row*3+col]
+			r.pdf.SetXY(r.left+float64(col)*third, y)
+			r.font("", 0)
+			r.shrinkToFit(field[1], third-2)
+			r.pdf.CellFormat(third-2, r.layout.line, r.tr(field[1]), "B", 2, "L", false, 0, "")
+			r.font("", -3)
+			r.pdf.CellFormat(third-2, r.layout.line*0.6, r.tr(strings.ToUpper(field[0])), "", 0, "L", false, 0, "")
+		}
+		r.pdf.SetXY(r.left, y+r.layout.line*1.8)
+	}
+	r.pdf.Ln(2)
+}
+
+// abilityScores draws a box for each ability with its modifier and score
+func (r *renderer) abilityScores() {
+	gap := 3.0
+	boxWidth := (r.width - 5*gap) / 6
+	boxHeight := r.layout.line * 3.6
+	y := r.pdf.GetY()
+	for i, ability := range abilities {
+		score, _ := r.c.AbilityScores.Get(ability.field)
+		x := r.left + float64(i)*(boxWidth+gap)
+		r.pdf.Rect(x, y, boxWidth, boxHeight, "D")
+
+		r.pdf.SetXY(x, y+1)
+		r.font("B", -1)
+		r.pdf.CellFormat(boxWidth, r.layout.line*0.8, ability.short, "", 2, "C", false, 0, "")
+		r.font("B", 8)
+		r.pdf.CellFormat(boxWidth, r.layout.line*1.5, signed(score.Modifier()), "", 2, "C", false, 0, "")
+		r.font("", 0)
+		r.pdf.CellFormat(boxWidth, r.layout.line, fmt.Sprint(score.Base), "", 0, "C", false, 0, "")
+	}
+	r.pdf.SetXY(r.left, y+boxHeight+4)
+}
+
+// savingThrows lists each saving throw, marking the proficient ones
+func (r *renderer) savingThrows(x, width float64) {
+	r.section(x, width, "Saving throws")
+	for _, ability := range abilities {
+		r.checkRow(x, width, r.c.SavingThrowProficient(ability.field), signed(r.c.SavingThrow(ability.field)), capitalize(ability.field))
+	}
+	r.pdf.SetY(r.pdf.GetY() + 3)
+}
+
+// skills lists each skill with its ability modifier, leaving the proficiency boxes to be marked by hand
+func (r *renderer) skills(x, width float64) {
+	r.section(x, width, "Skills")
+	short := map[string]string{}
+	for _, ability := range abilities {
+		short[ability.field] = ability.short
+	}
+	for _, skill := range models.Skills {
+		score, _ := r.c.AbilityScores.Get(skill.Ability)
+		r.checkRow(x, width, false, signed(score.Modifier()), fmt.Sprintf("%s (%s)", skill.Name, strings.ToLower(short[skill.Ability])))
+	}
+	r.pdf.SetY(r.pdf.GetY() + 1)
+
+	r.pdf.SetX(x)
+	r.font("", 0)
+	r.pdf.CellFormat(width, r.layout.line, r.tr(fmt.Sprintf("Passive Wisdom (Perception): %d", 10+r.c.AbilityScores.Wisdom.Modifier())), "1", 1, "L", false, 0, "")
+	r.pdf.SetY(r.pdf.GetY() + 3)
+}
+
+// combat draws the boxes of combat numbers
+func (r *renderer) combat(x, width float64) {
+	c := r.c
+	r.section(x, width, "Combat")
+	hitDice := []string{fmt.Sprintf("%dd%d", c.Level, models.HitDie(c.Class))}
+	for _, mc := range c.Multiclass {
+		hitDice = append(hitDice, fmt.Sprintf("%dd%d", mc.Level, models.HitDie(mc.Class)))
+	}
+	boxes := [][2]string{
+		{"Armor class (unarmored)", fmt.Sprint(10 + c.AbilityScores.Dexterity.Modifier())},
+		{"Initiative", signed(c.AbilityScores.Dexterity.Modifier())},
+		{"Speed", fmt.Sprintf("%d ft", c.Speed())},
+		{"Proficiency bonus", signed(c.ProficiencyBonus())},
+		{"Hit point maximum", fmt.Sprint(c.MaxHitPoints())},
+		{"Hit dice", strings.Join(hitDice, " + ")},
+	}
+	r.boxGrid(x, width, boxes)
+}
+
+// spellcasting draws the spellcasting numbers of a class that casts spells
+func (r *renderer) spellcasting(x, width float64) {
+	ability := r.c.SpellcastingAbility()
+	if ability == "" {
+		return
+	}
+	score, _ := r.c.AbilityScores.Get(ability)
+	bonus := r.c.ProficiencyBonus() + score.Modifier()
+	r.section(x, width, "Spellcasting")
+	r.boxGrid(x, width, [][2]string{
+		{"Spellcasting ability", capitalize(ability)},
+		{"Spell save DC", fmt.Sprint(8 + bonus)},
+		{"Spell attack bonus", signed(bonus)},
+	})
+}
+
+// boxGrid draws labeled values three to a row
+func (r *renderer) boxGrid(x, width float64, boxes [][2]string) {
+	third := width / 3
+	height := r.layout.line * 2.2
+	for i, box := range boxes {
+		if i > 0 && i%3 == 0 {
+			r.pdf.SetY(r.pdf.GetY() + height + 1)
+		}
+		bx, by := x+float64(i%3)*third, r.pdf.GetY()
+		r.pdf.Rect(bx, by, third-1.5, height, "D")
+		r.pdf.SetXY(bx, by+0.5)
+		r.font("B", 2)
+		r.shrinkToFit(box[1], third-2.5)
+		r.pdf.CellFormat(third-1.5, r.layout.line*1.2, r.tr(box[1]), "", 2, "C", false, 0, "")
+		r.font("", -3)
+		r.shrinkToFit(strings.ToUpper(box[0]), third-2.5)
+		r.pdf.CellFormat(third-1.5, r.layout.line*0.8, r.tr(strings.ToUpper(box[0])), "", 0, "C", false, 0, "")
+		r.pdf.SetY(by)
+	}
+	r.pdf.SetY(r.pdf.GetY() + height + 4)
+}
+
+// detailPages draws the features, personality, backstory, notes, inventory and spells
+func (r *renderer) detailPages() {
+	c := r.c
+	r.pdf.AddPage()
+
+	features := "None recorded."
+	if len(c.Features) > 0 {
+		features = "- " + strings.Join(c.Features, "\n- ")
+	}
+	r.textSection("Features & traits", features)
+	r.textSection("Personality", c.Personality)
+	r.textSection("Backstory", c.Backstory)
+	r.textSection("Notes", c.Notes)
+
+	r.blankTable(r.left, r.width, "Inventory", r.layout.blankRows, []string{"Item", "Qty", "Weight", "Notes"}, []float64{0.45, 0.1, 0.12, 0.33})
+	r.blankTable(r.left, r.width, "Currency", 1, []string{"CP", "SP", "EP", "GP", "PP"}, []float64{0.2, 0.2, 0.2, 0.2, 0.2})
+	if c.SpellcastingAbility() != "" {
+		r.blankTable(r.left, r.width, "Spells", 2*r.layout.blankRows, []string{"Level", "Spell", "Prepared", "Notes"}, []float64{0.1, 0.4, 0.12, 0.38})
+	}
+}
+
+// textSection draws a heading and wrapped text, running onto further pages as needed
+func (r *renderer) textSection(title, text string) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	r.section(r.left, r.width, title)
+	r.font("", 0)
+	r.pdf.MultiCell(r.width, r.layout.line*0.85, r.tr(text), "", "L", false)
+	r.pdf.Ln(4)
+}
+
+// blankTable draws a heading and column titles over empty rows to write in
+func (r *renderer) blankTable(x, width float64, title string, rows int, columns []string, widths []float64) {
+	// Keep a table together rather than splitting it over a page break
+	_, pageHeight := r.pdf.GetPageSize()
+	if r.pdf.GetY()+float64(rows+3)*r.layout.line > pageHeight-16 {
+		r.pdf.AddPage()
+		r.pdf.SetX(x)
+	}
+
+	r.section(x, width, title)
+	r.font("B", -2)
+	r.pdf.SetX(x)
+	for i, column := range columns {
+		r.pdf.CellFormat(width*widths[i], r.layout.line, column, "B", 0, "L", false, 0, "")
+	}
+	r.pdf.Ln(-1)
+	for row := 0; row < rows; row++ {
+		r.pdf.SetX(x)
+		for i := range columns {
+			r.pdf.CellFormat(width*widths[i], r.layout.line, "", "B", 0, "L", false, 0, "")
+		}
+		r.pdf.Ln(-1)
+	}
+	r.pdf.SetY(r.pdf.GetY() + 4)
+}
+
+// section draws a section heading
+func (r *renderer) section(x, width float64, title string) {
+	r.pdf.SetX(x)
+	r.font("B", 1)
+	if r.layout.shaded {
+		r.pdf.SetFillColor(220, 220, 220)
+	}
+	r.pdf.CellFormat(width, r.layout.line*1.1, r.tr(strings.ToUpper(title)), "B", 1, "L", r.layout.shaded, 0, "")
+	r.pdf.Ln(1)
+}
+
+// checkRow draws a row with a proficiency box, a bonus and a label
+func (r *renderer) checkRow(x, width float64, checked bool, bonus, label string) {
+	size := r.layout.line * 0.5
+	y := r.pdf.GetY()
+	style := "D"
+	if checked {
+		style = "FD"
+		r.pdf.SetFillColor(0, 0, 0)
+	}
+	r.pdf.Rect(x+1, y+(r.layout.line-size)/2, size, size, style)
+
+	r.pdf.SetXY(x+size+3, y)
+	r.font("B", 0)
+	r.pdf.CellFormat(10, r.layout.line, bonus, "", 0, "R", false, 0, "")
+	r.font("", 0)
+	r.pdf.CellFormat(width-size-13, r.layout.line, r.tr("  "+label), "", 1, "L", false, 0, "")
+}
+
+// shrinkToFit reduces the font size until the text fits the width
+func (r *renderer) shrinkToFit(text string, width float64) {
+	size, _ := r.pdf.GetFontSize()
+	for size > 5 && r.pdf.GetStringWidth(r.tr(text)) > width {
+		size -= 0.5
+		r.pdf.SetFontSize(size)
+	}
+}
+
+// signed writes a bonus with its sign
+func signed(n int) string {
+	return fmt.Sprintf("%+d", n)
+}
+
+// capitalize upper-cases the first letter of an ability name
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,83 @@
+package sheet
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+)
+
+func testCharacter() *models.Character {
+	return &models.Character{
+		CharacterName: "Lyra Moonwhisper",
+		Race:          "Elf",
+		Subrace:       "High Elf",
+		Class:         "Wizard",
+		Level:         5,
+		Multiclass:    []models.MulticlassEntry{{Class: "Fighter", Level: 1}},
+		AbilityScores: models.AbilityScores{
+			Strength:     models.AbilityScore{Base: 8},
+			Dexterity:    models.AbilityScore{Base: 14},
+			Constitution: models.AbilityScore{Base: 13},
+			Intelligence: models.AbilityScore{Base: 17},
+			Wisdom:       models.AbilityScore{Base: 12},
+			Charisma:     models.AbilityScore{Base: 10},
+		},
+		Features:  []string{"Arcane Recovery"},
+		Backstory: "Raised in the archives of Candlekeep — the café by the gate.",
+		UpdatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+// pages counts the pages of a PDF
+func pages(pdf []byte) int {
+	return len(regexp.MustCompile(`/Type /Page\b[^s]`).FindAll(pdf, -1))
+}
+
+func TestRender(t *testing.T) {
+	for _, layout := range append(Layouts(), "") {
+		var buf bytes.Buffer
+		if err := Render(&buf, testCharacter(), layout); err != nil {
+			t.Fatalf("Render %q: %v", layout, err)
+		}
+		if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+			t.Errorf("Expected a PDF for layout %q", layout)
+		}
+		if n := pages(buf.Bytes()); n < 2 {
+			t.Errorf("Expected at least 2 pages for layout %q, got %d", layout, n)
+		}
+
+		// The same character renders the same file
+		var again bytes.Buffer
+		Render(&again, testCharacter(), layout)
+		if !bytes.Equal(buf.Bytes(), again.Bytes()) {
+			t.Errorf("Expected layout %q to render the same file twice", layout)
+		}
+	}
+}
+
+func TestRenderLongText(t *testing.T) {
+	short, long := testCharacter(), testCharacter()
+	long.Backstory = strings.Repeat("A long and winding tale of the road. ", 600)
+
+	var shortPDF, longPDF bytes.Buffer
+	if err := Render(&shortPDF, short, "classic"); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if err := Render(&longPDF, long, "classic"); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if pages(longPDF.Bytes()) <= pages(shortPDF.Bytes()) {
+		t.Errorf("Expected a long backstory to run onto more pages, got %d and %d", pages(shortPDF.Bytes()), pages(longPDF.Bytes()))
+	}
+}
+
+func TestRenderUnknownLayout(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, testCharacter(), "fancy"); err == nil {
+		t.Error("Expected an unknown layout to be rejected")
+	}
+}
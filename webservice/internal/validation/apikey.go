@@ -0,0 +1,53 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidateAPIKeyRequest validates a request to mint an API key
+func ValidateAPIKeyRequest(request *models.CreateAPIKeyRequest) []models.ValidationError {
+	var errors []models.ValidationError
+
+	validate := validator.New()
+	if err := validate.Struct(request); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			field := strings.ToLower(err.Field())
+
+			var message string
+			switch err.Tag() {
+			case "required":
+				message = fmt.Sprintf("%s is required", field)
+			case "min":
+				message = fmt.Sprintf("%s must have at least %s entries", field, err.Param())
+			case "max":
+				message = fmt.Sprintf("%s must be at most %s characters", field, err.Param())
+			case "oneof":
+				message = fmt.Sprintf("%s must be one of: %s, got %v", field, err.Param(), err.Value())
+			default:
+				message = fmt.Sprintf("%s is invalid (value: %v)", field, err.Value())
+			}
+
+			errors = append(errors, models.ValidationError{
+				Field:   field,
+				Message: message,
+				Code:    "VALIDATION_ERROR",
+			})
+		}
+	}
+
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(time.Now()) {
+		errors = append(errors, models.ValidationError{
+			Field:   "expiresAt",
+			Message: "expiresAt must be in the future",
+			Code:    "VALIDATION_ERROR",
+		})
+	}
+
+	return errors
+}
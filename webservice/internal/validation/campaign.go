@@ -0,0 +1,234 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"player-character/internal/models"
+	"player-character/internal/render"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// xpThresholds holds the minimum experience points for each character level (index 0 is level 1)
+var xpThresholds = []int{
+	0, 300, 900, 2700, 6500, 14000, 23000, 34000, 48000, 64000,
+	85000, 100000, 120000, 140000, 165000, 195000, 225000, 265000, 305000, 355000,
+}
+
+// standardArray is the fixed set of scores used by the standard array method
+var standardArray = []int{15, 14, 13, 12, 10, 8}
+
+// pointBuyCosts maps a base score to its point-buy cost
+var pointBuyCosts = map[int]int{8: 0, 9: 1, 10: 2, 11: 3, 12: 4, 13: 5, 14: 7, 15: 9}
+
+// pointBuyBudget is the number of points available under point buy
+const pointBuyBudget = 27
+
+// ValidateCampaign validates a campaign and its settings
+func ValidateCampaign(campaign *models.Campaign) []models.ValidationError {
+	var errors []models.ValidationError
+
+	validate := validator.New()
+	if err := validate.Struct(campaign); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			field := strings.ToLower(err.Field())
+
+			var message string
+			switch err.Tag() {
+			case "required":
+				message = fmt.Sprintf("%s is required", field)
+			case "oneof":
+				message = fmt.Sprintf("%s must be one of: %s, got %v", field, err.Param(), err.Value())
+			default:
+				message = fmt.Sprintf("%s is invalid (value: %v)", field, err.Value())
+			}
+
+			errors = append(errors, models.ValidationError{
+				Field:   field,
+				Message: message,
+				Code:    "VALIDATION_ERROR",
+			})
+		}
+	}
+
+	for i, race := range campaign.Settings.AllowedRaces {
+		if !contains(validRaces, race) {
+			errors = append(errors, models.ValidationError{
+				Field:   fmt.Sprintf("settings.allowedRaces[%d]", i),
+				Message: fmt.Sprintf("Invalid race '%s'. Must be one of: %s", race, strings.Join(validRaces, ", ")),
+				Code:    "INVALID_RACE",
+			})
+		}
+	}
+
+	for i, class := range campaign.Settings.AllowedClasses {
+		if !contains(validClasses, class) {
+			errors = append(errors, models.ValidationError{
+				Field:   fmt.Sprintf("settings.allowedClasses[%d]", i),
+				Message: fmt.Sprintf("Invalid class '%s'. Must be one of: %s", class, strings.Join(validClasses, ", ")),
+				Code:    "INVALID_CLASS",
+			})
+		}
+	}
+
+	templates := campaign.Settings.Templates
+	for _, t := range []struct{ field, kind, format, text string }{
+		{"characterMarkdown", render.KindCharacter, render.Markdown, templates.CharacterMarkdown},
+		{"characterHtml", render.KindCharacter, render.HTML, templates.CharacterHTML},
+		{"rosterMarkdown", render.KindRoster, render.Markdown, templates.RosterMarkdown},
+		{"rosterHtml", render.KindRoster, render.HTML, templates.RosterHTML},
+	} {
+		if t.text == "" {
+			continue
+		}
+		if err := render.Check(t.kind, t.format, t.text); err != nil {
+			errors = append(errors, models.ValidationError{
+				Field:   "settings.templates." + t.field,
+				Message: fmt.Sprintf("Invalid template: %v", err),
+				Code:    "INVALID_TEMPLATE",
+			})
+		}
+	}
+
+	return errors
+}
+
+// ValidateCharacterForCampaign checks a character against the settings of the campaign it belongs to
+func ValidateCharacterForCampaign(character *models.Character, campaign *models.Campaign) []models.ValidationError {
+	var errors []models.ValidationError
+	settings := campaign.Settings
+
+	if character.PartyID != "" && !campaign.HasParty(character.PartyID) {
+		errors = append(errors, models.ValidationError{
+			Field:   "partyId",
+			Message: fmt.Sprintf("Party '%s' does not exist in campaign '%s'", character.PartyID, campaign.Name),
+			Code:    "INVALID_PARTY",
+		})
+	}
+
+	// Allowed content
+	if len(settings.AllowedRaces) > 0 && !contains(settings.AllowedRaces, character.Race) {
+		errors = append(errors, models.ValidationError{
+			Field:   "race",
+			Message: fmt.Sprintf("Race '%s' is not allowed in this campaign. Allowed: %s", character.Race, strings.Join(settings.AllowedRaces, ", ")),
+			Code:    "RACE_NOT_ALLOWED",
+		})
+	}
+
+	if len(settings.AllowedClasses) > 0 {
+		if !contains(settings.AllowedClasses, character.Class) {
+			errors = append(errors, models.ValidationError{
+				Field:   "class",
+				Message: fmt.Sprintf("Class '%s' is not allowed in this campaign. Allowed: %s", character.Class, strings.Join(settings.AllowedClasses, ", ")),
+				Code:    "CLASS_NOT_ALLOWED",
+			})
+		}
+		for i, mc := range character.Multiclass {
+			if !contains(settings.AllowedClasses, mc.Class) {
+				errors = append(errors, models.ValidationError{
+					Field:   fmt.Sprintf("multiclass[%d].class", i),
+					Message: fmt.Sprintf("Class '%s' is not allowed in this campaign. Allowed: %s", mc.Class, strings.Join(settings.AllowedClasses, ", ")),
+					Code:    "CLASS_NOT_ALLOWED",
+				})
+			}
+		}
+	}
+
+	// Leveling mode
+	totalLevel := character.Level
+	for _, mc := range character.Multiclass {
+		totalLevel += mc.Level
+	}
+
+	switch settings.Leveling {
+	case models.LevelingMilestone:
+		if character.ExperiencePoints != 0 {
+			errors = append(errors, models.ValidationError{
+				Field:   "experiencePoints",
+				Message: "Experience points are not tracked in milestone campaigns",
+				Code:    "XP_NOT_ALLOWED",
+			})
+		}
+	case models.LevelingXP:
+		if expected := levelForXP(character.ExperiencePoints); totalLevel != expected {
+			errors = append(errors, models.ValidationError{
+				Field:   "level",
+				Message: fmt.Sprintf("Level %d does not match %d experience points (expected level %d)", totalLevel, character.ExperiencePoints, expected),
+				Code:    "LEVEL_XP_MISMATCH",
+			})
+		}
+	}
+
+	// Ability score generation method
+	scores := []int{
+		character.AbilityScores.Strength.Base,
+		character.AbilityScores.Dexterity.Base,
+		character.AbilityScores.Constitution.Base,
+		character.AbilityScores.Intelligence.Base,
+		character.AbilityScores.Wisdom.Base,
+		character.AbilityScores.Charisma.Base,
+	}
+
+	switch settings.AbilityScoreMethod {
+	case models.AbilityMethodStandardArray:
+		sorted := append([]int(nil), scores...)
+		sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+		for i := range sorted {
+			if sorted[i] != standardArray[i] {
+				errors = append(errors, models.ValidationError{
+					Field:   "abilityScores",
+					Message: fmt.Sprintf("Ability scores must use the standard array %v, got %v", standardArray, scores),
+					Code:    "INVALID_ABILITY_METHOD",
+				})
+				break
+			}
+		}
+	case models.AbilityMethodPointBuy:
+		spent := 0
+		for _, score := range scores {
+			cost, ok := pointBuyCosts[score]
+			if !ok {
+				errors = append(errors, models.ValidationError{
+					Field:   "abilityScores",
+					Message: fmt.Sprintf("Point buy scores must be between 8 and 15, got %d", score),
+					Code:    "INVALID_ABILITY_METHOD",
+				})
+				return errors
+			}
+			spent += cost
+		}
+		if spent > pointBuyBudget {
+			errors = append(errors, models.ValidationError{
+				Field:   "abilityScores",
+				Message: fmt.Sprintf("Point buy total %d exceeds the budget of %d", spent, pointBuyBudget),
+				Code:    "INVALID_ABILITY_METHOD",
+			})
+		}
+	case models.AbilityMethodRoll:
+		for _, score := range scores {
+			if score < 3 || score > 18 {
+				errors = append(errors, models.ValidationError{
+					Field:   "abilityScores",
+					Message: fmt.Sprintf("Rolled scores must be between 3 and 18, got %d", score),
+					Code:    "INVALID_ABILITY_METHOD",
+				})
+				break
+			}
+		}
+	}
+
+	return errors
+}
+
+// levelForXP returns the character level reached with the given experience points
+func levelForXP(xp int) int {
+	level := 1
+	for i, threshold := range xpThresholds {
+		if xp >= threshold {
+			level = i + 1
+		}
+	}
+	return level
+}
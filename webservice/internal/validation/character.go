@@ -3,6 +3,7 @@ package validation
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"player-character/internal/models"
@@ -10,6 +11,40 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
+// validRaces lists the playable races supported by the service
+var validRaces = []string{
+	"Human", "Elf", "Dwarf", "Halfling", "Dragonborn", "Gnome",
+	"Half-Elf", "Half-Orc", "Tiefling",
+}
+
+// validClasses lists the playable classes supported by the service
+var validClasses = []string{
+	"Fighter", "Wizard", "Rogue", "Cleric", "Barbarian", "Bard",
+	"Druid", "Monk", "Paladin", "Ranger", "Sorcerer", "Warlock",
+}
+
+// validAlignments lists the nine D&D alignments
+var validAlignments = []string{
+	"Lawful Good", "Neutral Good", "Chaotic Good",
+	"Lawful Neutral", "True Neutral", "Chaotic Neutral",
+	"Lawful Evil", "Neutral Evil", "Chaotic Evil",
+}
+
+// Races returns the playable races supported by the service
+func Races() []string {
+	return slices.Clone(validRaces)
+}
+
+// Classes returns the playable classes supported by the service
+func Classes() []string {
+	return slices.Clone(validClasses)
+}
+
+// Alignments returns the alignments a character may have
+func Alignments() []string {
+	return slices.Clone(validAlignments)
+}
+
 // validateAlignment validates that the alignment is one of the allowed D&D alignments
 func validateAlignment(fl validator.FieldLevel) bool {
 	alignment := fl.Field().String()
@@ -17,12 +52,6 @@ func validateAlignment(fl validator.FieldLevel) bool {
 		return true // Allow empty alignment
 	}
 
-	validAlignments := []string{
-		"Lawful Good", "Neutral Good", "Chaotic Good",
-		"Lawful Neutral", "True Neutral", "Chaotic Neutral",
-		"Lawful Evil", "Neutral Evil", "Chaotic Evil",
-	}
-
 	for _, valid := range validAlignments {
 		if alignment == valid {
 			return true
@@ -82,10 +111,6 @@ func validateBusinessRules(character *models.Character) []models.ValidationError
 	var errors []models.ValidationError
 
 	// Validate race
-	validRaces := []string{
-		"Human", "Elf", "Dwarf", "Halfling", "Dragonborn", "Gnome",
-		"Half-Elf", "Half-Orc", "Tiefling",
-	}
 	if !contains(validRaces, character.Race) {
 		errors = append(errors, models.ValidationError{
 			Field:   "race",
@@ -95,10 +120,6 @@ func validateBusinessRules(character *models.Character) []models.ValidationError
 	}
 
 	// Validate class
-	validClasses := []string{
-		"Fighter", "Wizard", "Rogue", "Cleric", "Barbarian", "Bard",
-		"Druid", "Monk", "Paladin", "Ranger", "Sorcerer", "Warlock",
-	}
 	if !contains(validClasses, character.Class) {
 		errors = append(errors, models.ValidationError{
 			Field:   "class",
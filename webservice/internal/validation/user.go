@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"player-character/internal/models"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidateRegistration validates a new account registration request
+func ValidateRegistration(request *models.RegisterRequest) []models.ValidationError {
+	var errors []models.ValidationError
+
+	validate := validator.New()
+	if err := validate.Struct(request); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			field := strings.ToLower(err.Field())
+
+			var message string
+			switch err.Tag() {
+			case "required":
+				message = fmt.Sprintf("%s is required", field)
+			case "min":
+				message = fmt.Sprintf("%s must be at least %s characters", field, err.Param())
+			case "max":
+				message = fmt.Sprintf("%s must be at most %s characters", field, err.Param())
+			case "alphanum":
+				message = fmt.Sprintf("%s may only contain letters and digits", field)
+			case "email":
+				message = fmt.Sprintf("%s must be a valid email address", field)
+			default:
+				message = fmt.Sprintf("%s is invalid", field)
+			}
+
+			errors = append(errors, models.ValidationError{
+				Field:   field,
+				Message: message,
+				Code:    "VALIDATION_ERROR",
+			})
+		}
+	}
+
+	return errors
+}
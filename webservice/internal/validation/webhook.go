@@ -0,0 +1,151 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// resolveTimeout bounds the lookup of a webhook URL's host
+const resolveTimeout = 5 * time.Second
+
+// ValidateWebhookRequest validates a request to register a webhook endpoint.
+// Unless allowPrivate is set, the URL's host must only resolve to public
+// addresses, so that webhooks cannot be aimed at internal services.
+func ValidateWebhookRequest(request *models.CreateWebhookRequest, allowPrivate bool) []models.ValidationError {
+	var errors []models.ValidationError
+
+	validate := validator.New()
+	if err := validate.Struct(request); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			field := strings.ToLower(err.Field())
+
+			var message string
+			switch err.Tag() {
+			case "required":
+				message = fmt.Sprintf("%s is required", field)
+			case "url":
+				message = fmt.Sprintf("%s must be an absolute URL", field)
+			case "min":
+				message = fmt.Sprintf("%s must be at least %s characters", field, err.Param())
+			case "oneof":
+				message = fmt.Sprintf("%s must be one of: %s, got %v", field, err.Param(), err.Value())
+			default:
+				message = fmt.Sprintf("%s is invalid (value: %v)", field, err.Value())
+			}
+
+			errors = append(errors, models.ValidationError{
+				Field:   field,
+				Message: message,
+				Code:    "VALIDATION_ERROR",
+			})
+		}
+	}
+
+	parsed, err := url.Parse(request.URL)
+	if err != nil || request.URL == "" {
+		return errors
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		errors = append(errors, models.ValidationError{
+			Field:   "url",
+			Message: "url must use http or https",
+			Code:    "VALIDATION_ERROR",
+		})
+		return errors
+	}
+
+	if !allowPrivate && parsed.Hostname() != "" {
+		if message := checkWebhookHost(parsed.Hostname()); message != "" {
+			errors = append(errors, models.ValidationError{
+				Field:   "url",
+				Message: message,
+				Code:    "VALIDATION_ERROR",
+			})
+		}
+	}
+
+	return errors
+}
+
+// checkWebhookHost returns why a webhook may not be sent to host, or "" if it may
+func checkWebhookHost(host string) string {
+	addrs := []netip.Addr{}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = append(addrs, addr)
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+		defer cancel()
+		resolved, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+		if err != nil || len(resolved) == 0 {
+			return fmt.Sprintf("url host %s could not be resolved", host)
+		}
+		addrs = resolved
+	}
+
+	for _, addr := range addrs {
+		if !PublicAddress(addr) {
+			return fmt.Sprintf("url host %s resolves to %s, which is not a public address", host, addr.Unmap())
+		}
+	}
+	return ""
+}
+
+// specialPurposePrefixes are the ranges of the IANA IPv4 and IPv6
+// special-purpose address registries that are not globally reachable, or
+// that reach IPv4 addresses through translation, and so may lead to internal
+// hosts. Loopback, private, link-local and unspecified addresses are listed
+// too, though netip reports them on its own.
+var specialPurposePrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this network"
+	netip.MustParsePrefix("10.0.0.0/8"),      // private
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("127.0.0.0/8"),     // loopback
+	netip.MustParsePrefix("169.254.0.0/16"),  // link-local
+	netip.MustParsePrefix("172.16.0.0/12"),   // private
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast
+	netip.MustParsePrefix("192.168.0.0/16"),  // private
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("224.0.0.0/4"),     // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved, and broadcast
+	netip.MustParsePrefix("::/128"),          // unspecified
+	netip.MustParsePrefix("::1/128"),         // loopback
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local-use NAT64
+	netip.MustParsePrefix("100::/64"),        // discard-only
+	netip.MustParsePrefix("2001::/23"),       // IETF protocol assignments, including Teredo
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("2002::/16"),       // 6to4
+	netip.MustParsePrefix("fc00::/7"),        // unique local
+	netip.MustParsePrefix("fe80::/10"),       // link-local
+	netip.MustParsePrefix("ff00::/8"),        // multicast
+}
+
+// PublicAddress reports whether addr may receive webhooks: it must not be in
+// any special-purpose range, such as loopback, private, link-local,
+// unspecified, carrier-grade NAT or NAT64 addresses
+func PublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range specialPurposePrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
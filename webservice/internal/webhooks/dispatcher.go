@@ -0,0 +1,338 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"player-character/internal/authz"
+	"player-character/internal/events"
+	"player-character/internal/models"
+	"player-character/internal/validation"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderWebhookID = "X-Webhook-ID"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// claimBatch is how many due deliveries a worker claims per poll
+const claimBatch = 16
+
+// Config controls delivery timing and retries
+type Config struct {
+	// MaxAttempts is how many times a delivery is tried before it is dead-lettered
+	MaxAttempts int
+	// InitialBackoff is the wait after the first failure; it doubles with every further failure
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts
+	MaxBackoff time.Duration
+	// PollInterval is how often the store is checked for due retries
+	PollInterval time.Duration
+	// Timeout bounds a single HTTP attempt
+	Timeout time.Duration
+	// AllowPrivateAddresses lets webhooks reach loopback, private and
+	// link-local addresses, for development against local receivers
+	AllowPrivateAddresses bool
+}
+
+// DefaultConfig returns the production delivery settings: 8 attempts spread over roughly two hours
+func DefaultConfig() Config {
+	return Config{
+		MaxAttempts:    8,
+		InitialBackoff: 30 * time.Second,
+		MaxBackoff:     time.Hour,
+		PollInterval:   5 * time.Second,
+		Timeout:        10 * time.Second,
+	}
+}
+
+// Backoff returns how long to wait before the next attempt after the given number of failed attempts
+func (c Config) Backoff(attempts int) time.Duration {
+	backoff := c.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= c.MaxBackoff {
+			return c.MaxBackoff
+		}
+	}
+	return backoff
+}
+
+// Sign returns the signature header value for a payload sent at timestamp.
+// Receivers recompute HMAC-SHA256 over "<timestamp>.<body>" with the shared secret.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher turns character events into webhook deliveries and sends them in the background.
+// It implements events.Publisher so it can sit beside the event broker on the character handler.
+type Dispatcher struct {
+	store  database.WebhookStore
+	config Config
+	client *http.Client
+	logger *logging.Logger
+	wake   chan struct{}
+}
+
+// NewDispatcher creates a dispatcher; call Run to start delivering
+func NewDispatcher(store database.WebhookStore, config Config, logger *logging.Logger) *Dispatcher {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !config.AllowPrivateAddresses {
+		// Checked on every connection rather than at registration alone, so a
+		// host re-resolving to an internal address, or a redirect to one, is refused
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: refusePrivateAddresses}
+		transport.DialContext = dialer.DialContext
+	}
+
+	return &Dispatcher{
+		store:  store,
+		config: config,
+		client: &http.Client{Timeout: config.Timeout, Transport: transport},
+		logger: logger,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// refusePrivateAddresses is a net.Dialer Control function failing connections
+// to addresses webhooks may not reach
+func refusePrivateAddresses(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !validation.PublicAddress(addrPort.Addr()) {
+		return fmt.Errorf("refusing to deliver to %s, which is not a public address", addrPort.Addr().Unmap())
+	}
+	return nil
+}
+
+// AllowsPrivateAddresses reports whether webhooks may be registered for loopback, private and link-local addresses
+func (d *Dispatcher) AllowsPrivateAddresses() bool {
+	return d.config.AllowPrivateAddresses
+}
+
+// Publish records a pending delivery for every active webhook subscribed to the event.
+// Nothing is sent here; Run picks the deliveries up asynchronously.
+func (d *Dispatcher) Publish(ctx context.Context, event events.Event) error {
+	webhooks, err := d.store.ListForCampaign(event.CampaignID)
+	if err != nil {
+		return err
+	}
+
+	// Payloads leave the service and are kept in the delivery log, so they
+	// carry only what every reader of the character may see. Events are
+	// shared between subscribers, so redact a copy.
+	if event.Character != nil {
+		character := *event.Character
+		authz.Redact(&character, authz.CharacterAccess{Read: true})
+		event.Character = &character
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	queued := false
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event.Type) {
+			continue
+		}
+
+		delivery := models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       string(payload),
+			Status:        models.DeliveryPending,
+			NextAttemptAt: time.Now(),
+		}
+		if err := d.store.CreateDelivery(&delivery); err != nil {
+			return err
+		}
+		queued = true
+	}
+
+	if queued {
+		d.notify()
+	}
+	return nil
+}
+
+// Redeliver puts a delivery, typically a dead-lettered one, back in the queue with a fresh set of attempts
+func (d *Dispatcher) Redeliver(deliveryID string) (*models.WebhookDelivery, error) {
+	delivery, err := d.store.GetDelivery(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Status = models.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	if err := d.store.UpdateDelivery(delivery); err != nil {
+		return nil, err
+	}
+
+	d.notify()
+	return delivery, nil
+}
+
+// notify wakes Run without blocking
+func (d *Dispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers due webhooks until ctx is done. Several replicas may run it
+// against the same store; each delivery is claimed by only one of them.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.deliverDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// deliverDue claims and sends due deliveries until none are left
+func (d *Dispatcher) deliverDue(ctx context.Context) {
+	// A claim lasts longer than an attempt can take, so a crashed worker's deliveries are retried
+	lease := 2 * d.config.Timeout
+
+	for ctx.Err() == nil {
+		deliveries, err := d.store.ClaimDueDeliveries(time.Now(), lease, claimBatch)
+		if err != nil {
+			d.logger.ErrorWithContext(ctx, "Failed to claim webhook deliveries", err)
+			return
+		}
+		if len(deliveries) == 0 {
+			return
+		}
+
+		var wg sync.WaitGroup
+		for i := range deliveries {
+			wg.Add(1)
+			go func(delivery *models.WebhookDelivery) {
+				defer wg.Done()
+				d.attempt(ctx, delivery)
+			}(&deliveries[i])
+		}
+		wg.Wait()
+	}
+}
+
+// attempt sends a delivery once and records the outcome
+func (d *Dispatcher) attempt(ctx context.Context, delivery *models.WebhookDelivery) {
+	webhook, err := d.store.Get(delivery.WebhookID)
+	if err != nil || !webhook.Active {
+		delivery.Status = models.DeliveryDead
+		delivery.LastError = "webhook no longer exists or is inactive"
+		d.save(ctx, delivery)
+		return
+	}
+
+	delivery.Attempts++
+	status, err := d.send(ctx, webhook, delivery)
+	delivery.ResponseStatus = status
+
+	switch {
+	case err == nil:
+		now := time.Now()
+		delivery.Status = models.DeliverySucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= d.config.MaxAttempts:
+		delivery.Status = models.DeliveryDead
+		delivery.LastError = err.Error()
+		d.logger.Warn("Webhook delivery dead-lettered",
+			"webhook_id", webhook.ID,
+			"delivery_id", delivery.ID,
+			"attempts", delivery.Attempts,
+			"error", err.Error())
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = time.Now().Add(d.config.Backoff(delivery.Attempts))
+	}
+
+	d.save(ctx, delivery)
+}
+
+// send POSTs the signed payload; any non-2xx response is an error
+func (d *Dispatcher) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	payload := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "player-character-webhooks/1.0")
+	req.Header.Set(HeaderWebhookID, webhook.ID)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// save persists a delivery's new state, logging failures
+func (d *Dispatcher) save(ctx context.Context, delivery *models.WebhookDelivery) {
+	if err := d.store.UpdateDelivery(delivery); err != nil {
+		d.logger.ErrorWithContext(ctx, "Failed to record webhook delivery", err,
+			"delivery_id", delivery.ID)
+	}
+}
+
+// GenerateSecret returns a random signing secret for a new webhook
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,238 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"player-character/internal/events"
+	"player-character/internal/models"
+	"player-character/pkg/database"
+	"player-character/pkg/logging"
+)
+
+// testConfig retries quickly so tests do not wait on production backoff
+func testConfig() Config {
+	return Config{
+		MaxAttempts:    3,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     40 * time.Millisecond,
+		PollInterval:   5 * time.Millisecond,
+		Timeout:        time.Second,
+		// Test receivers listen on loopback
+		AllowPrivateAddresses: true,
+	}
+}
+
+func newTestDispatcher(t *testing.T) (*Dispatcher, *database.MemoryWebhookStore) {
+	t.Helper()
+	store := database.NewMemoryWebhookStore()
+	logger := logging.NewLogger(logging.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "console",
+	})
+	dispatcher := NewDispatcher(store, testConfig(), logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go dispatcher.Run(ctx)
+
+	return dispatcher, store
+}
+
+// waitForStatus polls the delivery log until the webhook's only delivery reaches status
+func waitForStatus(t *testing.T, store *database.MemoryWebhookStore, webhookID, status string) models.WebhookDelivery {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		deliveries, _ := store.ListDeliveries(webhookID, 10)
+		if len(deliveries) == 1 && deliveries[0].Status == status {
+			return deliveries[0]
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	deliveries, _ := store.ListDeliveries(webhookID, 10)
+	t.Fatalf("Delivery never reached status %q: %+v", status, deliveries)
+	return models.WebhookDelivery{}
+}
+
+func TestDispatcher_DeliversSignedPayload(t *testing.T) {
+	dispatcher, store := newTestDispatcher(t)
+
+	received := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	webhook := models.Webhook{URL: receiver.URL, Secret: "test-secret-1234567890", CampaignID: "campaign-1", Active: true}
+	if err := store.Create(&webhook); err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+
+	character := &models.Character{ID: "char-1", CharacterName: "Hooked", CampaignID: "campaign-1", Level: 2,
+		SecretBackstory: "Heir to the throne", DMNotes: "Betrays the party"}
+	if err := dispatcher.Publish(context.Background(), events.NewCharacterEvent(events.CharacterLeveledUp, character)); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	req := <-received
+	body := <-bodies
+	if got := req.Header.Get(HeaderEvent); got != events.CharacterLeveledUp {
+		t.Errorf("Expected event header %q, got %q", events.CharacterLeveledUp, got)
+	}
+	timestamp, err := strconv.ParseInt(req.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("Invalid timestamp header: %v", err)
+	}
+	if got, want := req.Header.Get(HeaderSignature), Sign(webhook.Secret, timestamp, body); got != want {
+		t.Errorf("Signature mismatch: got %q, want %q", got, want)
+	}
+
+	var sent events.Event
+	if err := json.Unmarshal(body, &sent); err != nil {
+		t.Fatalf("Invalid payload: %v", err)
+	}
+	if sent.Character == nil || sent.Character.CharacterName != "Hooked" {
+		t.Errorf("Expected the character in the payload, got %s", body)
+	} else if sent.Character.SecretBackstory != "" || sent.Character.DMNotes != "" {
+		t.Errorf("Expected hidden fields to be stripped from the payload, got %s", body)
+	}
+	if character.SecretBackstory == "" {
+		t.Error("Expected the published character to be left as it was")
+	}
+
+	delivery := waitForStatus(t, store, webhook.ID, models.DeliverySucceeded)
+	if delivery.Attempts != 1 || delivery.ResponseStatus != http.StatusNoContent || delivery.DeliveredAt == nil {
+		t.Errorf("Unexpected delivery record: %+v", delivery)
+	}
+}
+
+func TestDispatcher_RefusesPrivateAddresses(t *testing.T) {
+	store := database.NewMemoryWebhookStore()
+	config := testConfig()
+	config.MaxAttempts = 1
+	config.AllowPrivateAddresses = false
+	dispatcher := NewDispatcher(store, config, logging.NewLogger(logging.Config{Level: "error", Format: "json", Output: "console"}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dispatcher.Run(ctx)
+
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	// Registered as if its host had resolved elsewhere when it was validated
+	webhook := models.Webhook{URL: receiver.URL, Secret: "test-secret-1234567890", Active: true}
+	if err := store.Create(&webhook); err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+
+	character := &models.Character{ID: "char-1", CharacterName: "Rebound"}
+	if err := dispatcher.Publish(context.Background(), events.NewCharacterEvent(events.CharacterCreated, character)); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	delivery := waitForStatus(t, store, webhook.ID, models.DeliveryDead)
+	if calls.Load() != 0 {
+		t.Errorf("Expected the loopback receiver never to be called, got %d calls", calls.Load())
+	}
+	if !strings.Contains(delivery.LastError, "not a public address") {
+		t.Errorf("Expected the refusal to be recorded, got %q", delivery.LastError)
+	}
+}
+
+func TestDispatcher_RetriesThenDeadLetters(t *testing.T) {
+	dispatcher, store := newTestDispatcher(t)
+
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	webhook := models.Webhook{URL: receiver.URL, Secret: "test-secret-1234567890", Active: true}
+	if err := store.Create(&webhook); err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+
+	character := &models.Character{ID: "char-1", CharacterName: "Unlucky"}
+	if err := dispatcher.Publish(context.Background(), events.NewCharacterEvent(events.CharacterCreated, character)); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	delivery := waitForStatus(t, store, webhook.ID, models.DeliveryDead)
+	if delivery.Attempts != 3 || calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d attempts and %d calls", delivery.Attempts, calls.Load())
+	}
+	if delivery.ResponseStatus != http.StatusServiceUnavailable || delivery.LastError == "" {
+		t.Errorf("Expected the last failure to be recorded: %+v", delivery)
+	}
+
+	// A redelivered dead letter gets a fresh round of attempts
+	if _, err := dispatcher.Redeliver(delivery.ID); err != nil {
+		t.Fatalf("Redeliver failed: %v", err)
+	}
+	waitForStatus(t, store, webhook.ID, models.DeliveryDead)
+	if calls.Load() != 6 {
+		t.Errorf("Expected 6 calls after redelivery, got %d", calls.Load())
+	}
+}
+
+func TestDispatcher_Subscriptions(t *testing.T) {
+	store := database.NewMemoryWebhookStore()
+	dispatcher := NewDispatcher(store, testConfig(), logging.NewLogger(logging.Config{Level: "error", Format: "json", Output: "console"}))
+
+	hooks := []models.Webhook{
+		{ID: "global", URL: "http://example.invalid", Active: true},
+		{ID: "campaign-1", URL: "http://example.invalid", CampaignID: "campaign-1", Active: true},
+		{ID: "campaign-2", URL: "http://example.invalid", CampaignID: "campaign-2", Active: true},
+		{ID: "deletes-only", URL: "http://example.invalid", CampaignID: "campaign-1", Events: []string{events.CharacterDeleted}, Active: true},
+		{ID: "inactive", URL: "http://example.invalid", Active: false},
+	}
+	for i := range hooks {
+		if err := store.Create(&hooks[i]); err != nil {
+			t.Fatalf("Failed to create webhook: %v", err)
+		}
+	}
+
+	character := &models.Character{ID: "char-1", CampaignID: "campaign-1"}
+	if err := dispatcher.Publish(context.Background(), events.NewCharacterEvent(events.CharacterUpdated, character)); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	expected := map[string]int{"global": 1, "campaign-1": 1, "campaign-2": 0, "deletes-only": 0, "inactive": 0}
+	for id, want := range expected {
+		deliveries, _ := store.ListDeliveries(id, 10)
+		if len(deliveries) != want {
+			t.Errorf("Webhook %s: expected %d deliveries, got %d", id, want, len(deliveries))
+		}
+	}
+}
+
+func TestConfig_Backoff(t *testing.T) {
+	config := Config{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, want := range expected {
+		if got := config.Backoff(i + 1); got != want {
+			t.Errorf("Backoff(%d) = %v, want %v", i+1, got, want)
+		}
+	}
+}
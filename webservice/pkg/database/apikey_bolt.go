@@ -0,0 +1,57 @@
+package database
+
+import (
+	"time"
+
+	"player-character/internal/models"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltAPIKeyStore implements API key storage in a bbolt database file,
+// keeping keys in memory as MemoryAPIKeyStore does and writing every change
+// through to the file
+type BoltAPIKeyStore struct {
+	*MemoryAPIKeyStore
+	table *boltTable[models.APIKey]
+}
+
+// NewBoltAPIKeyStore creates an API key store in db, loading the keys it already holds
+func NewBoltAPIKeyStore(db *bolt.DB) (*BoltAPIKeyStore, error) {
+	memory := NewMemoryAPIKeyStore()
+	get, set := mapAccess(&memory.mutex, memory.keys)
+	table, err := newBoltTable(db, "apikeys", get, set)
+	if err != nil {
+		return nil, err
+	}
+	return &BoltAPIKeyStore{MemoryAPIKeyStore: memory, table: table}, nil
+}
+
+// Create stores a new API key
+func (s *BoltAPIKeyStore) Create(key *models.APIKey) error {
+	assignID(&key.ID)
+	return s.table.write([]string{key.ID}, func() error {
+		return s.MemoryAPIKeyStore.Create(key)
+	})
+}
+
+// Revoke marks an API key as revoked
+func (s *BoltAPIKeyStore) Revoke(id string) error {
+	return s.table.write([]string{id}, func() error {
+		return s.MemoryAPIKeyStore.Revoke(id)
+	})
+}
+
+// TouchLastUsed records when an API key was last used
+func (s *BoltAPIKeyStore) TouchLastUsed(id string, at time.Time) error {
+	return s.table.write([]string{id}, func() error {
+		return s.MemoryAPIKeyStore.TouchLastUsed(id, at)
+	})
+}
+
+// Restore stores an API key as it is, keeping its ID and timestamps
+func (s *BoltAPIKeyStore) Restore(key *models.APIKey) error {
+	return s.table.write([]string{key.ID}, func() error {
+		return s.MemoryAPIKeyStore.Restore(key)
+	})
+}
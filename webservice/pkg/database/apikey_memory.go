@@ -0,0 +1,170 @@
+package database
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryAPIKeyStore implements an in-memory API key storage
+type MemoryAPIKeyStore struct {
+	keys  map[string]models.APIKey
+	mutex sync.RWMutex
+}
+
+// NewMemoryAPIKeyStore creates a new in-memory API key store
+func NewMemoryAPIKeyStore() *MemoryAPIKeyStore {
+	return &MemoryAPIKeyStore{
+		keys: make(map[string]models.APIKey),
+	}
+}
+
+// Create stores a new API key
+func (s *MemoryAPIKeyStore) Create(key *models.APIKey) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Generate ID if not provided
+	if key.ID == "" {
+		key.ID = uuid.New().String()
+	}
+
+	if _, exists := s.keys[key.ID]; exists {
+		return errors.New("api key with this ID already exists")
+	}
+
+	key.CreatedAt = time.Now()
+
+	s.keys[key.ID] = *key
+	return nil
+}
+
+// Get retrieves an API key by ID
+func (s *MemoryAPIKeyStore) Get(id string) (*models.APIKey, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	key, exists := s.keys[id]
+	if !exists {
+		return nil, errors.New("api key not found")
+	}
+
+	return &key, nil
+}
+
+// GetByHash retrieves an API key by the hash of its secret
+func (s *MemoryAPIKeyStore) GetByHash(hash string) (*models.APIKey, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, key := range s.keys {
+		if key.KeyHash == hash {
+			return &key, nil
+		}
+	}
+
+	return nil, errors.New("api key not found")
+}
+
+// ListByUser retrieves every key minted by the user, newest first
+func (s *MemoryAPIKeyStore) ListByUser(userID string) ([]models.APIKey, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	keys := []models.APIKey{}
+	for _, key := range s.keys {
+		if key.UserID == userID {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+
+	return keys, nil
+}
+
+// Revoke marks an API key as revoked
+func (s *MemoryAPIKeyStore) Revoke(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key, exists := s.keys[id]
+	if !exists {
+		return errors.New("api key not found")
+	}
+
+	if key.RevokedAt == nil {
+		now := time.Now()
+		key.RevokedAt = &now
+		s.keys[id] = key
+	}
+	return nil
+}
+
+// TouchLastUsed records when an API key was last used
+func (s *MemoryAPIKeyStore) TouchLastUsed(id string, at time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key, exists := s.keys[id]
+	if !exists {
+		return errors.New("api key not found")
+	}
+
+	key.LastUsedAt = &at
+	s.keys[id] = key
+	return nil
+}
+
+// All retrieves every API key, oldest first
+func (s *MemoryAPIKeyStore) All() ([]models.APIKey, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	keys := make([]models.APIKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+		}
+		return keys[i].ID < keys[j].ID
+	})
+
+	return keys, nil
+}
+
+// Restore stores an API key as it is, keeping its ID and timestamps
+func (s *MemoryAPIKeyStore) Restore(key *models.APIKey) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.keys[key.ID]; exists {
+		return ErrExists
+	}
+
+	s.keys[key.ID] = *key
+	return nil
+}
+
+// APIKeyStore interface defines the contract for API key storage
+type APIKeyStore interface {
+	Create(key *models.APIKey) error
+	Get(id string) (*models.APIKey, error)
+	GetByHash(hash string) (*models.APIKey, error)
+	ListByUser(userID string) ([]models.APIKey, error)
+	Revoke(id string) error
+	TouchLastUsed(id string, at time.Time) error
+	// All retrieves every API key, oldest first, for backups
+	All() ([]models.APIKey, error)
+	// Restore stores an API key from a backup as it is, returning ErrExists if its ID is taken
+	Restore(key *models.APIKey) error
+}
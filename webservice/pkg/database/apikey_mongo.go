@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoAPIKeyStore implements MongoDB-based API key storage
+type MongoAPIKeyStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoAPIKeyStore creates an API key store backed by the given database
+func NewMongoAPIKeyStore(database *mongo.Database, collectionName string) *MongoAPIKeyStore {
+	return &MongoAPIKeyStore{
+		collection: database.Collection(collectionName),
+	}
+}
+
+// Create stores a new API key
+func (s *MongoAPIKeyStore) Create(key *models.APIKey) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Generate ID if not provided
+	if key.ID == "" {
+		key.ID = uuid.New().String()
+	}
+
+	key.CreatedAt = time.Now()
+
+	_, err := s.collection.InsertOne(ctx, key)
+	return err
+}
+
+// Get retrieves an API key by ID
+func (s *MongoAPIKeyStore) Get(id string) (*models.APIKey, error) {
+	return s.findOne(bson.M{"id": id})
+}
+
+// GetByHash retrieves an API key by the hash of its secret
+func (s *MongoAPIKeyStore) GetByHash(hash string) (*models.APIKey, error) {
+	return s.findOne(bson.M{"keyHash": hash})
+}
+
+// findOne decodes the first API key matching filter
+func (s *MongoAPIKeyStore) findOne(filter bson.M) (*models.APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var key models.APIKey
+	err := s.collection.FindOne(ctx, filter).Decode(&key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("api key not found")
+		}
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// ListByUser retrieves every key minted by the user, newest first
+func (s *MongoAPIKeyStore) ListByUser(userID string) ([]models.APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := s.collection.Find(ctx, bson.M{"userId": userID}, options.Find().SetSort(bson.M{"createdAt": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := []models.APIKey{}
+	if err = cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// All retrieves every API key, oldest first
+func (s *MongoAPIKeyStore) All() ([]models.APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	keys := []models.APIKey{}
+	if err := findAll(ctx, s.collection, &keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// Restore stores an API key as it is, keeping its ID and timestamps
+func (s *MongoAPIKeyStore) Restore(key *models.APIKey) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return insertIfAbsent(ctx, s.collection, bson.M{"id": key.ID}, key)
+}
+
+// Revoke marks an API key as revoked
+func (s *MongoAPIKeyStore) Revoke(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := s.collection.UpdateOne(ctx,
+		bson.M{"id": id},
+		bson.A{bson.M{"$set": bson.M{"revokedAt": bson.M{"$ifNull": bson.A{"$revokedAt", time.Now()}}}}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("api key not found")
+	}
+
+	return nil
+}
+
+// TouchLastUsed records when an API key was last used
+func (s *MongoAPIKeyStore) TouchLastUsed(id string, at time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := s.collection.UpdateOne(ctx, bson.M{"id": id}, bson.M{"$set": bson.M{"lastUsedAt": at}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("api key not found")
+	}
+
+	return nil
+}
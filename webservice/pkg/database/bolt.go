@@ -0,0 +1,218 @@
+package database
+
+import (
+	"sync"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/google/uuid"
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// OpenBolt opens the bbolt database file at path, creating it if needed.
+// Only one process may have it open at a time.
+func OpenBolt(path string) (*bolt.DB, error) {
+	return bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+}
+
+// boltTable persists the records of a memory store in a bbolt bucket, as
+// BSON documents in the same layout as in MongoDB, keyed by ID
+type boltTable[T any] struct {
+	db     *bolt.DB
+	bucket []byte
+	mutex  sync.Mutex                  // serializes writes, so memory and the file change in the same order
+	get    func(id string) *T          // the memory store's copy of a record, nil if it has none
+	set    func(records map[string]*T) // puts records into the memory store, removing those that are nil
+}
+
+// newBoltTable creates the bucket if needed and loads its records into the memory store
+func newBoltTable[T any](db *bolt.DB, bucket string, get func(string) *T, set func(map[string]*T)) (*boltTable[T], error) {
+	t := &boltTable[T]{db: db, bucket: []byte(bucket), get: get, set: set}
+
+	records := map[string]*T{}
+	err := db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(t.bucket)
+		if err != nil {
+			return err
+		}
+		return b.ForEach(func(id, data []byte) error {
+			var record T
+			if err := bson.Unmarshal(data, &record); err != nil {
+				return err
+			}
+			records[string(id)] = &record
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	set(records)
+	return t, nil
+}
+
+// write applies change to the memory store, then saves the records with the
+// given IDs as the memory store now holds them. When saving fails, those
+// records are put back in memory as they were and the error is returned.
+func (t *boltTable[T]) write(ids []string, change func() error) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	before := make(map[string]*T, len(ids))
+	for _, id := range ids {
+		before[id] = t.get(id)
+	}
+
+	if err := change(); err != nil {
+		return err
+	}
+
+	after := make(map[string]*T, len(ids))
+	for _, id := range ids {
+		after[id] = t.get(id)
+	}
+	if err := t.save(after); err != nil {
+		t.set(before)
+		return err
+	}
+	return nil
+}
+
+// save writes records to the bucket in one transaction, deleting those that are nil
+func (t *boltTable[T]) save(records map[string]*T) error {
+	return t.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(t.bucket)
+		for id, record := range records {
+			if record == nil {
+				if err := b.Delete([]byte(id)); err != nil {
+					return err
+				}
+				continue
+			}
+			data, err := bson.Marshal(record)
+			if err != nil {
+				return err
+			}
+			if err := b.Put([]byte(id), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// mapAccess returns get and set functions for a boltTable over a memory store's map
+func mapAccess[T any](mutex *sync.RWMutex, records map[string]T) (func(string) *T, func(map[string]*T)) {
+	get := func(id string) *T {
+		mutex.RLock()
+		defer mutex.RUnlock()
+
+		record, exists := records[id]
+		if !exists {
+			return nil
+		}
+		return &record
+	}
+	set := func(changes map[string]*T) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		for id, record := range changes {
+			if record == nil {
+				delete(records, id)
+				continue
+			}
+			records[id] = *record
+		}
+	}
+	return get, set
+}
+
+// assignID gives a record about to be created its ID up front, so that the
+// write-through knows which record to save
+func assignID(id *string) {
+	if *id == "" {
+		*id = uuid.New().String()
+	}
+}
+
+// BoltStore implements character storage in a bbolt database file, for
+// single-node deployments without MongoDB. Characters are kept in memory as
+// well, so listing, sorting and search behave exactly as for MemoryStore;
+// every change is written through to the file before it returns.
+type BoltStore struct {
+	*MemoryStore
+	table *boltTable[models.Character]
+}
+
+// NewBoltStore creates a character store in db, loading the characters it already holds
+func NewBoltStore(db *bolt.DB) (*BoltStore, error) {
+	memory := NewMemoryStore()
+	get := func(id string) *models.Character {
+		character, err := memory.Get(id)
+		if err != nil {
+			return nil
+		}
+		return character
+	}
+	set := func(records map[string]*models.Character) {
+		memory.mutex.Lock()
+		defer memory.mutex.Unlock()
+
+		memory.restore(records)
+	}
+
+	table, err := newBoltTable(db, "characters", get, set)
+	if err != nil {
+		return nil, err
+	}
+	return &BoltStore{MemoryStore: memory, table: table}, nil
+}
+
+// Create adds a new character
+func (s *BoltStore) Create(character *models.Character) error {
+	assignID(&character.ID)
+	return s.table.write([]string{character.ID}, func() error {
+		return s.MemoryStore.Create(character)
+	})
+}
+
+// Update modifies an existing character
+func (s *BoltStore) Update(id string, character *models.Character) error {
+	return s.table.write([]string{id}, func() error {
+		return s.MemoryStore.Update(id, character)
+	})
+}
+
+// Delete removes a character
+func (s *BoltStore) Delete(id string) error {
+	return s.table.write([]string{id}, func() error {
+		return s.MemoryStore.Delete(id)
+	})
+}
+
+// Restore stores a character as it is, keeping its ID and timestamps
+func (s *BoltStore) Restore(character *models.Character) error {
+	return s.table.write([]string{character.ID}, func() error {
+		return s.MemoryStore.Restore(character)
+	})
+}
+
+// Bulk applies a batch of writes atomically, in memory and in the file
+func (s *BoltStore) Bulk(writes []BulkWrite) error {
+	ids := make([]string, 0, len(writes))
+	for _, w := range writes {
+		if w.Op == BulkCreate {
+			assignID(&w.Character.ID)
+			ids = append(ids, w.Character.ID)
+			continue
+		}
+		ids = append(ids, w.ID)
+	}
+	return s.table.write(ids, func() error {
+		return s.MemoryStore.Bulk(writes)
+	})
+}
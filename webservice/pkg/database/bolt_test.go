@@ -0,0 +1,192 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// openTestBolt opens a bbolt file in a temporary directory, closed after the test
+func openTestBolt(t *testing.T, path string) *bolt.DB {
+	t.Helper()
+	db, err := OpenBolt(path)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", path, err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// newTestBoltStore creates a character store in a fresh bbolt file
+func newTestBoltStore(t *testing.T) *BoltStore {
+	t.Helper()
+	store, err := NewBoltStore(openTestBolt(t, filepath.Join(t.TempDir(), "test.db")))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	return store
+}
+
+// TestBoltStorePersists tests that characters written by one store are loaded by the next
+func TestBoltStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	db := openTestBolt(t, path)
+	store, err := NewBoltStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kept := &models.Character{CharacterName: "Thorin", Race: "Dwarf", Class: "Fighter", Level: 3, Features: []string{"Second Wind"}}
+	deleted := &models.Character{CharacterName: "Boromir", Race: "Human", Class: "Fighter", Level: 5}
+	for _, c := range []*models.Character{kept, deleted} {
+		if err := store.Create(c); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	updated := *kept
+	updated.Level = 4
+	if err := store.Update(kept.ID, &updated); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := store.Delete(deleted.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	fresh := &models.Character{CharacterName: "Gimli", Race: "Dwarf", Class: "Barbarian", Level: 2}
+	if err := store.Bulk([]BulkWrite{{Op: BulkCreate, Character: fresh}}); err != nil {
+		t.Fatalf("Bulk: %v", err)
+	}
+	db.Close()
+
+	reopened, err := NewBoltStore(openTestBolt(t, path))
+	if err != nil {
+		t.Fatalf("Failed to reopen: %v", err)
+	}
+	characters, total, err := reopened.List(ListOptions{Page: 1, Limit: 10, SortBy: "characterName", SortOrder: "asc"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 2 || characters[0].ID != fresh.ID || characters[1].ID != kept.ID {
+		t.Fatalf("Expected Gimli and Thorin after reopening, got %+v", characters)
+	}
+	if characters[1].Level != 4 || len(characters[1].Features) != 1 || !characters[1].CreatedAt.Equal(kept.CreatedAt.Truncate(time.Millisecond)) {
+		t.Errorf("Expected the update to persist, got %+v", characters[1])
+	}
+	if hits, _, err := reopened.Search("second wind", ListOptions{Page: 1, Limit: 10}); err != nil || len(hits) != 1 {
+		t.Errorf("Expected reloaded characters to be searchable, got %v, %v", hits, err)
+	}
+}
+
+// TestBoltStoreUndoesFailedWrites tests that a change the file does not take is not kept in memory either
+func TestBoltStoreUndoesFailedWrites(t *testing.T) {
+	db := openTestBolt(t, filepath.Join(t.TempDir(), "test.db"))
+	store, err := NewBoltStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing := &models.Character{CharacterName: "Thorin", Race: "Dwarf", Class: "Fighter", Level: 3}
+	if err := store.Create(existing); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	failed := &models.Character{CharacterName: "Gimli", Race: "Dwarf", Class: "Barbarian", Level: 2}
+	if err := store.Create(failed); err == nil {
+		t.Fatal("Expected Create to fail on a closed file")
+	}
+	if _, err := store.Get(failed.ID); err == nil {
+		t.Error("Expected the failed create not to be kept")
+	}
+
+	renamed := *existing
+	renamed.CharacterName = "Renamed"
+	if err := store.Bulk([]BulkWrite{{Op: BulkUpdate, ID: existing.ID, Character: &renamed}}); err == nil {
+		t.Fatal("Expected Bulk to fail on a closed file")
+	}
+	if character, _ := store.Get(existing.ID); character.CharacterName != "Thorin" {
+		t.Errorf("Expected the failed update to be undone, got %q", character.CharacterName)
+	}
+	if hits, _, _ := store.Search("renamed", ListOptions{Page: 1, Limit: 10}); len(hits) != 0 {
+		t.Errorf("Expected the failed update to be unsearchable, got %v", hits)
+	}
+}
+
+// TestBoltSiblingStoresPersist tests that campaigns, users, API keys and webhooks survive reopening
+func TestBoltSiblingStoresPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	db := openTestBolt(t, path)
+
+	campaigns, err := NewBoltCampaignStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := NewBoltUserStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := NewBoltAPIKeyStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	webhooks, err := NewBoltWebhookStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	campaign := &models.Campaign{Name: "Lost Mine", DMID: "user-1", Parties: []models.Party{{Name: "Heroes"}}}
+	if err := campaigns.Create(campaign); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.Create(&models.User{ID: "user-1", Username: "Gandalf", PasswordHash: "hash"}); err != nil {
+		t.Fatal(err)
+	}
+	key := &models.APIKey{Name: "bot", UserID: "user-1", KeyHash: "key-hash"}
+	if err := keys.Create(key); err != nil {
+		t.Fatal(err)
+	}
+	if err := keys.Revoke(key.ID); err != nil {
+		t.Fatal(err)
+	}
+	webhook := &models.Webhook{OwnerID: "user-1", URL: "https://example.com/hook", Secret: "secret"}
+	if err := webhooks.Create(webhook); err != nil {
+		t.Fatal(err)
+	}
+	delivery := &models.WebhookDelivery{WebhookID: webhook.ID, Status: models.DeliveryPending}
+	if err := webhooks.CreateDelivery(delivery); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	db = openTestBolt(t, path)
+	if campaigns, err = NewBoltCampaignStore(db); err != nil {
+		t.Fatal(err)
+	}
+	if users, err = NewBoltUserStore(db); err != nil {
+		t.Fatal(err)
+	}
+	if keys, err = NewBoltAPIKeyStore(db); err != nil {
+		t.Fatal(err)
+	}
+	if webhooks, err = NewBoltWebhookStore(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded, err := campaigns.Get(campaign.ID); err != nil || len(loaded.Parties) != 1 || loaded.Parties[0].ID == "" {
+		t.Errorf("Expected the campaign and its party to persist, got %+v (%v)", loaded, err)
+	}
+	if user, err := users.GetByUsername("gandalf"); err != nil || user.PasswordHash != "hash" {
+		t.Errorf("Expected the user to persist with its password hash, got %+v (%v)", user, err)
+	}
+	if loaded, err := keys.GetByHash("key-hash"); err != nil || loaded.RevokedAt == nil {
+		t.Errorf("Expected the revoked key to persist, got %+v (%v)", loaded, err)
+	}
+	if loaded, err := webhooks.Get(webhook.ID); err != nil || loaded.Secret != "secret" {
+		t.Errorf("Expected the webhook to persist with its secret, got %+v (%v)", loaded, err)
+	}
+	if deliveries, err := webhooks.ListDeliveries(webhook.ID, 10); err != nil || len(deliveries) != 1 {
+		t.Errorf("Expected the delivery to persist, got %+v (%v)", deliveries, err)
+	}
+}
@@ -0,0 +1,40 @@
+package database
+
+import (
+	"fmt"
+
+	"player-character/internal/models"
+)
+
+// BulkOp is the kind of write in a bulk batch
+type BulkOp string
+
+const (
+	BulkCreate BulkOp = "create"
+	BulkUpdate BulkOp = "update"
+	BulkDelete BulkOp = "delete"
+)
+
+// BulkWrite is one write of a bulk batch. Creates and updates carry the
+// character, which is filled in with the stored ID and timestamps as for
+// Create and Update; updates and deletes name the character by ID.
+type BulkWrite struct {
+	Op        BulkOp
+	ID        string
+	Character *models.Character
+}
+
+// BulkError reports which write of a batch failed. None of the batch was applied.
+type BulkError struct {
+	Index int
+	Err   error
+}
+
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("bulk write %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error of the failed write
+func (e *BulkError) Unwrap() error {
+	return e.Err
+}
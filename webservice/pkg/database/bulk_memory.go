@@ -0,0 +1,69 @@
+package database
+
+import (
+	"fmt"
+
+	"player-character/internal/models"
+)
+
+// Bulk applies a batch of writes under a single lock. When a write fails,
+// the characters touched so far are restored and a *BulkError is returned.
+func (s *MemoryStore) Bulk(writes []BulkWrite) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// The state of each touched character before the batch, nil if it did not exist
+	previous := make(map[string]*models.Character)
+	remember := func(id string) {
+		if _, seen := previous[id]; seen {
+			return
+		}
+		if existing, exists := s.characters[id]; exists {
+			previous[id] = &existing
+		} else {
+			previous[id] = nil
+		}
+	}
+
+	for i, w := range writes {
+		var err error
+		switch w.Op {
+		case BulkCreate:
+			id := w.Character.ID
+			if id != "" {
+				remember(id)
+			}
+			err = s.create(w.Character)
+			if err == nil && id == "" {
+				// A generated ID is new, so undoing the create removes it
+				previous[w.Character.ID] = nil
+			}
+		case BulkUpdate:
+			remember(w.ID)
+			err = s.update(w.ID, w.Character)
+		case BulkDelete:
+			remember(w.ID)
+			err = s.delete(w.ID)
+		default:
+			err = fmt.Errorf("unknown bulk operation %q", w.Op)
+		}
+		if err != nil {
+			s.restore(previous)
+			return &BulkError{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// restore puts characters back to their remembered state; the caller holds the write lock
+func (s *MemoryStore) restore(previous map[string]*models.Character) {
+	for id, character := range previous {
+		if character == nil {
+			delete(s.characters, id)
+			s.index.remove(id)
+			continue
+		}
+		s.characters[id] = *character
+		s.index.add(character)
+	}
+}
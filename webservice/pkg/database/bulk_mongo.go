@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Bulk applies a batch of writes in a MongoDB transaction, which needs a
+// replica set or sharded cluster. When a write fails the transaction is
+// aborted and a *BulkError is returned.
+func (s *MongoStore) Bulk(writes []BulkWrite) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		for i, w := range writes {
+			var err error
+			switch w.Op {
+			case BulkCreate:
+				err = s.create(sc, w.Character)
+			case BulkUpdate:
+				err = s.update(sc, w.ID, w.Character)
+			case BulkDelete:
+				err = s.delete(sc, w.ID)
+			default:
+				err = fmt.Errorf("unknown bulk operation %q", w.Op)
+			}
+			if err != nil {
+				return nil, &BulkError{Index: i, Err: err}
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
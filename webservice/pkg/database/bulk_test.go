@@ -0,0 +1,30 @@
+package database
+
+import (
+	"testing"
+
+	"player-character/internal/models"
+)
+
+func TestMemoryStoreBulkRestoresReusedID(t *testing.T) {
+	store := NewMemoryStore()
+	original := &models.Character{ID: "reused", CharacterName: "Original", Race: "Elf", Class: "Wizard", Level: 3}
+	if err := store.Create(original); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Delete then recreate the same ID, then fail
+	err := store.Bulk([]BulkWrite{
+		{Op: BulkDelete, ID: "reused"},
+		{Op: BulkCreate, Character: &models.Character{ID: "reused", CharacterName: "Replacement", Race: "Human", Class: "Rogue", Level: 1}},
+		{Op: BulkDelete, ID: "missing"},
+	})
+	if err == nil {
+		t.Fatal("Expected the batch to fail")
+	}
+
+	restored, err := store.Get("reused")
+	if err != nil || restored.CharacterName != "Original" {
+		t.Errorf("Expected the original character back, got %+v, %v", restored, err)
+	}
+}
@@ -0,0 +1,345 @@
+package database
+
+import (
+	"container/list"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"player-character/internal/models"
+	"player-character/pkg/filter"
+)
+
+// CacheConfig sizes a CachedStore
+type CacheConfig struct {
+	Size     int           // characters kept for Get; the least recently used are evicted
+	TTL      time.Duration // how long a character is served from the cache
+	ListSize int           // List results kept
+	ListTTL  time.Duration // how long a List result is served from the cache
+}
+
+// DefaultCacheConfig returns the cache settings used unless configured otherwise
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		Size:     10000,
+		TTL:      5 * time.Minute,
+		ListSize: 100,
+		ListTTL:  5 * time.Second,
+	}
+}
+
+// CacheStats counts cache lookups since the store was created
+type CacheStats struct {
+	Hits        uint64 `json:"hits"`
+	Misses      uint64 `json:"misses"`
+	ListHits    uint64 `json:"listHits"`
+	ListMisses  uint64 `json:"listMisses"`
+	Evictions   uint64 `json:"evictions"`
+	Entries     int    `json:"entries"`
+	ListEntries int    `json:"listEntries"`
+}
+
+// CachedStore is a read-through cache in front of another CharacterStore.
+// Get and List results are served from memory until they expire or a write
+// through this store invalidates them. Writes any other way, such as by
+// another replica, are only seen once the entries expire or Invalidate is
+// called; PublishWrites lets each replica tell the others of its writes.
+// Search and All always read the underlying store.
+type CachedStore struct {
+	CharacterStore
+
+	mutex      sync.Mutex
+	characters *lru[models.Character]
+	lists      *lru[cachedList]
+	// generation counts invalidations, so that a read racing a write does
+	// not cache what it read from before the write
+	generation uint64
+	stats      CacheStats
+	publish    func(ids []string)
+}
+
+// cachedList is a List result
+type cachedList struct {
+	characters []models.Character
+	total      int
+}
+
+// NewCachedStore wraps store with a cache
+func NewCachedStore(store CharacterStore, config CacheConfig) *CachedStore {
+	return &CachedStore{
+		CharacterStore: store,
+		characters:     newLRU[models.Character](config.Size, config.TTL),
+		lists:          newLRU[cachedList](config.ListSize, config.ListTTL),
+	}
+}
+
+// Get retrieves a character, from the cache if it holds it
+func (s *CachedStore) Get(id string) (*models.Character, error) {
+	s.mutex.Lock()
+	character, ok := s.characters.get(id, time.Now())
+	if ok {
+		s.stats.Hits++
+		s.mutex.Unlock()
+		return &character, nil
+	}
+	s.stats.Misses++
+	generation := s.generation
+	s.mutex.Unlock()
+
+	stored, err := s.CharacterStore.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	if s.generation == generation && s.characters.add(id, *stored, time.Now()) {
+		s.stats.Evictions++
+	}
+	s.mutex.Unlock()
+	return stored, nil
+}
+
+// List retrieves a page of characters, from the cache if the same page was
+// listed recently
+func (s *CachedStore) List(opts ListOptions) ([]models.Character, int, error) {
+	key := listKey(opts)
+
+	s.mutex.Lock()
+	cached, ok := s.lists.get(key, time.Now())
+	if ok {
+		s.stats.ListHits++
+		s.mutex.Unlock()
+		return append(make([]models.Character, 0, len(cached.characters)), cached.characters...), cached.total, nil
+	}
+	s.stats.ListMisses++
+	generation := s.generation
+	s.mutex.Unlock()
+
+	characters, total, err := s.CharacterStore.List(opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.mutex.Lock()
+	if s.generation == generation {
+		page := append(make([]models.Character, 0, len(characters)), characters...)
+		s.lists.add(key, cachedList{characters: page, total: total}, time.Now())
+	}
+	s.mutex.Unlock()
+	return characters, total, nil
+}
+
+// Create stores a new character. Cached characters stay valid, but any
+// listing may now include it.
+func (s *CachedStore) Create(character *models.Character) error {
+	defer s.written()
+	return s.CharacterStore.Create(character)
+}
+
+// Update replaces a character and drops it from the cache
+func (s *CachedStore) Update(id string, character *models.Character) error {
+	defer s.written(id)
+	return s.CharacterStore.Update(id, character)
+}
+
+// Delete removes a character and drops it from the cache
+func (s *CachedStore) Delete(id string) error {
+	defer s.written(id)
+	return s.CharacterStore.Delete(id)
+}
+
+// Restore stores a character from a backup and drops its ID from the cache
+func (s *CachedStore) Restore(character *models.Character) error {
+	defer s.written(character.ID)
+	return s.CharacterStore.Restore(character)
+}
+
+// Bulk applies a batch of writes and drops every character it names from the cache
+func (s *CachedStore) Bulk(writes []BulkWrite) error {
+	ids := make([]string, 0, len(writes))
+	for _, write := range writes {
+		if write.ID != "" {
+			ids = append(ids, write.ID)
+		}
+	}
+	defer s.written(ids...)
+	return s.CharacterStore.Bulk(writes)
+}
+
+// PublishWrites has every later write through the store call publish with
+// the IDs it invalidated, so that other replicas can invalidate them too.
+// Creations publish no IDs; they only make listings stale. Call it before the
+// store is used.
+func (s *CachedStore) PublishWrites(publish func(ids []string)) {
+	s.publish = publish
+}
+
+// written invalidates what a write through the store made stale, and publishes it
+func (s *CachedStore) written(ids ...string) {
+	s.Invalidate(ids...)
+	if s.publish != nil {
+		s.publish(ids)
+	}
+}
+
+// Invalidate drops the characters with the given IDs and every cached
+// listing. Writes made elsewhere, such as by other replicas, must be reported
+// through it to be seen before their entries expire.
+func (s *CachedStore) Invalidate(ids ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.generation++
+	for _, id := range ids {
+		s.characters.remove(id)
+	}
+	s.lists.clear()
+}
+
+// InvalidateAll drops every cached character and listing, for when writes
+// may have been made elsewhere without being reported through Invalidate
+func (s *CachedStore) InvalidateAll() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.generation++
+	s.characters.clear()
+	s.lists.clear()
+}
+
+// Stats returns the lookup counts and the number of cached entries
+func (s *CachedStore) Stats() CacheStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats := s.stats
+	stats.Entries = s.characters.len()
+	stats.ListEntries = s.lists.len()
+	return stats
+}
+
+// listKey identifies a listing by every option that affects its result
+func listKey(opts ListOptions) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d|%d|%t|%t|%s|%s|%q|%s|%q|%s|%s|",
+		opts.Page, opts.Limit, opts.SkipCount, opts.Lookahead, opts.SortBy, opts.SortOrder, opts.Search,
+		opts.CampaignID, opts.CampaignIDs, opts.PartyID, opts.OwnerID)
+	if opts.After != nil {
+		b.WriteString(opts.After.Encode())
+	}
+	b.WriteString("|")
+	if v := opts.Visibility; v != nil {
+		fmt.Fprintf(&b, "%s %q %q", v.UserID, v.CampaignIDs, v.WithinCampaigns)
+	}
+	fmt.Fprintf(&b, "|%q|", opts.Fields)
+	writeFilterKey(&b, opts.Filter)
+	return b.String()
+}
+
+// writeFilterKey writes a filter expression in a form that tells apart every
+// expression matching differently
+func writeFilterKey(b *strings.Builder, expr filter.Expr) {
+	switch e := expr.(type) {
+	case *filter.And:
+		b.WriteString("and(")
+		for _, operand := range e.Operands {
+			writeFilterKey(b, operand)
+			b.WriteString(",")
+		}
+		b.WriteString(")")
+	case *filter.Or:
+		b.WriteString("or(")
+		for _, operand := range e.Operands {
+			writeFilterKey(b, operand)
+			b.WriteString(",")
+		}
+		b.WriteString(")")
+	case *filter.Not:
+		b.WriteString("not(")
+		writeFilterKey(b, e.Operand)
+		b.WriteString(")")
+	case *filter.Comparison:
+		fmt.Fprintf(b, "%s %d %#v", e.Field.Name, e.Op, e.Values)
+	}
+}
+
+// lru is a fixed-size map whose entries expire, evicting the least recently
+// used entry to make room. It is not safe for concurrent use.
+type lru[V any] struct {
+	size    int
+	ttl     time.Duration
+	order   *list.List // of *lruEntry, most recently used first
+	entries map[string]*list.Element
+}
+
+// lruEntry is a cached value and when it stops being served
+type lruEntry[V any] struct {
+	key     string
+	value   V
+	expires time.Time
+}
+
+func newLRU[V any](size int, ttl time.Duration) *lru[V] {
+	return &lru[V]{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// get returns the value under key unless it is missing or expired
+func (c *lru[V]) get(key string, now time.Time) (V, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	entry := element.Value.(*lruEntry[V])
+	if !now.Before(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// add stores value under key, reporting whether another entry was evicted to make room
+func (c *lru[V]) add(key string, value V, now time.Time) bool {
+	if c.size <= 0 || c.ttl <= 0 {
+		return false
+	}
+	if element, ok := c.entries[key]; ok {
+		element.Value = &lruEntry[V]{key: key, value: value, expires: now.Add(c.ttl)}
+		c.order.MoveToFront(element)
+		return false
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{key: key, value: value, expires: now.Add(c.ttl)})
+	if c.order.Len() <= c.size {
+		return false
+	}
+	oldest := c.order.Back()
+	c.order.Remove(oldest)
+	delete(c.entries, oldest.Value.(*lruEntry[V]).key)
+	return true
+}
+
+func (c *lru[V]) remove(key string) {
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+		delete(c.entries, key)
+	}
+}
+
+func (c *lru[V]) clear() {
+	c.order.Init()
+	clear(c.entries)
+}
+
+func (c *lru[V]) len() int {
+	return c.order.Len()
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// cacheInvalidationRetention is how long published invalidations are kept in MongoDB
+const cacheInvalidationRetention = time.Hour
+
+// cacheInvalidation records a write through one replica's cache
+type cacheInvalidation struct {
+	IDs       []string  `bson:"ids"`
+	Timestamp time.Time `bson:"timestamp"`
+}
+
+// MongoCacheInvalidations shares cache invalidations between replicas
+// through a MongoDB collection. Every write through a replica's cache is
+// inserted into the collection, and every replica watches it with a change
+// stream, invalidating what the others wrote. This covers every write
+// through the cache, including restores and bulk writes, whichever event
+// broker is in use. Change streams require MongoDB to run as a replica set.
+type MongoCacheInvalidations struct {
+	collection *mongo.Collection
+	onError    func(error)
+}
+
+// NewMongoCacheInvalidations has cache publish its writes to the given
+// collection and watches it for other replicas' writes until ctx is done.
+// It fails unless MongoDB runs as a replica set. Publish and watch failures
+// are reported to onError, and the watch is restarted.
+func NewMongoCacheInvalidations(ctx context.Context, database *mongo.Database, collectionName string, cache *CachedStore, onError func(error)) (*MongoCacheInvalidations, error) {
+	collection := database.Collection(collectionName)
+
+	indexCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// A standalone server would only fail once watched, losing invalidations meanwhile
+	var hello struct {
+		SetName string `bson:"setName"`
+	}
+	if err := database.Client().Database("admin").RunCommand(indexCtx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return nil, err
+	}
+	if hello.SetName == "" {
+		return nil, errors.New("MongoDB is not running as a replica set, which change streams need")
+	}
+
+	// Expire old invalidations so the collection does not grow without bound
+	_, err := collection.Indexes().CreateOne(indexCtx, mongo.IndexModel{
+		Keys:    bson.M{"timestamp": 1},
+		Options: options.Index().SetExpireAfterSeconds(int32(cacheInvalidationRetention.Seconds())),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invalidations := &MongoCacheInvalidations{
+		collection: collection,
+		onError:    onError,
+	}
+	cache.PublishWrites(invalidations.publish)
+	go invalidations.watch(ctx, cache)
+
+	return invalidations, nil
+}
+
+// publish records a write; every replica, including this one, invalidates it from its change stream
+func (m *MongoCacheInvalidations) publish(ids []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if ids == nil {
+		ids = []string{}
+	}
+	if _, err := m.collection.InsertOne(ctx, cacheInvalidation{IDs: ids, Timestamp: time.Now()}); err != nil {
+		m.report(err)
+	}
+}
+
+// watch follows inserts into the collection until ctx is done, restarting after failures
+func (m *MongoCacheInvalidations) watch(ctx context.Context, cache *CachedStore) {
+	pipeline := mongo.Pipeline{bson.D{{Key: "$match", Value: bson.M{"operationType": "insert"}}}}
+
+	for ctx.Err() == nil {
+		stream, err := m.collection.Watch(ctx, pipeline)
+		if err != nil {
+			m.reportAndWait(ctx, err)
+			continue
+		}
+
+		// Writes made while the stream was down were missed, so nothing cached before it opened can be trusted
+		cache.InvalidateAll()
+
+		for stream.Next(ctx) {
+			var change struct {
+				FullDocument cacheInvalidation `bson:"fullDocument"`
+			}
+			if err := stream.Decode(&change); err != nil {
+				m.report(err)
+				cache.InvalidateAll()
+				continue
+			}
+			cache.Invalidate(change.FullDocument.IDs...)
+		}
+
+		if err := stream.Err(); err != nil && !errors.Is(err, context.Canceled) {
+			m.reportAndWait(ctx, err)
+		}
+		stream.Close(context.Background())
+	}
+}
+
+// report forwards an error to the error callback, if any
+func (m *MongoCacheInvalidations) report(err error) {
+	if m.onError != nil {
+		m.onError(err)
+	}
+}
+
+// reportAndWait reports err and pauses before the watch is retried
+func (m *MongoCacheInvalidations) reportAndWait(ctx context.Context, err error) {
+	m.report(err)
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+	}
+}
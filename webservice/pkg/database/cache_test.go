@@ -0,0 +1,225 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+	"player-character/pkg/filter"
+)
+
+// countingStore counts the reads that reach the underlying store
+type countingStore struct {
+	CharacterStore
+	gets, lists int
+}
+
+func (s *countingStore) Get(id string) (*models.Character, error) {
+	s.gets++
+	return s.CharacterStore.Get(id)
+}
+
+func (s *countingStore) List(opts ListOptions) ([]models.Character, int, error) {
+	s.lists++
+	return s.CharacterStore.List(opts)
+}
+
+func newCountingCache(t *testing.T, config CacheConfig) (*CachedStore, *countingStore) {
+	t.Helper()
+	backing := &countingStore{CharacterStore: NewMemoryStore()}
+	for _, id := range []string{"a", "b", "c"} {
+		if err := backing.Create(&models.Character{ID: id, CharacterName: "Character " + id, Race: "Elf", Class: "Wizard", Level: 1}); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	return NewCachedStore(backing, config), backing
+}
+
+func TestCachedStoreGet(t *testing.T) {
+	cache, backing := newCountingCache(t, DefaultCacheConfig())
+
+	for i := 0; i < 3; i++ {
+		if character, err := cache.Get("a"); err != nil || character.CharacterName != "Character a" {
+			t.Fatalf("Get failed: %+v, %v", character, err)
+		}
+	}
+	if backing.gets != 1 {
+		t.Errorf("Expected one read of the store, got %d", backing.gets)
+	}
+
+	// Callers may change what they get without changing the cache
+	character, _ := cache.Get("a")
+	character.CharacterName = "Changed"
+	if character, _ := cache.Get("a"); character.CharacterName != "Character a" {
+		t.Errorf("Expected the cached character to be unchanged, got %q", character.CharacterName)
+	}
+
+	if err := cache.Update("a", &models.Character{CharacterName: "Updated", Race: "Elf", Class: "Wizard", Level: 2}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if character, _ := cache.Get("a"); character.CharacterName != "Updated" {
+		t.Errorf("Expected the update to be read, got %q", character.CharacterName)
+	}
+
+	if err := cache.Delete("a"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := cache.Get("a"); err == nil {
+		t.Error("Expected Get to fail after Delete")
+	}
+
+	// A write elsewhere is seen once reported
+	cache.Get("b")
+	backing.Update("b", &models.Character{CharacterName: "Elsewhere", Race: "Elf", Class: "Wizard", Level: 1})
+	if character, _ := cache.Get("b"); character.CharacterName != "Character b" {
+		t.Fatalf("Expected the cached character before invalidation, got %q", character.CharacterName)
+	}
+	cache.Invalidate("b")
+	if character, _ := cache.Get("b"); character.CharacterName != "Elsewhere" {
+		t.Errorf("Expected the write elsewhere after invalidation, got %q", character.CharacterName)
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 5 || stats.Misses != 5 || stats.Entries != 1 {
+		t.Errorf("Expected 5 hits, 5 misses and 1 entry, got %+v", stats)
+	}
+}
+
+func TestCachedStoreExpiryAndEviction(t *testing.T) {
+	cache, backing := newCountingCache(t, CacheConfig{Size: 2, TTL: 20 * time.Millisecond, ListSize: 1, ListTTL: time.Minute})
+
+	cache.Get("a")
+	cache.Get("b")
+	cache.Get("a")
+	cache.Get("c") // evicts b, the least recently used
+	if stats := cache.Stats(); stats.Evictions != 1 || stats.Entries != 2 {
+		t.Errorf("Expected one eviction and two entries, got %+v", stats)
+	}
+	backing.gets = 0
+	cache.Get("a")
+	cache.Get("b")
+	if backing.gets != 1 {
+		t.Errorf("Expected only the evicted character to be read, got %d reads", backing.gets)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	backing.gets = 0
+	cache.Get("a")
+	if backing.gets != 1 {
+		t.Errorf("Expected an expired character to be read again, got %d reads", backing.gets)
+	}
+}
+
+func TestCachedStoreList(t *testing.T) {
+	cache, backing := newCountingCache(t, DefaultCacheConfig())
+	level, err := filter.Parse("level>=1")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	opts := ListOptions{Page: 1, Limit: 10, SortBy: "characterName", SortOrder: "asc", Filter: level}
+
+	for i := 0; i < 2; i++ {
+		if characters, total, err := cache.List(opts); err != nil || len(characters) != 3 || total != 3 {
+			t.Fatalf("List failed: %d of %d, %v", len(characters), total, err)
+		}
+	}
+	if backing.lists != 1 {
+		t.Errorf("Expected one listing of the store, got %d", backing.lists)
+	}
+
+	// Different options are listed separately
+	high, _ := filter.Parse("level>=2")
+	for _, other := range []ListOptions{
+		{Page: 2, Limit: 10, SortBy: "characterName", SortOrder: "asc", Filter: level},
+		{Page: 1, Limit: 10, SortBy: "characterName", SortOrder: "desc", Filter: level},
+		{Page: 1, Limit: 10, SortBy: "characterName", SortOrder: "asc", Filter: high},
+		{Page: 1, Limit: 10, SortBy: "characterName", SortOrder: "asc", Filter: level, OwnerID: "user-1"},
+	} {
+		backing.lists = 0
+		cache.List(other)
+		if backing.lists != 1 {
+			t.Errorf("Expected %+v to be listed from the store", other)
+		}
+	}
+
+	// Any write invalidates every listing
+	if err := cache.Create(&models.Character{CharacterName: "New", Race: "Elf", Class: "Wizard", Level: 1}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if characters, total, _ := cache.List(opts); len(characters) != 4 || total != 4 {
+		t.Errorf("Expected the new character to be listed, got %d of %d", len(characters), total)
+	}
+
+	if stats := cache.Stats(); stats.ListHits != 1 || stats.ListMisses != 6 {
+		t.Errorf("Expected 1 list hit and 6 misses, got %+v", stats)
+	}
+}
+
+func TestCachedStorePublishWrites(t *testing.T) {
+	cache, backing := newCountingCache(t, DefaultCacheConfig())
+	var published [][]string
+	cache.PublishWrites(func(ids []string) { published = append(published, ids) })
+
+	if err := cache.Create(&models.Character{ID: "d", CharacterName: "Character d", Race: "Elf", Class: "Wizard", Level: 1}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := cache.Restore(&models.Character{ID: "e", CharacterName: "Character e", Race: "Elf", Class: "Wizard", Level: 1}); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if err := cache.Bulk([]BulkWrite{{Op: BulkDelete, ID: "a"}, {Op: BulkDelete, ID: "b"}}); err != nil {
+		t.Fatalf("Bulk failed: %v", err)
+	}
+	want := [][]string{nil, {"e"}, {"a", "b"}}
+	if !reflect.DeepEqual(published, want) {
+		t.Errorf("Expected writes %v to be published, got %v", want, published)
+	}
+
+	// Another replica's writes are applied without being published again
+	cache.Get("c")
+	cache.Invalidate("c")
+	cache.Get("c")
+	if len(published) != 3 || backing.gets != 2 {
+		t.Errorf("Expected an unpublished invalidation and a fresh read, got %d publications and %d reads", len(published), backing.gets)
+	}
+
+	cache.List(ListOptions{Page: 1, Limit: 10})
+	cache.InvalidateAll()
+	if stats := cache.Stats(); stats.Entries != 0 || stats.ListEntries != 0 {
+		t.Errorf("Expected InvalidateAll to empty the cache, got %+v", stats)
+	}
+}
+
+func TestListKey(t *testing.T) {
+	and, _ := filter.Parse("level>=1 AND class=Wizard")
+	or, _ := filter.Parse("level>=1 OR class=Wizard")
+	not, _ := filter.Parse("NOT level>=1")
+
+	keys := map[string]bool{}
+	for _, opts := range []ListOptions{
+		{},
+		{Filter: and},
+		{Filter: or},
+		{Filter: not},
+		{Fields: []string{"id"}},
+		{CampaignIDs: []string{"a", "b"}},
+		{CampaignIDs: []string{"a,b"}},
+		{Visibility: &Visibility{UserID: "user-1"}},
+		{Visibility: &Visibility{UserID: "user-1", CampaignIDs: []string{"a"}}},
+		{After: &Cursor{SortBy: "level", Value: 1, ID: "a"}},
+		{SkipCount: true},
+		{Lookahead: true},
+		{Search: "elf"},
+	} {
+		key := listKey(opts)
+		if keys[key] {
+			t.Errorf("Expected %+v to have a key of its own, got %q again", opts, key)
+		}
+		keys[key] = true
+	}
+
+	again, _ := filter.Parse("level>=1 AND class=Wizard")
+	if listKey(ListOptions{Filter: and}) != listKey(ListOptions{Filter: again}) {
+		t.Error("Expected equal filters to share a key")
+	}
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"player-character/internal/models"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltCampaignStore implements campaign storage in a bbolt database file,
+// keeping campaigns in memory as MemoryCampaignStore does and writing every
+// change through to the file
+type BoltCampaignStore struct {
+	*MemoryCampaignStore
+	table *boltTable[models.Campaign]
+}
+
+// NewBoltCampaignStore creates a campaign store in db, loading the campaigns it already holds
+func NewBoltCampaignStore(db *bolt.DB) (*BoltCampaignStore, error) {
+	memory := NewMemoryCampaignStore()
+	get, set := mapAccess(&memory.mutex, memory.campaigns)
+	table, err := newBoltTable(db, "campaigns", get, set)
+	if err != nil {
+		return nil, err
+	}
+	return &BoltCampaignStore{MemoryCampaignStore: memory, table: table}, nil
+}
+
+// Create adds a new campaign
+func (s *BoltCampaignStore) Create(campaign *models.Campaign) error {
+	assignID(&campaign.ID)
+	return s.table.write([]string{campaign.ID}, func() error {
+		return s.MemoryCampaignStore.Create(campaign)
+	})
+}
+
+// Update modifies an existing campaign
+func (s *BoltCampaignStore) Update(id string, campaign *models.Campaign) error {
+	return s.table.write([]string{id}, func() error {
+		return s.MemoryCampaignStore.Update(id, campaign)
+	})
+}
+
+// Delete removes a campaign
+func (s *BoltCampaignStore) Delete(id string) error {
+	return s.table.write([]string{id}, func() error {
+		return s.MemoryCampaignStore.Delete(id)
+	})
+}
+
+// Restore stores a campaign as it is, keeping its ID and timestamps
+func (s *BoltCampaignStore) Restore(campaign *models.Campaign) error {
+	return s.table.write([]string{campaign.ID}, func() error {
+		return s.MemoryCampaignStore.Restore(campaign)
+	})
+}
@@ -0,0 +1,218 @@
+package database
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryCampaignStore implements an in-memory campaign storage
+type MemoryCampaignStore struct {
+	campaigns map[string]models.Campaign
+	mutex     sync.RWMutex
+}
+
+// NewMemoryCampaignStore creates a new in-memory campaign store
+func NewMemoryCampaignStore() *MemoryCampaignStore {
+	return &MemoryCampaignStore{
+		campaigns: make(map[string]models.Campaign),
+	}
+}
+
+// Create stores a new campaign
+func (s *MemoryCampaignStore) Create(campaign *models.Campaign) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Generate ID if not provided
+	if campaign.ID == "" {
+		campaign.ID = uuid.New().String()
+	}
+
+	// Check if ID already exists
+	if _, exists := s.campaigns[campaign.ID]; exists {
+		return errors.New("campaign with this ID already exists")
+	}
+
+	assignPartyIDs(campaign)
+
+	// Set timestamps
+	now := time.Now()
+	campaign.CreatedAt = now
+	campaign.UpdatedAt = now
+
+	s.campaigns[campaign.ID] = *campaign
+	return nil
+}
+
+// Get retrieves a campaign by ID
+func (s *MemoryCampaignStore) Get(id string) (*models.Campaign, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	campaign, exists := s.campaigns[id]
+	if !exists {
+		return nil, errors.New("campaign not found")
+	}
+
+	return &campaign, nil
+}
+
+// GetMany retrieves the campaigns with the given IDs in one call; missing IDs are skipped
+func (s *MemoryCampaignStore) GetMany(ids []string) ([]models.Campaign, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	campaigns := []models.Campaign{}
+	for _, id := range ids {
+		if campaign, exists := s.campaigns[id]; exists {
+			campaigns = append(campaigns, campaign)
+		}
+	}
+
+	return campaigns, nil
+}
+
+// List retrieves campaigns ordered by creation time, newest first
+func (s *MemoryCampaignStore) List(page, limit int) ([]models.Campaign, int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	allCampaigns := make([]models.Campaign, 0, len(s.campaigns))
+	for _, campaign := range s.campaigns {
+		allCampaigns = append(allCampaigns, campaign)
+	}
+
+	sort.Slice(allCampaigns, func(i, j int) bool {
+		return allCampaigns[i].CreatedAt.After(allCampaigns[j].CreatedAt)
+	})
+
+	total := len(allCampaigns)
+
+	// Calculate pagination
+	start := (page - 1) * limit
+	if start >= total {
+		return []models.Campaign{}, total, nil
+	}
+
+	end := start + limit
+	if end > total {
+		end = total
+	}
+
+	return allCampaigns[start:end], total, nil
+}
+
+// ListByMember retrieves every campaign the user runs, plays in or views
+func (s *MemoryCampaignStore) ListByMember(userID string) ([]models.Campaign, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	campaigns := []models.Campaign{}
+	for _, campaign := range s.campaigns {
+		if campaign.IsMember(userID) || campaign.IsViewer(userID) {
+			campaigns = append(campaigns, campaign)
+		}
+	}
+
+	sort.Slice(campaigns, func(i, j int) bool {
+		return campaigns[i].CreatedAt.After(campaigns[j].CreatedAt)
+	})
+
+	return campaigns, nil
+}
+
+// All retrieves every campaign, oldest first
+func (s *MemoryCampaignStore) All() ([]models.Campaign, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	campaigns := make([]models.Campaign, 0, len(s.campaigns))
+	for _, campaign := range s.campaigns {
+		campaigns = append(campaigns, campaign)
+	}
+	sort.Slice(campaigns, func(i, j int) bool {
+		if !campaigns[i].CreatedAt.Equal(campaigns[j].CreatedAt) {
+			return campaigns[i].CreatedAt.Before(campaigns[j].CreatedAt)
+		}
+		return campaigns[i].ID < campaigns[j].ID
+	})
+
+	return campaigns, nil
+}
+
+// Restore stores a campaign as it is, keeping its ID and timestamps
+func (s *MemoryCampaignStore) Restore(campaign *models.Campaign) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.campaigns[campaign.ID]; exists {
+		return ErrExists
+	}
+
+	s.campaigns[campaign.ID] = *campaign
+	return nil
+}
+
+// Update modifies an existing campaign
+func (s *MemoryCampaignStore) Update(id string, campaign *models.Campaign) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, exists := s.campaigns[id]
+	if !exists {
+		return errors.New("campaign not found")
+	}
+
+	assignPartyIDs(campaign)
+
+	// Preserve original ID and creation time
+	campaign.ID = id
+	campaign.CreatedAt = existing.CreatedAt
+	campaign.UpdatedAt = time.Now()
+
+	s.campaigns[id] = *campaign
+	return nil
+}
+
+// Delete removes a campaign
+func (s *MemoryCampaignStore) Delete(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.campaigns[id]; !exists {
+		return errors.New("campaign not found")
+	}
+
+	delete(s.campaigns, id)
+	return nil
+}
+
+// assignPartyIDs generates IDs for parties that were submitted without one
+func assignPartyIDs(campaign *models.Campaign) {
+	for i := range campaign.Parties {
+		if campaign.Parties[i].ID == "" {
+			campaign.Parties[i].ID = uuid.New().String()
+		}
+	}
+}
+
+// CampaignStore interface defines the contract for campaign storage
+type CampaignStore interface {
+	Create(campaign *models.Campaign) error
+	Get(id string) (*models.Campaign, error)
+	GetMany(ids []string) ([]models.Campaign, error)
+	List(page, limit int) ([]models.Campaign, int, error)
+	ListByMember(userID string) ([]models.Campaign, error)
+	Update(id string, campaign *models.Campaign) error
+	Delete(id string) error
+	// All retrieves every campaign, oldest first, for backups
+	All() ([]models.Campaign, error)
+	// Restore stores a campaign from a backup as it is, returning ErrExists if its ID is taken
+	Restore(campaign *models.Campaign) error
+}
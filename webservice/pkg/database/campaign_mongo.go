@@ -0,0 +1,209 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoCampaignStore implements MongoDB-based campaign storage
+type MongoCampaignStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoCampaignStore creates a campaign store backed by the given database
+func NewMongoCampaignStore(database *mongo.Database, collectionName string) *MongoCampaignStore {
+	return &MongoCampaignStore{
+		collection: database.Collection(collectionName),
+	}
+}
+
+// Create stores a new campaign
+func (s *MongoCampaignStore) Create(campaign *models.Campaign) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Generate ID if not provided
+	if campaign.ID == "" {
+		campaign.ID = uuid.New().String()
+	}
+
+	assignPartyIDs(campaign)
+
+	// Set timestamps
+	now := time.Now()
+	campaign.CreatedAt = now
+	campaign.UpdatedAt = now
+
+	_, err := s.collection.InsertOne(ctx, campaign)
+	return err
+}
+
+// Get retrieves a campaign by ID
+func (s *MongoCampaignStore) Get(id string) (*models.Campaign, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var campaign models.Campaign
+	err := s.collection.FindOne(ctx, bson.M{"id": id}).Decode(&campaign)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("campaign not found")
+		}
+		return nil, err
+	}
+
+	return &campaign, nil
+}
+
+// GetMany retrieves the campaigns with the given IDs in one query; missing IDs are skipped
+func (s *MongoCampaignStore) GetMany(ids []string) ([]models.Campaign, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := s.collection.Find(ctx, bson.M{"id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	campaigns := []models.Campaign{}
+	if err = cursor.All(ctx, &campaigns); err != nil {
+		return nil, err
+	}
+
+	return campaigns, nil
+}
+
+// List retrieves campaigns ordered by creation time, newest first
+func (s *MongoCampaignStore) List(page, limit int) ([]models.Campaign, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	total, err := s.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * limit)).
+		SetLimit(int64(limit)).
+		SetSort(bson.M{"createdAt": -1})
+
+	cursor, err := s.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var campaigns []models.Campaign
+	if err = cursor.All(ctx, &campaigns); err != nil {
+		return nil, 0, err
+	}
+
+	// Ensure we return an empty slice instead of nil when no results
+	if campaigns == nil {
+		campaigns = []models.Campaign{}
+	}
+
+	return campaigns, int(total), nil
+}
+
+// ListByMember retrieves every campaign the user runs, plays in or views
+func (s *MongoCampaignStore) ListByMember(userID string) ([]models.Campaign, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"$or": []bson.M{
+		{"dmId": userID},
+		{"players": userID},
+		{"viewers": userID},
+	}}
+
+	cursor, err := s.collection.Find(ctx, filter, options.Find().SetSort(bson.M{"createdAt": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	campaigns := []models.Campaign{}
+	if err = cursor.All(ctx, &campaigns); err != nil {
+		return nil, err
+	}
+
+	return campaigns, nil
+}
+
+// All retrieves every campaign, oldest first
+func (s *MongoCampaignStore) All() ([]models.Campaign, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	campaigns := []models.Campaign{}
+	if err := findAll(ctx, s.collection, &campaigns); err != nil {
+		return nil, err
+	}
+
+	return campaigns, nil
+}
+
+// Restore stores a campaign as it is, keeping its ID and timestamps
+func (s *MongoCampaignStore) Restore(campaign *models.Campaign) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return insertIfAbsent(ctx, s.collection, bson.M{"id": campaign.ID}, campaign)
+}
+
+// Update modifies an existing campaign
+func (s *MongoCampaignStore) Update(id string, campaign *models.Campaign) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	existing, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+
+	assignPartyIDs(campaign)
+
+	// Preserve original ID and creation time, update timestamp
+	campaign.ID = id
+	campaign.CreatedAt = existing.CreatedAt
+	campaign.UpdatedAt = time.Now()
+
+	result, err := s.collection.ReplaceOne(ctx, bson.M{"id": id}, campaign)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("campaign not found")
+	}
+
+	return nil
+}
+
+// Delete removes a campaign
+func (s *MongoCampaignStore) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := s.collection.DeleteOne(ctx, bson.M{"id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("campaign not found")
+	}
+
+	return nil
+}
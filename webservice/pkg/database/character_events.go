@@ -0,0 +1,169 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"player-character/internal/models"
+)
+
+// Domain event types recorded by EventSourcedStore. Updates are recorded as
+// the specific events they amount to, with CharacterEdited for whatever
+// changes the others do not describe.
+const (
+	CharacterCreated = "CharacterCreated"
+	CharacterEdited  = "CharacterEdited"
+	CharacterDeleted = "CharacterDeleted"
+	LevelGained      = "LevelGained"
+	LevelLost        = "LevelLost"
+	ExperienceGained = "ExperienceGained"
+	FeatureAdded     = "FeatureAdded"
+	FeatureRemoved   = "FeatureRemoved"
+)
+
+// CharacterEvent is one change to a character. Each character's events are
+// numbered from 1 in the order they happened; applying them in turn gives
+// the character as it stands after the last.
+type CharacterEvent struct {
+	CharacterID string    `json:"characterId" bson:"characterId"`
+	Version     int       `json:"version" bson:"version"`
+	Type        string    `json:"type" bson:"type"`
+	Timestamp   time.Time `json:"timestamp" bson:"timestamp"`
+
+	Character  *models.Character `json:"character,omitempty" bson:"character,omitempty"`   // CharacterCreated: the new character
+	Level      int               `json:"level,omitempty" bson:"level,omitempty"`           // LevelGained, LevelLost: the new level
+	Experience int               `json:"experience,omitempty" bson:"experience,omitempty"` // ExperienceGained: the points gained
+	Feature    string            `json:"feature,omitempty" bson:"feature,omitempty"`       // FeatureAdded, FeatureRemoved
+	// CharacterEdited: the changed fields by JSON name, and their new values in Changes
+	Fields  []string          `json:"fields,omitempty" bson:"fields,omitempty"`
+	Changes *models.Character `json:"changes,omitempty" bson:"changes,omitempty"`
+}
+
+// applyEvent returns the character after event, given the character before
+// it, nil if it did not exist. The character passed in is not changed.
+func applyEvent(character *models.Character, event CharacterEvent) (*models.Character, error) {
+	switch event.Type {
+	case CharacterCreated:
+		if event.Character == nil {
+			return nil, fmt.Errorf("event %d of character %s creates nothing", event.Version, event.CharacterID)
+		}
+		created := *event.Character
+		return &created, nil
+	case CharacterDeleted:
+		return nil, nil
+	}
+
+	if character == nil {
+		return nil, fmt.Errorf("event %d of character %s changes a character that does not exist", event.Version, event.CharacterID)
+	}
+	next := *character
+	switch event.Type {
+	case LevelGained, LevelLost:
+		next.Level = event.Level
+	case ExperienceGained:
+		next.ExperiencePoints += event.Experience
+	case FeatureAdded:
+		next.Features = append(slices.Clip(next.Features), event.Feature)
+	case FeatureRemoved:
+		if i := slices.Index(next.Features, event.Feature); i >= 0 {
+			next.Features = slices.Delete(slices.Clone(next.Features), i, i+1)
+			if len(next.Features) == 0 {
+				next.Features = nil
+			}
+		}
+	case CharacterEdited:
+		if event.Changes == nil {
+			event.Changes = &models.Character{}
+		}
+		to, from := reflect.ValueOf(&next).Elem(), reflect.ValueOf(event.Changes).Elem()
+		for _, name := range event.Fields {
+			field, ok := characterFields[name]
+			if !ok {
+				return nil, fmt.Errorf("event %d of character %s edits unknown field %q", event.Version, event.CharacterID, name)
+			}
+			to.Field(field.index).Set(from.Field(field.index))
+		}
+	default:
+		return nil, fmt.Errorf("event %d of character %s has unknown type %q", event.Version, event.CharacterID, event.Type)
+	}
+	next.UpdatedAt = event.Timestamp
+	return &next, nil
+}
+
+// characterEvents returns the events taking a character from before to
+// after, either of which is nil when the character does not exist, numbered
+// from version+1. Creations are recorded at now, the rest when the
+// character says it was updated.
+func characterEvents(id string, version int, before, after *models.Character, now time.Time) []CharacterEvent {
+	var events []CharacterEvent
+	record := func(event CharacterEvent) {
+		version++
+		event.CharacterID = id
+		event.Version = version
+		events = append(events, event)
+	}
+
+	switch {
+	case after == nil:
+		if before != nil {
+			record(CharacterEvent{Type: CharacterDeleted, Timestamp: now})
+		}
+		return events
+	case before == nil:
+		created := *after
+		record(CharacterEvent{Type: CharacterCreated, Timestamp: now, Character: &created})
+		return events
+	}
+
+	// Record the changes with events of their own first, then edit whatever
+	// still differs, so that replaying the events always gives after
+	at := after.UpdatedAt
+	state := before
+	apply := func(event CharacterEvent) {
+		event.Timestamp = at
+		next, _ := applyEvent(state, event)
+		state = next
+		record(event)
+	}
+
+	if after.Level > state.Level {
+		apply(CharacterEvent{Type: LevelGained, Level: after.Level})
+	} else if after.Level < state.Level {
+		apply(CharacterEvent{Type: LevelLost, Level: after.Level})
+	}
+	if gained := after.ExperiencePoints - state.ExperiencePoints; gained > 0 {
+		apply(CharacterEvent{Type: ExperienceGained, Experience: gained})
+	}
+	for _, feature := range before.Features {
+		if !slices.Contains(after.Features, feature) && slices.Contains(state.Features, feature) {
+			apply(CharacterEvent{Type: FeatureRemoved, Feature: feature})
+		}
+	}
+	for _, feature := range after.Features {
+		if !slices.Contains(state.Features, feature) {
+			apply(CharacterEvent{Type: FeatureAdded, Feature: feature})
+		}
+	}
+
+	edit := CharacterEvent{Type: CharacterEdited, Changes: &models.Character{}}
+	from, to, changes := reflect.ValueOf(state).Elem(), reflect.ValueOf(after).Elem(), reflect.ValueOf(edit.Changes).Elem()
+	for name, field := range characterFields {
+		i := field.index
+		if name == "updatedAt" || reflect.DeepEqual(from.Field(i).Interface(), to.Field(i).Interface()) {
+			continue
+		}
+		edit.Fields = append(edit.Fields, name)
+		changes.Field(i).Set(to.Field(i))
+	}
+	// An update changing nothing is still recorded, as it was still made
+	if len(edit.Fields) == 0 {
+		edit.Changes = nil
+	}
+	if len(edit.Fields) > 0 || len(events) == 0 {
+		slices.Sort(edit.Fields)
+		apply(edit)
+	}
+	return events
+}
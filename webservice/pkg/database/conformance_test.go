@@ -0,0 +1,47 @@
+package database_test
+
+import (
+	"testing"
+
+	"player-character/pkg/database"
+	"player-character/pkg/database/storetest"
+)
+
+func TestMemoryStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) database.CharacterStore {
+		return database.NewMemoryStore()
+	})
+}
+
+// TestMongoStore runs against MongoDB when MONGODB_TEST_URI is set
+func TestMongoStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) database.CharacterStore {
+		return database.NewTestMongoStore(t)
+	})
+}
+
+func TestBoltStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) database.CharacterStore {
+		return database.NewTestBoltStore(t)
+	})
+}
+
+// TestPostgresStore runs against PostgreSQL when POSTGRES_TEST_DSN is set
+func TestPostgresStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) database.CharacterStore {
+		return database.NewTestPostgresStore(t)
+	})
+}
+
+// TestCachedStore checks that caching does not change what a store returns
+func TestCachedStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) database.CharacterStore {
+		return database.NewCachedStore(database.NewMemoryStore(), database.DefaultCacheConfig())
+	})
+}
+
+func TestEventSourcedStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) database.CharacterStore {
+		return database.NewTestEventSourcedStore(t)
+	})
+}
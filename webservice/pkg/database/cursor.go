@@ -0,0 +1,123 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"player-character/internal/models"
+)
+
+// ErrInvalidCursor is returned by DecodeCursor for malformed tokens and for
+// tokens issued under a different sort
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks the last character of a page for keyset pagination. The next
+// page holds the characters sorting after it by (sort field, ID).
+type Cursor struct {
+	SortBy    string
+	SortOrder string
+	Value     interface{} // the sort field's value: string, int or time.Time
+	ID        string
+}
+
+// cursorToken is the encoded form of a Cursor
+type cursorToken struct {
+	SortBy    string          `json:"s"`
+	SortOrder string          `json:"o"`
+	Value     json.RawMessage `json:"v"`
+	ID        string          `json:"id"`
+}
+
+// NewCursor returns the cursor positioned at a character for the listing's sort
+func NewCursor(opts ListOptions, character *models.Character) *Cursor {
+	return &Cursor{
+		SortBy:    opts.SortBy,
+		SortOrder: opts.SortOrder,
+		Value:     sortValue(character, opts.SortBy),
+		ID:        character.ID,
+	}
+}
+
+// Encode returns the cursor as an opaque URL-safe token
+func (c *Cursor) Encode() string {
+	value, _ := json.Marshal(c.Value)
+	data, _ := json.Marshal(cursorToken{SortBy: c.SortBy, SortOrder: c.SortOrder, Value: value, ID: c.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token from Encode, checking it was issued for the same sort
+func DecodeCursor(token string, sortBy, sortOrder string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var t cursorToken
+	if err := json.Unmarshal(data, &t); err != nil || t.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	if t.SortBy != sortBy || t.SortOrder != sortOrder {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := &Cursor{SortBy: t.SortBy, SortOrder: t.SortOrder, ID: t.ID}
+	switch sortValue(&models.Character{}, sortBy).(type) {
+	case int:
+		var n int
+		err = json.Unmarshal(t.Value, &n)
+		cursor.Value = n
+	case time.Time:
+		var at time.Time
+		err = json.Unmarshal(t.Value, &at)
+		cursor.Value = at
+	default:
+		var s string
+		err = json.Unmarshal(t.Value, &s)
+		cursor.Value = s
+	}
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// sortValue returns the value a character is sorted by. Unknown fields sort by createdAt.
+func sortValue(character *models.Character, sortBy string) interface{} {
+	switch sortBy {
+	case "characterName":
+		return character.CharacterName
+	case "level":
+		return character.Level
+	case "race":
+		return character.Race
+	case "class":
+		return character.Class
+	default:
+		return character.CreatedAt
+	}
+}
+
+// compareSortValues orders two sort values the way every store sorts: text
+// byte by byte, as MongoDB and PostgreSQL's "C" collation compare it, numbers
+// and times naturally
+func compareSortValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case int:
+		b := b.(int)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	}
+	return 0
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"player-character/internal/models"
+)
+
+func TestDecodeCursorRejects(t *testing.T) {
+	token := NewCursor(ListOptions{SortBy: "level", SortOrder: "asc"}, &models.Character{ID: "a", Level: 3}).Encode()
+
+	cursor, err := DecodeCursor(token, "level", "asc")
+	if err != nil || cursor.Value != 3 || cursor.ID != "a" {
+		t.Fatalf("Expected level 3 after a, got %+v, %v", cursor, err)
+	}
+
+	for _, tt := range []struct{ token, sortBy, sortOrder string }{
+		{token, "level", "desc"},
+		{token, "characterName", "asc"},
+		{"not base64!", "level", "asc"},
+		{"e30", "level", "asc"}, // {}
+	} {
+		if _, err := DecodeCursor(tt.token, tt.sortBy, tt.sortOrder); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q, %s, %s) = %v, expected ErrInvalidCursor", tt.token, tt.sortBy, tt.sortOrder, err)
+		}
+	}
+}
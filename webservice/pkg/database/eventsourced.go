@@ -0,0 +1,307 @@
+package database
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"player-character/internal/models"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DefaultSnapshotInterval is how many events of a character are recorded
+// between snapshots unless configured otherwise
+const DefaultSnapshotInterval = 100
+
+var (
+	// characterEventsBucket holds a bucket of events per character ID, keyed by version
+	characterEventsBucket = []byte("character_events")
+	// characterSnapshotsBucket holds a bucket of snapshots per character ID, keyed by version
+	characterSnapshotsBucket = []byte("character_snapshots")
+)
+
+// characterSnapshot is a character as it stood after the event with Version,
+// recorded at Timestamp, so that replays can start from it
+type characterSnapshot struct {
+	Version   int              `bson:"version"`
+	Timestamp time.Time        `bson:"timestamp"`
+	Character models.Character `bson:"character"`
+}
+
+// EventSourcedStore keeps every change to a character as an event in a bbolt
+// database file, for deployments that must be able to tell how a character
+// came to be. The current characters are a projection of the events, kept in
+// memory, so listing, sorting and search behave exactly as for MemoryStore.
+// Every few events the character is snapshotted, so that loading the store
+// and replaying a character to a point in time need not apply every event
+// since its creation.
+type EventSourcedStore struct {
+	*MemoryStore
+	db               *bolt.DB
+	snapshotInterval int
+	mutex            sync.Mutex     // serializes writes, so events are numbered in the order they are made
+	versions         map[string]int // each character's last event
+}
+
+// NewEventSourcedStore creates an event-sourced character store in db,
+// rebuilding the characters from the events it already holds. A character
+// is snapshotted every snapshotInterval events.
+func NewEventSourcedStore(db *bolt.DB, snapshotInterval int) (*EventSourcedStore, error) {
+	s := &EventSourcedStore{
+		MemoryStore:      NewMemoryStore(),
+		db:               db,
+		snapshotInterval: snapshotInterval,
+		versions:         map[string]int{},
+	}
+
+	characters := map[string]*models.Character{}
+	err := db.Update(func(tx *bolt.Tx) error {
+		events, err := tx.CreateBucketIfNotExists(characterEventsBucket)
+		if err != nil {
+			return err
+		}
+		snapshots, err := tx.CreateBucketIfNotExists(characterSnapshotsBucket)
+		if err != nil {
+			return err
+		}
+		return events.ForEachBucket(func(id []byte) error {
+			character, version, err := replayCharacter(events.Bucket(id), snapshots.Bucket(id), time.Time{})
+			if err != nil {
+				return err
+			}
+			s.versions[string(id)] = version
+			if character != nil {
+				characters[string(id)] = character
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.MemoryStore.mutex.Lock()
+	s.MemoryStore.restore(characters)
+	s.MemoryStore.mutex.Unlock()
+	return s, nil
+}
+
+// Create adds a new character
+func (s *EventSourcedStore) Create(character *models.Character) error {
+	assignID(&character.ID)
+	return s.write([]string{character.ID}, func() error {
+		return s.MemoryStore.Create(character)
+	})
+}
+
+// Update modifies an existing character
+func (s *EventSourcedStore) Update(id string, character *models.Character) error {
+	return s.write([]string{id}, func() error {
+		return s.MemoryStore.Update(id, character)
+	})
+}
+
+// Delete removes a character. Its events are kept.
+func (s *EventSourcedStore) Delete(id string) error {
+	return s.write([]string{id}, func() error {
+		return s.MemoryStore.Delete(id)
+	})
+}
+
+// Restore stores a character as it is, keeping its ID and timestamps
+func (s *EventSourcedStore) Restore(character *models.Character) error {
+	return s.write([]string{character.ID}, func() error {
+		return s.MemoryStore.Restore(character)
+	})
+}
+
+// Bulk applies a batch of writes atomically, recording their events in one transaction
+func (s *EventSourcedStore) Bulk(writes []BulkWrite) error {
+	ids := make([]string, 0, len(writes))
+	for _, w := range writes {
+		if w.Op == BulkCreate {
+			assignID(&w.Character.ID)
+			ids = append(ids, w.Character.ID)
+			continue
+		}
+		ids = append(ids, w.ID)
+	}
+	return s.write(ids, func() error {
+		return s.MemoryStore.Bulk(writes)
+	})
+}
+
+// History returns every event of a character, oldest first, including those
+// of a character since deleted
+func (s *EventSourcedStore) History(id string) ([]CharacterEvent, error) {
+	var events []CharacterEvent
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(characterEventsBucket).Bucket([]byte(id))
+		if b == nil {
+			return errors.New("character not found")
+		}
+		return b.ForEach(func(_, data []byte) error {
+			var event CharacterEvent
+			if err := bson.Unmarshal(data, &event); err != nil {
+				return err
+			}
+			events = append(events, event)
+			return nil
+		})
+	})
+	return events, err
+}
+
+// CharacterAt returns a character as it stood at a point in time, replaying
+// its events up to then from the latest snapshot before it
+func (s *EventSourcedStore) CharacterAt(id string, at time.Time) (*models.Character, error) {
+	var character *models.Character
+	err := s.db.View(func(tx *bolt.Tx) error {
+		events := tx.Bucket(characterEventsBucket).Bucket([]byte(id))
+		if events == nil {
+			return nil
+		}
+		var err error
+		character, _, err = replayCharacter(events, tx.Bucket(characterSnapshotsBucket).Bucket([]byte(id)), at)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if character == nil {
+		return nil, errors.New("character not found")
+	}
+	return character, nil
+}
+
+// write applies change to the projection, then records the events taking
+// the characters with the given IDs from how they were to how they now are.
+// When recording fails, those characters are put back as they were.
+func (s *EventSourcedStore) write(ids []string, change func() error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	before := make(map[string]*models.Character, len(ids))
+	for _, id := range ids {
+		before[id] = s.current(id)
+	}
+
+	if err := change(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var events []CharacterEvent
+	var snapshots []characterSnapshot
+	for id, previous := range before {
+		after := s.current(id)
+		changes := characterEvents(id, s.versions[id], previous, after, now)
+		if len(changes) == 0 {
+			continue
+		}
+		events = append(events, changes...)
+
+		first, last := changes[0], changes[len(changes)-1]
+		if after != nil && s.snapshotInterval > 0 && last.Version/s.snapshotInterval > (first.Version-1)/s.snapshotInterval {
+			snapshots = append(snapshots, characterSnapshot{Version: last.Version, Timestamp: last.Timestamp, Character: *after})
+		}
+	}
+
+	if err := s.record(events, snapshots); err != nil {
+		s.MemoryStore.mutex.Lock()
+		s.MemoryStore.restore(before)
+		s.MemoryStore.mutex.Unlock()
+		return err
+	}
+	for _, event := range events {
+		s.versions[event.CharacterID] = event.Version
+	}
+	return nil
+}
+
+// current returns the projection's copy of a character, nil if it has none
+func (s *EventSourcedStore) current(id string) *models.Character {
+	character, err := s.MemoryStore.Get(id)
+	if err != nil {
+		return nil
+	}
+	return character
+}
+
+// record writes events and snapshots in one transaction
+func (s *EventSourcedStore) record(events []CharacterEvent, snapshots []characterSnapshot) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, event := range events {
+			if err := putVersion(tx.Bucket(characterEventsBucket), event.CharacterID, event.Version, event); err != nil {
+				return err
+			}
+		}
+		for _, snapshot := range snapshots {
+			if err := putVersion(tx.Bucket(characterSnapshotsBucket), snapshot.Character.ID, snapshot.Version, snapshot); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// putVersion stores a record under its version in the character's bucket within parent
+func putVersion(parent *bolt.Bucket, id string, version int, record interface{}) error {
+	b, err := parent.CreateBucketIfNotExists([]byte(id))
+	if err != nil {
+		return err
+	}
+	data, err := bson.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return b.Put(versionKey(version), data)
+}
+
+// versionKey encodes a version so that keys sort in version order
+func versionKey(version int) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(version))
+}
+
+// replayCharacter applies a character's events from its latest snapshot,
+// returning the character after the last event, nil if it does not exist
+// then, and that event's version. A non-zero until stops before the first
+// event, and ignores snapshots, recorded after it.
+func replayCharacter(events, snapshots *bolt.Bucket, until time.Time) (*models.Character, int, error) {
+	var character *models.Character
+	version := 0
+
+	if snapshots != nil {
+		c := snapshots.Cursor()
+		for key, data := c.Last(); key != nil; key, data = c.Prev() {
+			var snapshot characterSnapshot
+			if err := bson.Unmarshal(data, &snapshot); err != nil {
+				return nil, 0, err
+			}
+			if until.IsZero() || !snapshot.Timestamp.After(until) {
+				character, version = &snapshot.Character, snapshot.Version
+				break
+			}
+		}
+	}
+
+	c := events.Cursor()
+	for key, data := c.Seek(versionKey(version + 1)); key != nil; key, data = c.Next() {
+		var event CharacterEvent
+		if err := bson.Unmarshal(data, &event); err != nil {
+			return nil, 0, err
+		}
+		if !until.IsZero() && event.Timestamp.After(until) {
+			break
+		}
+		next, err := applyEvent(character, event)
+		if err != nil {
+			return nil, 0, err
+		}
+		character, version = next, event.Version
+	}
+	return character, version, nil
+}
@@ -0,0 +1,211 @@
+package database
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// newTestEventSourcedStore creates an event-sourced store in a fresh bbolt
+// file, snapshotting often so that snapshots are exercised too
+func newTestEventSourcedStore(t *testing.T) *EventSourcedStore {
+	t.Helper()
+	store, err := NewEventSourcedStore(openTestBolt(t, filepath.Join(t.TempDir(), "test.db")), 3)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	return store
+}
+
+func TestCharacterEvents(t *testing.T) {
+	base := models.Character{ID: "c1", CharacterName: "Lyra", Race: "Elf", Class: "Wizard", Level: 3,
+		ExperiencePoints: 900, Features: []string{"Darkvision", "Arcane Recovery"}}
+	updated := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	change := func(f func(c *models.Character)) *models.Character {
+		c := base
+		c.Features = append([]string(nil), base.Features...)
+		c.UpdatedAt = updated
+		f(&c)
+		return &c
+	}
+
+	tests := []struct {
+		name          string
+		before, after *models.Character
+		expected      []string
+	}{
+		{"created", nil, &base, []string{CharacterCreated}},
+		{"deleted", &base, nil, []string{CharacterDeleted}},
+		{"level gained", &base, change(func(c *models.Character) { c.Level = 5 }), []string{LevelGained}},
+		{"level lost", &base, change(func(c *models.Character) { c.Level = 2 }), []string{LevelLost}},
+		{"experience gained", &base, change(func(c *models.Character) { c.ExperiencePoints = 2700 }), []string{ExperienceGained}},
+		{"experience corrected", &base, change(func(c *models.Character) { c.ExperiencePoints = 100 }), []string{CharacterEdited}},
+		{"features", &base, change(func(c *models.Character) { c.Features = []string{"Arcane Recovery", "Fey Ancestry"} }),
+			[]string{FeatureRemoved, FeatureAdded}},
+		{"features reordered", &base, change(func(c *models.Character) { c.Features = []string{"Arcane Recovery", "Darkvision"} }),
+			[]string{CharacterEdited}},
+		{"features cleared", &base, change(func(c *models.Character) { c.Features = []string{} }),
+			[]string{FeatureRemoved, FeatureRemoved, CharacterEdited}},
+		{"several", &base, change(func(c *models.Character) {
+			c.Level, c.ExperiencePoints, c.Subclass = 4, 2700, "Evocation"
+			c.Features = append(c.Features, "Sculpt Spells")
+		}), []string{LevelGained, ExperienceGained, FeatureAdded, CharacterEdited}},
+		{"nothing", &base, change(func(c *models.Character) {}), []string{CharacterEdited}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := characterEvents("c1", 4, tt.before, tt.after, updated)
+
+			var types []string
+			for i, event := range events {
+				types = append(types, event.Type)
+				if event.Version != 5+i || event.CharacterID != "c1" {
+					t.Errorf("Expected event %d to be version %d of c1, got %d of %s", i, 5+i, event.Version, event.CharacterID)
+				}
+			}
+			if !reflect.DeepEqual(types, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, types)
+			}
+
+			// Replaying the events must give the character after the change
+			character := tt.before
+			for _, event := range events {
+				var err error
+				if character, err = applyEvent(character, event); err != nil {
+					t.Fatalf("applyEvent failed: %v", err)
+				}
+			}
+			if !reflect.DeepEqual(character, tt.after) {
+				t.Errorf("Expected replay to give\n%+v, got\n%+v", tt.after, character)
+			}
+		})
+	}
+}
+
+// TestEventSourcedStoreRebuilds tests that a store reopened on the same file
+// rebuilds the characters and continues their events
+func TestEventSourcedStoreRebuilds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	db, err := OpenBolt(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewEventSourcedStore(db, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kept := &models.Character{CharacterName: "Thorin", Race: "Dwarf", Class: "Fighter", Level: 1}
+	deleted := &models.Character{CharacterName: "Boromir", Race: "Human", Class: "Fighter", Level: 5}
+	for _, c := range []*models.Character{kept, deleted} {
+		if err := store.Create(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for level := 2; level <= 6; level++ {
+		update := *kept
+		update.Level = level
+		if err := store.Update(kept.ID, &update); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Delete(deleted.ID); err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := store.Get(kept.ID)
+	db.Close()
+
+	db = openTestBolt(t, path)
+	store, err = NewEventSourcedStore(db, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := store.Get(kept.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if stored.Level != 6 || !stored.UpdatedAt.Equal(expected.UpdatedAt.Truncate(time.Millisecond)) {
+		t.Errorf("Expected the character as last updated, got %+v", stored)
+	}
+	if _, err := store.Get(deleted.ID); err == nil {
+		t.Error("Expected the deleted character to stay deleted")
+	}
+
+	var snapshots int
+	db.View(func(tx *bolt.Tx) error {
+		snapshots = tx.Bucket(characterSnapshotsBucket).Bucket([]byte(kept.ID)).Stats().KeyN
+		return nil
+	})
+	if snapshots != 3 {
+		t.Errorf("Expected a snapshot every 2 of the 6 events, got %d", snapshots)
+	}
+
+	update := *stored
+	update.Level = 7
+	if err := store.Update(kept.ID, &update); err != nil {
+		t.Fatal(err)
+	}
+	history, err := store.History(kept.ID)
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(history) != 7 || history[6].Version != 7 || history[6].Type != LevelGained || history[6].Level != 7 {
+		t.Errorf("Expected the new event to follow the others, got %+v", history)
+	}
+	if history, err := store.History(deleted.ID); err != nil || len(history) != 2 || history[1].Type != CharacterDeleted {
+		t.Errorf("Expected the deleted character's history, got %+v, %v", history, err)
+	}
+}
+
+// TestEventSourcedStoreCharacterAt tests replaying characters to points in time
+func TestEventSourcedStoreCharacterAt(t *testing.T) {
+	store := newTestEventSourcedStore(t)
+
+	beforeCreation := time.Now()
+	time.Sleep(2 * time.Millisecond)
+	character := &models.Character{CharacterName: "Thorin", Race: "Dwarf", Class: "Fighter", Level: 1}
+	if err := store.Create(character); err != nil {
+		t.Fatal(err)
+	}
+
+	// Levels 2 to 8, past two snapshots, noting the time after each
+	at := map[int]time.Time{}
+	for level := 2; level <= 8; level++ {
+		time.Sleep(2 * time.Millisecond)
+		update := *character
+		update.Level = level
+		if err := store.Update(character.ID, &update); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond)
+		at[level] = time.Now()
+	}
+	time.Sleep(2 * time.Millisecond)
+	if err := store.Delete(character.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.CharacterAt(character.ID, beforeCreation); err == nil {
+		t.Error("Expected the character not to exist before it was created")
+	}
+	for _, level := range []int{2, 3, 5, 8} {
+		past, err := store.CharacterAt(character.ID, at[level])
+		if err != nil {
+			t.Fatalf("CharacterAt failed: %v", err)
+		}
+		if past.Level != level || past.CharacterName != "Thorin" {
+			t.Errorf("Expected level %d, got %+v", level, past)
+		}
+	}
+	if _, err := store.CharacterAt(character.ID, time.Now()); err == nil {
+		t.Error("Expected the character not to exist after it was deleted")
+	}
+	if _, err := store.CharacterAt("missing", time.Now()); err == nil {
+		t.Error("Expected an unknown character not to be found")
+	}
+}
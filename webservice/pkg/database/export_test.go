@@ -0,0 +1,10 @@
+package database
+
+// Constructors for the conformance tests in package database_test, which
+// cannot reach unexported helpers
+var (
+	NewTestMongoStore        = newTestMongoStore
+	NewTestBoltStore         = newTestBoltStore
+	NewTestPostgresStore     = newTestPostgresStore
+	NewTestEventSourcedStore = newTestEventSourcedStore
+)
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"player-character/internal/models"
+	"player-character/pkg/filter"
+)
+
+// matchesFilter evaluates a parsed filter against a character. Comparisons on
+// multiclass fields match when any entry matches.
+func matchesFilter(expr filter.Expr, character *models.Character) bool {
+	switch e := expr.(type) {
+	case *filter.And:
+		for _, operand := range e.Operands {
+			if !matchesFilter(operand, character) {
+				return false
+			}
+		}
+		return true
+	case *filter.Or:
+		for _, operand := range e.Operands {
+			if matchesFilter(operand, character) {
+				return true
+			}
+		}
+		return false
+	case *filter.Not:
+		return !matchesFilter(e.Operand, character)
+	case *filter.Comparison:
+		for _, value := range e.Field.Values(character) {
+			if e.Matches(value) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"regexp"
+
+	"player-character/pkg/filter"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// filterToBSON translates a parsed filter to a MongoDB query with the same
+// results as matchesFilter
+func filterToBSON(expr filter.Expr) bson.M {
+	switch e := expr.(type) {
+	case *filter.And:
+		return bson.M{"$and": operandsToBSON(e.Operands)}
+	case *filter.Or:
+		return bson.M{"$or": operandsToBSON(e.Operands)}
+	case *filter.Not:
+		return bson.M{"$nor": []bson.M{filterToBSON(e.Operand)}}
+	case *filter.Comparison:
+		if e.Field.Array != "" {
+			// Any entry must match on its own, as matchesFilter checks entry by entry
+			return bson.M{e.Field.Array: bson.M{"$elemMatch": comparisonToBSON(e)}}
+		}
+		return comparisonToBSON(e)
+	}
+	return bson.M{}
+}
+
+func operandsToBSON(operands []filter.Expr) []bson.M {
+	docs := make([]bson.M, len(operands))
+	for i, operand := range operands {
+		docs[i] = filterToBSON(operand)
+	}
+	return docs
+}
+
+// comparisonToBSON builds the condition on the field's path. Optional fields
+// are left out of documents when zero, so a comparison the zero value passes
+// also matches documents without the field.
+func comparisonToBSON(c *filter.Comparison) bson.M {
+	var condition bson.M
+	switch c.Op {
+	case filter.Eq:
+		condition = bson.M{"$eq": c.Values[0]}
+	case filter.Gt:
+		condition = bson.M{"$gt": c.Values[0]}
+	case filter.Gte:
+		condition = bson.M{"$gte": c.Values[0]}
+	case filter.Lt:
+		condition = bson.M{"$lt": c.Values[0]}
+	case filter.Lte:
+		condition = bson.M{"$lte": c.Values[0]}
+	case filter.In:
+		condition = bson.M{"$in": c.Values}
+	case filter.Contains:
+		condition = bson.M{"$regex": regexp.QuoteMeta(c.Values[0].(string)), "$options": "i"}
+	}
+
+	doc := bson.M{c.Field.Path: condition}
+	if c.Field.Optional && c.Matches(c.Field.Zero()) {
+		return bson.M{"$or": []bson.M{doc, {c.Field.Path: bson.M{"$exists": false}}}}
+	}
+	return doc
+}
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"player-character/pkg/filter"
+)
+
+// filterToSQL translates a parsed filter to a condition on the character
+// documents with the same results as matchesFilter, adding its values to q
+func filterToSQL(q *postgresQuery, expr filter.Expr) string {
+	switch e := expr.(type) {
+	case *filter.And:
+		return "(" + strings.Join(operandsToSQL(q, e.Operands), " AND ") + ")"
+	case *filter.Or:
+		return "(" + strings.Join(operandsToSQL(q, e.Operands), " OR ") + ")"
+	case *filter.Not:
+		return "NOT (" + filterToSQL(q, e.Operand) + ")"
+	case *filter.Comparison:
+		if e.Field.Array != "" {
+			// Any entry must match on its own, as matchesFilter checks entry by entry
+			return fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements(coalesce(nullif(doc->'%s', 'null'), '[]')) AS entry(value) WHERE %s)",
+				e.Field.Array, comparisonToSQL(q, e, "entry.value"))
+		}
+		return comparisonToSQL(q, e, "doc")
+	}
+	return "TRUE"
+}
+
+func operandsToSQL(q *postgresQuery, operands []filter.Expr) []string {
+	conditions := make([]string, len(operands))
+	for i, operand := range operands {
+		conditions[i] = filterToSQL(q, operand)
+	}
+	return conditions
+}
+
+// comparisonToSQL builds the condition on the field's path within the JSON
+// value named by source. Omitted optional fields read as the zero value, so
+// that comparisons treat them as matchesFilter does.
+func comparisonToSQL(q *postgresQuery, c *filter.Comparison, source string) string {
+	value := fieldSQL(c.Field, source)
+	typ := sqlType(c.Field.Type)
+
+	switch c.Op {
+	case filter.In:
+		return fmt.Sprintf("%s = ANY(%s::%s[])", value, q.arg(sqlValues(c)), typ)
+	case filter.Contains:
+		return fmt.Sprintf("strpos(lower(%s), lower(%s::text)) > 0", value, q.arg(c.Values[0]))
+	}
+
+	op := map[filter.Op]string{filter.Eq: "=", filter.Gt: ">", filter.Gte: ">=", filter.Lt: "<", filter.Lte: "<="}[c.Op]
+	return fmt.Sprintf("%s %s %s::%s", value, op, q.arg(c.Values[0]), typ)
+}
+
+// fieldSQL reads a field's value from the JSON value named by source. Text
+// compares byte by byte, as in Go and MongoDB.
+func fieldSQL(field *filter.Field, source string) string {
+	path := fmt.Sprintf("%s #>> '{%s}'", source, strings.ReplaceAll(field.Path, ".", ","))
+	switch field.Type {
+	case filter.Int:
+		return fmt.Sprintf("coalesce((%s)::bigint, 0)", path)
+	case filter.Time:
+		return fmt.Sprintf("coalesce((%s)::timestamptz, '0001-01-01T00:00:00Z')", path)
+	default:
+		return fmt.Sprintf(`coalesce(%s, '') COLLATE "C"`, path)
+	}
+}
+
+func sqlType(t filter.Type) string {
+	switch t {
+	case filter.Int:
+		return "bigint"
+	case filter.Time:
+		return "timestamptz"
+	default:
+		return "text"
+	}
+}
+
+// sqlValues returns the values of an In comparison as a slice of their type
+func sqlValues(c *filter.Comparison) any {
+	switch c.Field.Type {
+	case filter.Int:
+		values := make([]int, len(c.Values))
+		for i, v := range c.Values {
+			values[i] = v.(int)
+		}
+		return values
+	case filter.Time:
+		values := make([]time.Time, len(c.Values))
+		for i, v := range c.Values {
+			values[i] = v.(time.Time)
+		}
+		return values
+	default:
+		values := make([]string, len(c.Values))
+		for i, v := range c.Values {
+			values[i] = v.(string)
+		}
+		return values
+	}
+}
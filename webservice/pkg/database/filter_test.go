@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"player-character/pkg/filter"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// newTestMongoStore connects to MONGODB_TEST_URI with a fresh collection that
+// is dropped after the test, or skips the test when the variable is unset
+func newTestMongoStore(t *testing.T) *MongoStore {
+	t.Helper()
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI not set")
+	}
+
+	store, err := NewMongoStore(uri, "playercharacter_test", "test_"+uuid.New().String())
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	// Migrations create the unique ID index that keeps concurrent creates apart,
+	// and the text index search needs
+	if err := createIndexes(context.Background(), store.collection, characterIndexes); err != nil {
+		t.Fatalf("Failed to create indexes: %v", err)
+	}
+	if err := createTextIndex(context.Background(), store.collection); err != nil {
+		t.Fatalf("Failed to create the text index: %v", err)
+	}
+	t.Cleanup(func() {
+		store.collection.Drop(context.Background())
+		store.Disconnect(context.Background())
+	})
+	return store
+}
+
+func TestFilterToBSON(t *testing.T) {
+	tests := []struct {
+		filter   string
+		expected bson.M
+	}{
+		{"level>=5", bson.M{"level": bson.M{"$gte": 5}}},
+		{"alignment!=Chaotic Good", bson.M{"$nor": []bson.M{{"alignment": bson.M{"$eq": "Chaotic Good"}}}}},
+		// Optional fields are missing when empty, which the zero value must still match
+		{"subrace<M", bson.M{"$or": []bson.M{
+			{"subrace": bson.M{"$lt": "M"}},
+			{"subrace": bson.M{"$exists": false}},
+		}}},
+		{"multiclass.class in (Rogue,Bard)", bson.M{"multiclass": bson.M{"$elemMatch": bson.M{"class": bson.M{"$in": []interface{}{"Rogue", "Bard"}}}}}},
+		{"characterName~a.b", bson.M{"characterName": bson.M{"$regex": `a\.b`, "$options": "i"}}},
+	}
+
+	for _, tt := range tests {
+		expr, err := filter.Parse(tt.filter)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.filter, err)
+		}
+		if got := filterToBSON(expr); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("filterToBSON(%q) = %v, expected %v", tt.filter, got, tt.expected)
+		}
+	}
+}
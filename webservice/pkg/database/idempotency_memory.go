@@ -0,0 +1,109 @@
+package database
+
+import (
+	"container/heap"
+	"errors"
+	"sync"
+	"time"
+
+	"player-character/internal/models"
+)
+
+// MemoryIdempotencyStore implements an in-memory idempotency key storage
+type MemoryIdempotencyStore struct {
+	records map[string]models.IdempotencyRecord
+	expiry  expiryHeap // when each record was stored to expire, soonest first
+	mutex   sync.Mutex
+}
+
+// NewMemoryIdempotencyStore creates a new in-memory idempotency key store
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		records: make(map[string]models.IdempotencyRecord),
+	}
+}
+
+// expiring is a key and the expiry of the record stored under it
+type expiring struct {
+	key       string
+	expiresAt time.Time
+}
+
+// expiryHeap is a min-heap of expiring keys, implementing heap.Interface
+type expiryHeap []expiring
+
+func (h expiryHeap) Len() int           { return len(h) }
+func (h expiryHeap) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+func (h expiryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *expiryHeap) Push(x any)        { *h = append(*h, x.(expiring)) }
+func (h *expiryHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// sweep drops the records expired by now; the caller holds the lock. Heap
+// entries of records since released or replaced are dropped on the way.
+func (s *MemoryIdempotencyStore) sweep(now time.Time) {
+	for len(s.expiry) > 0 && !now.Before(s.expiry[0].expiresAt) {
+		entry := heap.Pop(&s.expiry).(expiring)
+		if record, exists := s.records[entry.key]; exists && record.ExpiresAt.Equal(entry.expiresAt) {
+			delete(s.records, entry.key)
+		}
+	}
+}
+
+// Reserve stores the record unless an unexpired one holds its key, which is returned instead
+func (s *MemoryIdempotencyStore) Reserve(record *models.IdempotencyRecord) (*models.IdempotencyRecord, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+	if existing, exists := s.records[record.Key]; exists {
+		return &existing, nil
+	}
+
+	record.CreatedAt = now
+	s.records[record.Key] = *record
+	heap.Push(&s.expiry, expiring{key: record.Key, expiresAt: record.ExpiresAt})
+	return nil, nil
+}
+
+// Complete stores the response for a reserved key
+func (s *MemoryIdempotencyStore) Complete(key string, status int, contentType string, body []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record, exists := s.records[key]
+	if !exists {
+		return errors.New("idempotency key not found")
+	}
+
+	record.Completed = true
+	record.Status = status
+	record.ContentType = contentType
+	record.Body = body
+	s.records[key] = record
+	return nil
+}
+
+// Release forgets a reserved key so the request can be retried
+func (s *MemoryIdempotencyStore) Release(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// IdempotencyStore interface defines the contract for idempotency key storage
+type IdempotencyStore interface {
+	// Reserve claims record.Key for a request in progress. If the key is
+	// already held and has not expired, it returns the existing record and
+	// stores nothing.
+	Reserve(record *models.IdempotencyRecord) (*models.IdempotencyRecord, error)
+	Complete(key string, status int, contentType string, body []byte) error
+	Release(key string) error
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"player-character/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoIdempotencyStore implements MongoDB-based idempotency key storage
+type MongoIdempotencyStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoIdempotencyStore creates an idempotency key store backed by the
+// given database, indexing keys for uniqueness and expiring old records
+func NewMongoIdempotencyStore(database *mongo.Database, collectionName string) (*MongoIdempotencyStore, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := database.Collection(collectionName)
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.M{"key": 1}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.M{"expiresAt": 1}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MongoIdempotencyStore{collection: collection}, nil
+}
+
+// Reserve stores the record unless an unexpired one holds its key, which is returned instead
+func (s *MongoIdempotencyStore) Reserve(record *models.IdempotencyRecord) (*models.IdempotencyRecord, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for {
+		now := time.Now()
+		record.CreatedAt = now
+
+		// The TTL monitor only runs once a minute, so replace expired records ourselves.
+		// The unique index makes sure only one of two racing requests gets the key.
+		_, err := s.collection.ReplaceOne(ctx,
+			bson.M{"key": record.Key, "expiresAt": bson.M{"$lte": now}},
+			record,
+			options.Replace().SetUpsert(true))
+		if err == nil {
+			return nil, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+
+		var existing models.IdempotencyRecord
+		err = s.collection.FindOne(ctx, bson.M{"key": record.Key}).Decode(&existing)
+		if err == nil {
+			return &existing, nil
+		}
+		// The record was released or expired since the upsert; try again
+		// until the context runs out
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+	}
+}
+
+// Complete stores the response for a reserved key
+func (s *MongoIdempotencyStore) Complete(key string, status int, contentType string, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := s.collection.UpdateOne(ctx, bson.M{"key": key}, bson.M{"$set": bson.M{
+		"completed":   true,
+		"status":      status,
+		"contentType": contentType,
+		"body":        body,
+	}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("idempotency key not found")
+	}
+	return nil
+}
+
+// Release forgets a reserved key so the request can be retried
+func (s *MongoIdempotencyStore) Release(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := s.collection.DeleteOne(ctx, bson.M{"key": key})
+	return err
+}
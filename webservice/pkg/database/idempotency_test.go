@@ -0,0 +1,45 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+)
+
+// TestMemoryIdempotencyStoreExpiry tests that expired keys can be reserved again
+func TestMemoryIdempotencyStoreExpiry(t *testing.T) {
+	keys := NewMemoryIdempotencyStore()
+	expired := &models.IdempotencyRecord{Key: "k", Fingerprint: "a", ExpiresAt: time.Now().Add(-time.Second)}
+	if existing, err := keys.Reserve(expired); existing != nil || err != nil {
+		t.Fatalf("Expected the first reservation to succeed, got %v, %v", existing, err)
+	}
+	fresh := &models.IdempotencyRecord{Key: "k", Fingerprint: "b", ExpiresAt: time.Now().Add(time.Hour)}
+	if existing, err := keys.Reserve(fresh); existing != nil || err != nil {
+		t.Errorf("Expected an expired key to be reserved again, got %v, %v", existing, err)
+	}
+	if existing, _ := keys.Reserve(fresh); existing == nil || existing.Fingerprint != "b" {
+		t.Errorf("Expected the live reservation back, got %v", existing)
+	}
+}
+
+// TestMemoryIdempotencyStoreSweep tests that expired records are dropped, and
+// that a key released and reserved again is kept until its new expiry
+func TestMemoryIdempotencyStoreSweep(t *testing.T) {
+	keys := NewMemoryIdempotencyStore()
+	now := time.Now()
+	for _, key := range []string{"a", "b", "c"} {
+		keys.Reserve(&models.IdempotencyRecord{Key: key, ExpiresAt: now.Add(-time.Second)})
+	}
+	keys.Reserve(&models.IdempotencyRecord{Key: "live", ExpiresAt: now.Add(time.Hour)})
+	keys.Release("live")
+	keys.Reserve(&models.IdempotencyRecord{Key: "live", Fingerprint: "again", ExpiresAt: now.Add(2 * time.Hour)})
+
+	keys.Reserve(&models.IdempotencyRecord{Key: "d", ExpiresAt: now.Add(time.Hour)})
+	if len(keys.records) != 2 {
+		t.Errorf("Expected only the live records to be kept, got %v", keys.records)
+	}
+	if existing, _ := keys.Reserve(&models.IdempotencyRecord{Key: "live"}); existing == nil || existing.Fingerprint != "again" {
+		t.Errorf("Expected the second reservation to be kept, got %v", existing)
+	}
+}
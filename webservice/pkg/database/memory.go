@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"player-character/internal/models"
+	"player-character/pkg/filter"
 
 	"github.com/google/uuid"
 )
@@ -15,6 +16,7 @@ import (
 // MemoryStore implements an in-memory character storage
 type MemoryStore struct {
 	characters map[string]models.Character
+	index      *textIndex
 	mutex      sync.RWMutex
 }
 
@@ -22,6 +24,7 @@ type MemoryStore struct {
 func NewMemoryStore() *MemoryStore {
 	return &MemoryStore{
 		characters: make(map[string]models.Character),
+		index:      newTextIndex(),
 	}
 }
 
@@ -30,6 +33,11 @@ func (s *MemoryStore) Create(character *models.Character) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	return s.create(character)
+}
+
+// create stores a new character; the caller holds the write lock
+func (s *MemoryStore) create(character *models.Character) error {
 	// Generate ID if not provided
 	if character.ID == "" {
 		character.ID = uuid.New().String()
@@ -46,6 +54,7 @@ func (s *MemoryStore) Create(character *models.Character) error {
 	character.UpdatedAt = now
 
 	s.characters[character.ID] = *character
+	s.index.add(character)
 	return nil
 }
 
@@ -63,69 +72,145 @@ func (s *MemoryStore) Get(id string) (*models.Character, error) {
 }
 
 // List retrieves characters with pagination and search
-func (s *MemoryStore) List(page, limit int, sortBy, sortOrder, search string) ([]models.Character, int, error) {
+func (s *MemoryStore) List(opts ListOptions) ([]models.Character, int, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	// Convert map to slice
+	page, limit := opts.Page, opts.Limit
+	sortBy, direction := sortSpec(opts.SortBy, opts.SortOrder)
+
+	// Convert map to slice, applying scope, visibility and search
+	ownedParties := s.ownedParties(opts)
 	var allCharacters []models.Character
 	for _, char := range s.characters {
-		allCharacters = append(allCharacters, char)
-	}
-
-	// Filter by search term if provided
-	if search != "" {
-		searchLower := strings.ToLower(search)
-		var filtered []models.Character
-		for _, char := range allCharacters {
-			if strings.Contains(strings.ToLower(char.CharacterName), searchLower) ||
-				strings.Contains(strings.ToLower(char.Race), searchLower) ||
-				strings.Contains(strings.ToLower(char.Class), searchLower) {
-				filtered = append(filtered, char)
-			}
+		if s.inScope(&char, opts, ownedParties) {
+			allCharacters = append(allCharacters, char)
 		}
-		allCharacters = filtered
 	}
 
-	// Sort characters
-	sort.Slice(allCharacters, func(i, j int) bool {
-		var less bool
-		switch sortBy {
-		case "characterName":
-			less = strings.ToLower(allCharacters[i].CharacterName) < strings.ToLower(allCharacters[j].CharacterName)
-		case "level":
-			less = allCharacters[i].Level < allCharacters[j].Level
-		case "race":
-			less = strings.ToLower(allCharacters[i].Race) < strings.ToLower(allCharacters[j].Race)
-		case "class":
-			less = strings.ToLower(allCharacters[i].Class) < strings.ToLower(allCharacters[j].Class)
-		case "createdAt":
-			less = allCharacters[i].CreatedAt.Before(allCharacters[j].CreatedAt)
-		default:
-			// Default sort by createdAt
-			less = allCharacters[i].CreatedAt.Before(allCharacters[j].CreatedAt)
+	// Sort characters, breaking ties by ID so that cursors have a stable order
+	compare := func(a, b *models.Character) int {
+		cmp := compareSortValues(sortValue(a, sortBy), sortValue(b, sortBy))
+		if cmp == 0 {
+			cmp = strings.Compare(a.ID, b.ID)
 		}
-
-		if sortOrder == "desc" {
-			return !less
-		}
-		return less
+		return cmp * direction
+	}
+	sort.Slice(allCharacters, func(i, j int) bool {
+		return compare(&allCharacters[i], &allCharacters[j]) < 0
 	})
 
 	total := len(allCharacters)
+	if opts.SkipCount {
+		total = -1
+	}
 
-	// Calculate pagination
+	// Continue after the cursor, or skip to the page
 	start := (page - 1) * limit
-	if start >= total {
+	if opts.After != nil {
+		start = sort.Search(len(allCharacters), func(i int) bool {
+			cmp := compareSortValues(sortValue(&allCharacters[i], sortBy), opts.After.Value)
+			if cmp == 0 {
+				cmp = strings.Compare(allCharacters[i].ID, opts.After.ID)
+			}
+			return cmp*direction > 0
+		})
+	}
+	if start >= len(allCharacters) {
 		return []models.Character{}, total, nil
 	}
 
-	end := start + limit
-	if end > total {
-		end = total
+	end := start + opts.fetch()
+	if end > len(allCharacters) {
+		end = len(allCharacters)
 	}
 
-	return allCharacters[start:end], total, nil
+	result := allCharacters[start:end]
+	if len(opts.Fields) > 0 {
+		for i := range result {
+			projectCharacter(&result[i], opts.Fields)
+		}
+	}
+	return result, total, nil
+}
+
+// ownedParties returns the parties the caller has a character in, which are
+// visible to them, or nil when the listing has no visibility restriction
+func (s *MemoryStore) ownedParties(opts ListOptions) map[string]bool {
+	if opts.Visibility == nil {
+		return nil
+	}
+	ownedParties := make(map[string]bool)
+	for _, char := range s.characters {
+		if char.OwnerID == opts.Visibility.UserID && char.PartyID != "" {
+			ownedParties[char.PartyID] = true
+		}
+	}
+	return ownedParties
+}
+
+// inScope reports whether a character passes the listing's scope, visibility, filter and search
+func (s *MemoryStore) inScope(char *models.Character, opts ListOptions, ownedParties map[string]bool) bool {
+	if opts.CampaignID != "" && char.CampaignID != opts.CampaignID {
+		return false
+	}
+	if len(opts.CampaignIDs) > 0 && !containsString(opts.CampaignIDs, char.CampaignID) {
+		return false
+	}
+	if opts.PartyID != "" && char.PartyID != opts.PartyID {
+		return false
+	}
+	if opts.OwnerID != "" && char.OwnerID != opts.OwnerID {
+		return false
+	}
+	if opts.Visibility != nil && !opts.Visibility.allows(char, ownedParties) {
+		return false
+	}
+	if opts.Filter != nil && !matchesFilter(opts.Filter, char) {
+		return false
+	}
+	if opts.Search != "" {
+		searchLower := strings.ToLower(opts.Search)
+		if !strings.Contains(strings.ToLower(char.CharacterName), searchLower) &&
+			!strings.Contains(strings.ToLower(char.Race), searchLower) &&
+			!strings.Contains(strings.ToLower(char.Class), searchLower) {
+			return false
+		}
+	}
+	return true
+}
+
+// All retrieves every character, oldest first
+func (s *MemoryStore) All() ([]models.Character, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	characters := make([]models.Character, 0, len(s.characters))
+	for _, character := range s.characters {
+		characters = append(characters, character)
+	}
+	sort.Slice(characters, func(i, j int) bool {
+		if !characters[i].CreatedAt.Equal(characters[j].CreatedAt) {
+			return characters[i].CreatedAt.Before(characters[j].CreatedAt)
+		}
+		return characters[i].ID < characters[j].ID
+	})
+
+	return characters, nil
+}
+
+// Restore stores a character as it is, keeping its ID and timestamps
+func (s *MemoryStore) Restore(character *models.Character) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.characters[character.ID]; exists {
+		return ErrExists
+	}
+
+	s.characters[character.ID] = *character
+	s.index.add(character)
+	return nil
 }
 
 // Update modifies an existing character
@@ -133,6 +218,11 @@ func (s *MemoryStore) Update(id string, character *models.Character) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	return s.update(id, character)
+}
+
+// update modifies an existing character; the caller holds the write lock
+func (s *MemoryStore) update(id string, character *models.Character) error {
 	existing, exists := s.characters[id]
 	if !exists {
 		return errors.New("character not found")
@@ -144,6 +234,7 @@ func (s *MemoryStore) Update(id string, character *models.Character) error {
 	character.UpdatedAt = time.Now()
 
 	s.characters[id] = *character
+	s.index.add(character)
 	return nil
 }
 
@@ -152,19 +243,94 @@ func (s *MemoryStore) Delete(id string) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	return s.delete(id)
+}
+
+// delete removes a character; the caller holds the write lock
+func (s *MemoryStore) delete(id string) error {
 	if _, exists := s.characters[id]; !exists {
 		return errors.New("character not found")
 	}
 
 	delete(s.characters, id)
+	s.index.remove(id)
 	return nil
 }
 
+// ListOptions controls pagination, sorting, search and scoping for List
+type ListOptions struct {
+	Page        int
+	Limit       int
+	After       *Cursor // continue after this cursor instead of skipping to Page, if set
+	SkipCount   bool    // don't count matches; List returns a total of -1
+	Lookahead   bool    // also return the character after the page, if any, to show another page follows
+	SortBy      string
+	SortOrder   string
+	Search      string
+	Filter      filter.Expr // only characters matching this parsed filter, if set
+	CampaignID  string      // only characters in this campaign, if set
+	CampaignIDs []string    // only characters in one of these campaigns, if set
+	PartyID     string      // only characters in this party, if set
+	OwnerID     string      // only characters owned by this user, if set
+	Visibility  *Visibility // only characters this caller may read, if set
+	Fields      []string    // load only these fields (JSON names), if set
+}
+
+// fetch returns how many characters a page reads, including the lookahead
+func (o ListOptions) fetch() int {
+	if o.Lookahead {
+		return o.Limit + 1
+	}
+	return o.Limit
+}
+
+// Visibility restricts a listing to the characters a user is allowed to read:
+// their own, every character in CampaignIDs, and characters sharing a party
+// with one of their own characters. When WithinCampaigns is set, only
+// characters in those campaigns qualify at all.
+type Visibility struct {
+	UserID          string
+	CampaignIDs     []string
+	WithinCampaigns []string
+}
+
+// allows reports whether a character is visible, given the parties the user has characters in
+func (v *Visibility) allows(character *models.Character, ownedParties map[string]bool) bool {
+	if len(v.WithinCampaigns) > 0 && !containsString(v.WithinCampaigns, character.CampaignID) {
+		return false
+	}
+	if character.OwnerID == v.UserID {
+		return true
+	}
+	if character.PartyID != "" && ownedParties[character.PartyID] {
+		return true
+	}
+	return character.CampaignID != "" && containsString(v.CampaignIDs, character.CampaignID)
+}
+
+// containsString checks if a slice contains a string
+func containsString(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
+
 // CharacterStore interface defines the contract for character storage
 type CharacterStore interface {
 	Create(character *models.Character) error
 	Get(id string) (*models.Character, error)
-	List(page, limit int, sortBy, sortOrder, search string) ([]models.Character, int, error)
+	List(opts ListOptions) ([]models.Character, int, error)
+	// Search runs a full-text query, most relevant first; see MemoryStore.Search
+	Search(query string, opts ListOptions) ([]models.SearchHit, int, error)
 	Update(id string, character *models.Character) error
 	Delete(id string) error
+	// All retrieves every character, oldest first, for backups
+	All() ([]models.Character, error)
+	// Restore stores a character from a backup as it is, returning ErrExists if its ID is taken
+	Restore(character *models.Character) error
+	// Bulk applies a batch of writes atomically: all of them or, on error, none
+	Bulk(writes []BulkWrite) error
 }
@@ -0,0 +1,264 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoCollections names the collections migrations work on
+type MongoCollections struct {
+	Characters        string
+	Campaigns         string
+	Users             string
+	APIKeys           string
+	Webhooks          string
+	WebhookDeliveries string
+}
+
+// MongoMigration changes the MongoDB schema from the previous version to
+// Version. Down undoes Up; it is nil for migrations that cannot be undone.
+// Both must be safe to run again after failing part-way.
+type MongoMigration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database, c MongoCollections) error
+	Down        func(ctx context.Context, db *mongo.Database, c MongoCollections) error
+}
+
+// MigrationStep is one migration to run up or down
+type MigrationStep struct {
+	Version     int    `json:"version"`
+	Description string `json:"description"`
+	Down        bool   `json:"down,omitempty"`
+}
+
+func (s MigrationStep) String() string {
+	direction := "up"
+	if s.Down {
+		direction = "down"
+	}
+	return fmt.Sprintf("%4d %-4s %s", s.Version, direction, s.Description)
+}
+
+// MigrationStatus reports whether a migration has been applied
+type MigrationStatus struct {
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	AppliedAt   *time.Time `json:"appliedAt,omitempty"`
+}
+
+// migrationRecord is an applied migration as recorded in the history collection
+type migrationRecord struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"appliedAt"`
+}
+
+// migrationLockID is the _id of the history document held while migrating,
+// so that replicas starting together run each migration once
+const migrationLockID = "lock"
+
+// migrationLockTimeout is how long a lock is honored; a process that died
+// while migrating leaves its lock behind, and the next one takes it over
+const migrationLockTimeout = 10 * time.Minute
+
+// LatestMigration is the target version of the newest migration
+const LatestMigration = -1
+
+// MongoMigrator applies versioned migrations to a MongoDB database,
+// recording each applied migration in a history collection
+type MongoMigrator struct {
+	db          *mongo.Database
+	collections MongoCollections
+	history     *mongo.Collection
+	migrations  []MongoMigration
+}
+
+// NewMongoMigrator creates a migrator for the migrations this version of the
+// service knows, recording them in the named history collection
+func NewMongoMigrator(db *mongo.Database, collections MongoCollections, historyCollection string) *MongoMigrator {
+	return &MongoMigrator{
+		db:          db,
+		collections: collections,
+		history:     db.Collection(historyCollection),
+		migrations:  mongoMigrations,
+	}
+}
+
+// Status lists every known migration and when it was applied
+func (m *MongoMigrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = MigrationStatus{Version: migration.Version, Description: migration.Description}
+		if record, ok := applied[migration.Version]; ok {
+			statuses[i].AppliedAt = &record.AppliedAt
+		}
+	}
+	return statuses, nil
+}
+
+// Migrate brings the database to the target version, LatestMigration for
+// the newest, applying missing migrations up to it and rolling back applied
+// ones after it. A dry run only returns the steps that would be run.
+// Otherwise the steps run in order, stopping at the first to fail; those
+// before it are returned, and stay applied.
+func (m *MongoMigrator) Migrate(ctx context.Context, target int, dryRun bool) ([]MigrationStep, error) {
+	if dryRun {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return planMigrations(m.migrations, applied, target)
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Plan under the lock, so migrations another replica just ran are not run again
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := planMigrations(m.migrations, applied, target)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]MongoMigration{}
+	for _, migration := range m.migrations {
+		byVersion[migration.Version] = migration
+	}
+	for i, step := range steps {
+		migration := byVersion[step.Version]
+		if err := m.run(ctx, migration, step.Down); err != nil {
+			return steps[:i], fmt.Errorf("migration %d (%s): %w", step.Version, step.Description, err)
+		}
+	}
+	return steps, nil
+}
+
+// run applies one migration in the given direction and records the result
+func (m *MongoMigrator) run(ctx context.Context, migration MongoMigration, down bool) error {
+	if down {
+		if err := migration.Down(ctx, m.db, m.collections); err != nil {
+			return err
+		}
+		_, err := m.history.DeleteOne(ctx, bson.M{"_id": migration.Version})
+		return err
+	}
+
+	if err := migration.Up(ctx, m.db, m.collections); err != nil {
+		return err
+	}
+	_, err := m.history.InsertOne(ctx, migrationRecord{
+		Version:     migration.Version,
+		Description: migration.Description,
+		AppliedAt:   time.Now(),
+	})
+	return err
+}
+
+// applied returns the recorded migrations by version
+func (m *MongoMigrator) applied(ctx context.Context) (map[int]migrationRecord, error) {
+	cursor, err := m.history.Find(ctx, bson.M{"_id": bson.M{"$type": "number"}})
+	if err != nil {
+		return nil, err
+	}
+	var records []migrationRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]migrationRecord, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// lock takes the migration lock, waiting while another process holds it, and
+// returns the function releasing it
+func (m *MongoMigrator) lock(ctx context.Context) (func(), error) {
+	owner := fmt.Sprintf("%d", time.Now().UnixNano())
+	for {
+		now := time.Now()
+		_, err := m.history.UpdateOne(ctx,
+			bson.M{"_id": migrationLockID, "lockedAt": bson.M{"$lt": now.Add(-migrationLockTimeout)}},
+			bson.M{"$set": bson.M{"lockedAt": now, "owner": owner}},
+			options.Update().SetUpsert(true))
+		if err == nil {
+			break
+		}
+		// A current lock makes the upsert collide with the existing document
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for another migration to finish: %w", ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+
+	return func() {
+		m.history.DeleteOne(context.Background(), bson.M{"_id": migrationLockID, "owner": owner})
+	}, nil
+}
+
+// planMigrations returns the steps taking a database with the applied
+// migrations to the target version: missing migrations up to the target in
+// ascending order, then applied ones after it in descending order
+func planMigrations(migrations []MongoMigration, applied map[int]migrationRecord, target int) ([]MigrationStep, error) {
+	known := map[int]bool{}
+	for _, migration := range migrations {
+		known[migration.Version] = true
+	}
+	for version := range applied {
+		if !known[version] {
+			return nil, fmt.Errorf("database has migration %d applied, which this version does not know; upgrade first", version)
+		}
+	}
+	if target != LatestMigration && target != 0 && !known[target] {
+		return nil, fmt.Errorf("unknown migration %d", target)
+	}
+
+	steps := []MigrationStep{}
+	for _, migration := range migrations {
+		_, done := applied[migration.Version]
+		if !done && (target == LatestMigration || migration.Version <= target) {
+			steps = append(steps, MigrationStep{Version: migration.Version, Description: migration.Description})
+		}
+	}
+	if target == LatestMigration {
+		return steps, nil
+	}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		if _, done := applied[migration.Version]; !done || migration.Version <= target {
+			continue
+		}
+		if migration.Down == nil {
+			return nil, fmt.Errorf("%w: migration %d (%s)", ErrIrreversible, migration.Version, migration.Description)
+		}
+		steps = append(steps, MigrationStep{Version: migration.Version, Description: migration.Description, Down: true})
+	}
+	return steps, nil
+}
+
+// ErrIrreversible is returned when rolling back past a migration that cannot be undone
+var ErrIrreversible = errors.New("migration cannot be rolled back")
@@ -0,0 +1,188 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestPlanMigrations(t *testing.T) {
+	noop := func(context.Context, *mongo.Database, MongoCollections) error { return nil }
+	migrations := []MongoMigration{
+		{Version: 1, Description: "one", Up: noop, Down: noop},
+		{Version: 2, Description: "two", Up: noop},
+		{Version: 3, Description: "three", Up: noop, Down: noop},
+		{Version: 4, Description: "four", Up: noop, Down: noop},
+	}
+	applied := func(versions ...int) map[int]migrationRecord {
+		records := map[int]migrationRecord{}
+		for _, version := range versions {
+			records[version] = migrationRecord{Version: version}
+		}
+		return records
+	}
+	up := func(version int) MigrationStep {
+		return MigrationStep{Version: version, Description: migrations[version-1].Description}
+	}
+	down := func(version int) MigrationStep {
+		step := up(version)
+		step.Down = true
+		return step
+	}
+
+	tests := []struct {
+		name     string
+		applied  map[int]migrationRecord
+		target   int
+		expected []MigrationStep
+		err      string
+	}{
+		{"fresh database", applied(), LatestMigration, []MigrationStep{up(1), up(2), up(3), up(4)}, ""},
+		{"up to date", applied(1, 2, 3, 4), LatestMigration, []MigrationStep{}, ""},
+		{"partial target", applied(1), 3, []MigrationStep{up(2), up(3)}, ""},
+		{"fills gaps", applied(1, 3), LatestMigration, []MigrationStep{up(2), up(4)}, ""},
+		{"rolls back newest first", applied(1, 2, 3, 4), 2, []MigrationStep{down(4), down(3)}, ""},
+		{"past an irreversible migration", applied(1, 2, 3), 1, nil, "cannot be rolled back"},
+		{"unknown target", applied(), 7, nil, "unknown migration 7"},
+		{"unknown applied migration", applied(1, 9), LatestMigration, nil, "does not know"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, err := planMigrations(migrations, tt.applied, tt.target)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Expected an error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("planMigrations failed: %v", err)
+			}
+			if !reflect.DeepEqual(steps, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, steps)
+			}
+		})
+	}
+
+	if _, err := planMigrations(migrations, applied(1, 2), 0); !errors.Is(err, ErrIrreversible) {
+		t.Errorf("Expected ErrIrreversible, got %v", err)
+	}
+}
+
+func TestMongoMigrator(t *testing.T) {
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI not set")
+	}
+
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	db := client.Database("playercharacter_migrate_test_" + uuid.New().String()[:8])
+	t.Cleanup(func() {
+		db.Drop(context.Background())
+		client.Disconnect(context.Background())
+	})
+
+	collections := MongoCollections{
+		Characters: "characters", Campaigns: "campaigns", Users: "users",
+		APIKeys: "apikeys", Webhooks: "webhooks", WebhookDeliveries: "webhook_deliveries",
+	}
+	migrator := NewMongoMigrator(db, collections, "migrations")
+
+	// A character whose creation time an old update cleared
+	if _, err := db.Collection("characters").InsertOne(ctx, bson.M{"id": "cleared", "createdAt": time.Time{}}); err != nil {
+		t.Fatalf("InsertOne failed: %v", err)
+	}
+
+	steps, err := migrator.Migrate(ctx, LatestMigration, true)
+	if err != nil || len(steps) != len(mongoMigrations) {
+		t.Fatalf("Expected a dry run to plan every migration, got %v, %v", steps, err)
+	}
+	if names := indexNames(t, db.Collection("characters")); names["id_unique"] {
+		t.Fatal("Expected a dry run to change nothing")
+	}
+
+	if steps, err := migrator.Migrate(ctx, LatestMigration, false); err != nil || len(steps) != len(mongoMigrations) {
+		t.Fatalf("Expected every migration to run, got %v, %v", steps, err)
+	}
+	if names := indexNames(t, db.Collection("characters")); !names["id_unique"] || !names["level_id"] || !names[textIndexName] {
+		t.Errorf("Expected the character indexes, got %v", names)
+	}
+	if _, err := db.Collection("characters").InsertOne(ctx, bson.M{"id": "cleared"}); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("Expected the unique index to reject a duplicate ID, got %v", err)
+	}
+	var backfilled struct {
+		CreatedAt time.Time `bson:"createdAt"`
+	}
+	if err := db.Collection("characters").FindOne(ctx, bson.M{"id": "cleared"}).Decode(&backfilled); err != nil ||
+		backfilled.CreatedAt.Year() < 2000 {
+		t.Errorf("Expected the creation time to be backfilled, got %v, %v", backfilled.CreatedAt, err)
+	}
+
+	if steps, err := migrator.Migrate(ctx, LatestMigration, false); err != nil || len(steps) != 0 {
+		t.Errorf("Expected nothing left to run, got %v, %v", steps, err)
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			t.Errorf("Expected migration %d to be applied", status.Version)
+		}
+	}
+
+	// The backfill cannot be undone, so rollback stops before it
+	if _, err := migrator.Migrate(ctx, 0, false); !errors.Is(err, ErrIrreversible) {
+		t.Errorf("Expected ErrIrreversible, got %v", err)
+	}
+	if names := indexNames(t, db.Collection("characters")); !names["id_unique"] {
+		t.Error("Expected a refused rollback to change nothing")
+	}
+
+	// Reversible migrations roll back, newest first
+	reversible := NewMongoMigrator(db, collections, "reversible_migrations")
+	reversible.migrations = mongoMigrations[:2]
+	if _, err := reversible.Migrate(ctx, LatestMigration, false); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	steps, err = reversible.Migrate(ctx, 0, false)
+	if expected := []MigrationStep{
+		{Version: 2, Description: mongoMigrations[1].Description, Down: true},
+		{Version: 1, Description: mongoMigrations[0].Description, Down: true},
+	}; err != nil || !reflect.DeepEqual(steps, expected) {
+		t.Fatalf("Expected %v, got %v, %v", expected, steps, err)
+	}
+	if names := indexNames(t, db.Collection("characters")); names["id_unique"] || names["level_id"] {
+		t.Errorf("Expected the character indexes to be dropped, got %v", names)
+	}
+	if names := indexNames(t, db.Collection("users")); names["usernameLower_unique"] {
+		t.Errorf("Expected the user indexes to be dropped, got %v", names)
+	}
+}
+
+// indexNames returns the names of a collection's indexes
+func indexNames(t *testing.T, collection *mongo.Collection) map[string]bool {
+	t.Helper()
+	specs, err := collection.Indexes().ListSpecifications(context.Background())
+	if err != nil {
+		t.Fatalf("ListSpecifications failed: %v", err)
+	}
+	names := map[string]bool{}
+	for _, spec := range specs {
+		names[spec.Name] = true
+	}
+	return names
+}
@@ -0,0 +1,33 @@
+-- Characters are stored as their JSON document, with the fields that are
+-- sorted, scoped and searched on copied into indexed columns. Text columns
+-- compare byte by byte, as MongoDB does.
+CREATE TABLE characters (
+    id             text COLLATE "C" PRIMARY KEY,
+    doc            jsonb NOT NULL,
+    character_name text COLLATE "C" NOT NULL,
+    race           text COLLATE "C" NOT NULL,
+    class          text COLLATE "C" NOT NULL,
+    level          integer NOT NULL,
+    created_at     timestamptz NOT NULL,
+    owner_id       text COLLATE "C" NOT NULL DEFAULT '',
+    campaign_id    text COLLATE "C" NOT NULL DEFAULT '',
+    party_id       text COLLATE "C" NOT NULL DEFAULT '',
+    -- Full-text search: the weighted number of times each word occurs, for
+    -- matching and scoring, and the words' positions, for phrases
+    terms          jsonb NOT NULL DEFAULT '{}',
+    phrases        tsvector NOT NULL DEFAULT ''
+);
+
+-- Sorts break ties by ID, so each sort index ends with it
+CREATE INDEX characters_character_name ON characters (character_name, id);
+CREATE INDEX characters_race ON characters (race, id);
+CREATE INDEX characters_class ON characters (class, id);
+CREATE INDEX characters_level ON characters (level, id);
+CREATE INDEX characters_created_at ON characters (created_at, id);
+
+CREATE INDEX characters_owner_id ON characters (owner_id);
+CREATE INDEX characters_campaign_id ON characters (campaign_id);
+CREATE INDEX characters_party_id ON characters (party_id);
+
+CREATE INDEX characters_terms ON characters USING gin (terms);
+CREATE INDEX characters_phrases ON characters USING gin (phrases);
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoMigrations lists the schema versions in order. Released migrations
+// must not change; add a new one instead.
+var mongoMigrations = []MongoMigration{
+	{
+		Version:     1,
+		Description: "index characters by ID, sort fields and scope",
+		Up: func(ctx context.Context, db *mongo.Database, c MongoCollections) error {
+			return createIndexes(ctx, db.Collection(c.Characters), characterIndexes)
+		},
+		Down: func(ctx context.Context, db *mongo.Database, c MongoCollections) error {
+			return dropIndexes(ctx, db.Collection(c.Characters), characterIndexes)
+		},
+	},
+	{
+		Version:     2,
+		Description: "index campaigns, users, API keys and webhooks by ID and lookup fields",
+		Up: func(ctx context.Context, db *mongo.Database, c MongoCollections) error {
+			for name, indexes := range siblingIndexes(c) {
+				if err := createIndexes(ctx, db.Collection(name), indexes); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database, c MongoCollections) error {
+			for name, indexes := range siblingIndexes(c) {
+				if err := dropIndexes(ctx, db.Collection(name), indexes); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		// Update used to $set every field, writing the zero time over the
+		// creation time. The insert time is kept in each document's ObjectID.
+		Version:     3,
+		Description: "backfill character creation times cleared by updates",
+		Up: func(ctx context.Context, db *mongo.Database, c MongoCollections) error {
+			return backfill(ctx, db.Collection(c.Characters),
+				bson.M{"createdAt": bson.M{"$lt": time.Unix(0, 0)}, "_id": bson.M{"$type": "objectId"}},
+				bson.M{"createdAt": bson.M{"$toDate": "$_id"}})
+		},
+	},
+	{
+		// Built by NewMongoStore before migrations existed, so databases
+		// that already have it skip the build
+		Version:     4,
+		Description: "index characters for full-text search",
+		Up: func(ctx context.Context, db *mongo.Database, c MongoCollections) error {
+			return createTextIndex(ctx, db.Collection(c.Characters))
+		},
+		Down: func(ctx context.Context, db *mongo.Database, c MongoCollections) error {
+			return dropIndexes(ctx, db.Collection(c.Characters), []indexSpec{{name: textIndexName}})
+		},
+	},
+}
+
+// indexSpec is an index a migration creates, named so it can be dropped again
+type indexSpec struct {
+	name   string
+	keys   bson.D
+	unique bool
+}
+
+// characterIndexes serve ID lookups, and sorts and cursors, which break ties by ID
+var characterIndexes = []indexSpec{
+	{"id_unique", bson.D{{Key: "id", Value: 1}}, true},
+	{"characterName_id", bson.D{{Key: "characterName", Value: 1}, {Key: "id", Value: 1}}, false},
+	{"level_id", bson.D{{Key: "level", Value: 1}, {Key: "id", Value: 1}}, false},
+	{"race_id", bson.D{{Key: "race", Value: 1}, {Key: "id", Value: 1}}, false},
+	{"class_id", bson.D{{Key: "class", Value: 1}, {Key: "id", Value: 1}}, false},
+	{"createdAt_id", bson.D{{Key: "createdAt", Value: 1}, {Key: "id", Value: 1}}, false},
+	{"ownerId", bson.D{{Key: "ownerId", Value: 1}}, false},
+	{"campaignId", bson.D{{Key: "campaignId", Value: 1}}, false},
+	{"partyId", bson.D{{Key: "partyId", Value: 1}}, false},
+}
+
+// siblingIndexes returns the indexes of the other collections by collection name
+func siblingIndexes(c MongoCollections) map[string][]indexSpec {
+	id := indexSpec{"id_unique", bson.D{{Key: "id", Value: 1}}, true}
+	return map[string][]indexSpec{
+		c.Campaigns: {id},
+		c.Users: {id,
+			{"usernameLower_unique", bson.D{{Key: "usernameLower", Value: 1}}, true}},
+		c.APIKeys: {id,
+			{"keyHash_unique", bson.D{{Key: "keyHash", Value: 1}}, true},
+			{"userId", bson.D{{Key: "userId", Value: 1}}, false}},
+		c.Webhooks: {id,
+			{"ownerId", bson.D{{Key: "ownerId", Value: 1}}, false}},
+		c.WebhookDeliveries: {id,
+			{"webhookId_createdAt", bson.D{{Key: "webhookId", Value: 1}, {Key: "createdAt", Value: -1}}, false},
+			{"status_nextAttemptAt", bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}}, false}},
+	}
+}
+
+// createIndexes creates indexes, doing nothing for those that exist. A unique
+// index fails to build while duplicates exist, which the error points out.
+func createIndexes(ctx context.Context, collection *mongo.Collection, indexes []indexSpec) error {
+	indexModels := make([]mongo.IndexModel, len(indexes))
+	for i, index := range indexes {
+		opts := options.Index().SetName(index.name)
+		if index.unique {
+			opts.SetUnique(true)
+		}
+		indexModels[i] = mongo.IndexModel{Keys: index.keys, Options: opts}
+	}
+
+	if _, err := collection.Indexes().CreateMany(ctx, indexModels); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("%s holds duplicates of a unique field; remove them and migrate again: %w", collection.Name(), err)
+		}
+		return err
+	}
+	return nil
+}
+
+// dropIndexes drops indexes by name, ignoring those already gone
+func dropIndexes(ctx context.Context, collection *mongo.Collection, indexes []indexSpec) error {
+	for _, index := range indexes {
+		if _, err := collection.Indexes().DropOne(ctx, index.name); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// isNotFound reports whether err is MongoDB's IndexNotFound or NamespaceNotFound
+func isNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && (cmdErr.Code == 27 || cmdErr.Code == 26)
+}
+
+// backfill sets fields on the documents matching filter, computing them with
+// aggregation expressions over each document
+func backfill(ctx context.Context, collection *mongo.Collection, filter bson.M, fields bson.M) error {
+	_, err := collection.UpdateMany(ctx, filter, mongo.Pipeline{{{Key: "$set", Value: fields}}})
+	return err
+}
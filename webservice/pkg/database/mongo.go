@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"errors"
+	"regexp"
 	"time"
 
 	"player-character/internal/models"
@@ -45,6 +46,11 @@ func NewMongoStore(connectionString, databaseName, collectionName string) (*Mong
 	}, nil
 }
 
+// Database returns the underlying MongoDB database so sibling stores can share the connection
+func (s *MongoStore) Database() *mongo.Database {
+	return s.database
+}
+
 // Disconnect closes the MongoDB connection
 func (s *MongoStore) Disconnect(ctx context.Context) error {
 	return s.client.Disconnect(ctx)
@@ -55,6 +61,11 @@ func (s *MongoStore) Create(character *models.Character) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	return s.create(ctx, character)
+}
+
+// create stores a new character within ctx, which may carry a transaction
+func (s *MongoStore) create(ctx context.Context, character *models.Character) error {
 	// Generate ID if not provided
 	if character.ID == "" {
 		character.ID = uuid.New().String()
@@ -65,8 +76,14 @@ func (s *MongoStore) Create(character *models.Character) error {
 	character.CreatedAt = now
 	character.UpdatedAt = now
 
-	_, err := s.collection.InsertOne(ctx, character)
-	return err
+	// Insert only if the ID is free, as one upsert
+	if err := insertIfAbsent(ctx, s.collection, bson.M{"id": character.ID}, character); err != nil {
+		if errors.Is(err, ErrExists) {
+			return errors.New("character with this ID already exists")
+		}
+		return err
+	}
+	return nil
 }
 
 // Get retrieves a character by ID
@@ -89,58 +106,53 @@ func (s *MongoStore) Get(id string) (*models.Character, error) {
 }
 
 // List retrieves characters with pagination, sorting, and search
-func (s *MongoStore) List(page, limit int, sortBy, sortOrder, search string) ([]models.Character, int, error) {
+func (s *MongoStore) List(opts ListOptions) ([]models.Character, int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Build filter for search
-	filter := bson.M{}
-	if search != "" {
-		// Case-insensitive regex search on characterName, race, and class
-		regexPattern := bson.M{"$regex": search, "$options": "i"}
-		filter = bson.M{
-			"$or": []bson.M{
-				{"characterName": regexPattern},
-				{"race": regexPattern},
-				{"class": regexPattern},
-			},
-		}
-	}
+	page, limit, sortBy, sortOrder := opts.Page, opts.Limit, opts.SortBy, opts.SortOrder
 
-	// Get total count with filter
-	total, err := s.collection.CountDocuments(ctx, filter)
+	filter, err := s.listFilter(ctx, opts)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	// Calculate skip
-	skip := (page - 1) * limit
+	// Sort by the requested field, breaking ties by ID so that cursors have a stable order
+	sortField, direction := sortSpec(sortBy, sortOrder)
+	sortDoc := bson.D{{Key: sortField, Value: direction}, {Key: "id", Value: direction}}
 
-	// Build sort document
-	sortDoc := bson.M{}
-	switch sortBy {
-	case "characterName":
-		sortDoc["characterName"] = getSortValue(sortOrder)
-	case "level":
-		sortDoc["level"] = getSortValue(sortOrder)
-	case "race":
-		sortDoc["race"] = getSortValue(sortOrder)
-	case "class":
-		sortDoc["class"] = getSortValue(sortOrder)
-	case "createdAt":
-		sortDoc["createdAt"] = getSortValue(sortOrder)
-	default:
-		// Default sort by createdAt descending
-		sortDoc["createdAt"] = -1
+	// Get total count with filter, unless the caller doesn't need it
+	total := int64(-1)
+	if !opts.SkipCount {
+		count, err := s.collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		total = count
 	}
 
-	// Find documents with pagination and sorting
-	opts := options.Find().
-		SetSkip(int64(skip)).
-		SetLimit(int64(limit)).
+	findOpts := options.Find().
+		SetLimit(int64(opts.fetch())).
 		SetSort(sortDoc)
+	if len(opts.Fields) > 0 {
+		findOpts.SetProjection(projectionDoc(opts.Fields))
+	}
+
+	// Continue after the cursor with a keyset condition, or skip to the page
+	if opts.After != nil {
+		op := "$gt"
+		if direction < 0 {
+			op = "$lt"
+		}
+		filter = bson.M{"$and": []bson.M{filter, {"$or": []bson.M{
+			{sortField: bson.M{op: opts.After.Value}},
+			{sortField: opts.After.Value, "id": bson.M{op: opts.After.ID}},
+		}}}}
+	} else {
+		findOpts.SetSkip(int64((page - 1) * limit))
+	}
 
-	cursor, err := s.collection.Find(ctx, filter, opts)
+	cursor, err := s.collection.Find(ctx, filter, findOpts)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -159,6 +171,94 @@ func (s *MongoStore) List(page, limit int, sortBy, sortOrder, search string) ([]
 	return characters, int(total), nil
 }
 
+// listFilter builds the query matching a listing's search, scope, filter and visibility
+func (s *MongoStore) listFilter(ctx context.Context, opts ListOptions) (bson.M, error) {
+	// Build filter for search
+	filter := bson.M{}
+	if opts.Search != "" {
+		// Case-insensitive substring search on characterName, race, and class
+		regexPattern := bson.M{"$regex": regexp.QuoteMeta(opts.Search), "$options": "i"}
+		filter["$or"] = []bson.M{
+			{"characterName": regexPattern},
+			{"race": regexPattern},
+			{"class": regexPattern},
+		}
+	}
+
+	// Restrict to a campaign or party when scoped
+	campaignFilter := bson.M{}
+	if opts.CampaignID != "" {
+		campaignFilter["$eq"] = opts.CampaignID
+	}
+	if len(opts.CampaignIDs) > 0 {
+		campaignFilter["$in"] = opts.CampaignIDs
+	}
+	if len(campaignFilter) > 0 {
+		filter["campaignId"] = campaignFilter
+	}
+	if opts.PartyID != "" {
+		filter["partyId"] = opts.PartyID
+	}
+	if opts.OwnerID != "" {
+		filter["ownerId"] = opts.OwnerID
+	}
+
+	// Apply the structured filter alongside the search, which may also use $or
+	if opts.Filter != nil {
+		filter = bson.M{"$and": []bson.M{filter, filterToBSON(opts.Filter)}}
+	}
+
+	// Restrict to characters the caller may read
+	if opts.Visibility != nil {
+		visibilityFilter, err := s.visibilityFilter(ctx, opts.Visibility)
+		if err != nil {
+			return nil, err
+		}
+		filter = bson.M{"$and": []bson.M{filter, visibilityFilter}}
+	}
+
+	return filter, nil
+}
+
+// visibilityFilter builds the filter matching characters the user may read
+func (s *MongoStore) visibilityFilter(ctx context.Context, visibility *Visibility) (bson.M, error) {
+	ownedParties, err := s.collection.Distinct(ctx, "partyId", bson.M{
+		"ownerId": visibility.UserID,
+		"partyId": bson.M{"$nin": []interface{}{nil, ""}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clauses := []bson.M{{"ownerId": visibility.UserID}}
+	if len(ownedParties) > 0 {
+		clauses = append(clauses, bson.M{"partyId": bson.M{"$in": ownedParties}})
+	}
+	if len(visibility.CampaignIDs) > 0 {
+		clauses = append(clauses, bson.M{"campaignId": bson.M{"$in": visibility.CampaignIDs}})
+	}
+
+	if len(visibility.WithinCampaigns) > 0 {
+		return bson.M{"$and": []bson.M{
+			{"campaignId": bson.M{"$in": visibility.WithinCampaigns}},
+			{"$or": clauses},
+		}}, nil
+	}
+
+	return bson.M{"$or": clauses}, nil
+}
+
+// sortSpec returns the document field and direction to sort by
+func sortSpec(sortBy, sortOrder string) (string, int) {
+	switch sortBy {
+	case "characterName", "level", "race", "class", "createdAt":
+		return sortBy, getSortValue(sortOrder)
+	default:
+		// Default sort by createdAt descending
+		return "createdAt", -1
+	}
+}
+
 // getSortValue converts sort order string to MongoDB sort value
 func getSortValue(sortOrder string) int {
 	if sortOrder == "desc" {
@@ -167,28 +267,71 @@ func getSortValue(sortOrder string) int {
 	return 1
 }
 
+// All retrieves every character, oldest first
+func (s *MongoStore) All() ([]models.Character, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	characters := []models.Character{}
+	if err := findAll(ctx, s.collection, &characters); err != nil {
+		return nil, err
+	}
+
+	return characters, nil
+}
+
+// Restore stores a character as it is, keeping its ID and timestamps
+func (s *MongoStore) Restore(character *models.Character) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return insertIfAbsent(ctx, s.collection, bson.M{"id": character.ID}, character)
+}
+
 // Update modifies an existing character
 func (s *MongoStore) Update(id string, character *models.Character) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	return s.update(ctx, id, character)
+}
+
+// update modifies an existing character within ctx, which may carry a transaction
+func (s *MongoStore) update(ctx context.Context, id string, character *models.Character) error {
 	filter := bson.M{"id": id}
 
 	// Preserve original ID and creation time, update timestamp
 	character.ID = id
 	character.UpdatedAt = time.Now()
 
-	update := bson.M{"$set": character}
-
-	result, err := s.collection.UpdateOne(ctx, filter, update)
+	doc, err := bson.Marshal(character)
 	if err != nil {
 		return err
 	}
 
-	if result.MatchedCount == 0 {
-		return errors.New("character not found")
+	// Replace the document, so fields the update leaves out are cleared, but
+	// carry over its _id and creation time. $literal keeps values that start
+	// with $ from being read as field paths.
+	update := mongo.Pipeline{{{Key: "$replaceWith", Value: bson.M{"$mergeObjects": bson.A{
+		bson.M{"$literal": bson.Raw(doc)},
+		bson.M{"_id": "$_id", "createdAt": "$createdAt"},
+	}}}}}
+	opts := options.FindOneAndUpdate().
+		SetProjection(bson.M{"createdAt": 1}).
+		SetReturnDocument(options.After)
+
+	var stored struct {
+		CreatedAt time.Time `bson:"createdAt"`
+	}
+	err = s.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&stored)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return errors.New("character not found")
+		}
+		return err
 	}
 
+	character.CreatedAt = stored.CreatedAt
 	return nil
 }
 
@@ -197,6 +340,11 @@ func (s *MongoStore) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	return s.delete(ctx, id)
+}
+
+// delete removes a character within ctx, which may carry a transaction
+func (s *MongoStore) delete(ctx context.Context, id string) error {
 	filter := bson.M{"id": id}
 
 	result, err := s.collection.DeleteOne(ctx, filter)
@@ -0,0 +1,498 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postgresMigrations holds the schema, one numbered SQL file per version
+//
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+// postgresMigrationLock is the advisory lock held while migrating, so that
+// replicas starting together apply each migration once
+const postgresMigrationLock = 7_346_225_001
+
+// postgresConn runs statements on the pool or within a transaction
+type postgresConn interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// PostgresStore implements PostgreSQL-based character storage. Each character
+// is a JSONB document, with the fields used for sorting and scoping copied
+// into indexed columns; listing, filters and search behave as for MongoStore.
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresStore connects to PostgreSQL and migrates the schema to the latest version
+func NewPostgresStore(dsn string) (*PostgresStore, error) {
+	config, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return newPostgresStore(config)
+}
+
+func newPostgresStore(config *pgxpool.Config) (*PostgresStore, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	if err := migratePostgres(ctx, pool); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("migrating schema: %w", err)
+	}
+
+	return &PostgresStore{pool: pool}, nil
+}
+
+// Close closes the connection pool
+func (s *PostgresStore) Close() {
+	s.pool.Close()
+}
+
+// migratePostgres applies the migrations not yet recorded in schema_migrations,
+// each in its own transaction
+func migratePostgres(ctx context.Context, pool *pgxpool.Pool) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", postgresMigrationLock); err != nil {
+		return err
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", postgresMigrationLock)
+
+	if _, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    integer PRIMARY KEY,
+		name       text NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`); err != nil {
+		return err
+	}
+
+	rows, err := conn.Query(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return err
+	}
+	applied, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return err
+	}
+	done := map[int]bool{}
+	for _, version := range applied {
+		done[version] = true
+	}
+
+	names, err := fs.Glob(postgresMigrations, "migrations/postgres/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+	for _, path := range names {
+		name := strings.TrimSuffix(path[strings.LastIndexByte(path, '/')+1:], ".sql")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return fmt.Errorf("migration %s is not numbered", name)
+		}
+		if done[version] {
+			continue
+		}
+
+		script, err := postgresMigrations.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		err = pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			// Without arguments the script runs as a simple query, which may hold several statements
+			if _, err := tx.Exec(ctx, string(script)); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", version, name)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// characterRow is a character as stored: its document and the columns derived from it
+type characterRow struct {
+	character models.Character
+	doc       []byte
+	terms     []byte
+	phrases   string
+}
+
+// newCharacterRow encodes a character for storage. Timestamps are stored to
+// the millisecond, like MongoDB, so that sort values read back in documents
+// and cursors compare equal to the columns.
+func newCharacterRow(character *models.Character) (*characterRow, error) {
+	row := &characterRow{character: *character}
+	row.character.CreatedAt = character.CreatedAt.Truncate(time.Millisecond)
+	row.character.UpdatedAt = character.UpdatedAt.Truncate(time.Millisecond)
+
+	var err error
+	if row.doc, err = json.Marshal(&row.character); err != nil {
+		return nil, err
+	}
+	if row.terms, err = json.Marshal(termWeights(&row.character)); err != nil {
+		return nil, err
+	}
+	row.phrases = phraseVector(&row.character)
+	return row, nil
+}
+
+// characterColumns lists the columns written for each character, in the order of characterRow.args
+const characterColumns = "id, doc, character_name, race, class, level, created_at, owner_id, campaign_id, party_id, terms, phrases"
+
+// args returns the values of characterColumns
+func (r *characterRow) args() []any {
+	c := &r.character
+	return []any{c.ID, r.doc, c.CharacterName, c.Race, c.Class, c.Level, c.CreatedAt,
+		c.OwnerID, c.CampaignID, c.PartyID, r.terms, r.phrases}
+}
+
+// insertCharacterSQL stores a row; the phrases are sent as text and cast
+const insertCharacterSQL = "INSERT INTO characters (" + characterColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12::text::tsvector)"
+
+// Create stores a new character
+func (s *PostgresStore) Create(character *models.Character) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.create(ctx, s.pool, character)
+}
+
+// create stores a new character on conn, which may be a transaction
+func (s *PostgresStore) create(ctx context.Context, conn postgresConn, character *models.Character) error {
+	// Generate ID if not provided
+	if character.ID == "" {
+		character.ID = uuid.New().String()
+	}
+
+	// Set timestamps to the precision stored
+	now := time.Now().Truncate(time.Millisecond)
+	character.CreatedAt = now
+	character.UpdatedAt = now
+
+	row, err := newCharacterRow(character)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Exec(ctx, insertCharacterSQL, row.args()...); err != nil {
+		if isUniqueViolation(err) {
+			return errors.New("character with this ID already exists")
+		}
+		return err
+	}
+	return nil
+}
+
+// isUniqueViolation reports whether err is PostgreSQL's unique_violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// Get retrieves a character by ID
+func (s *PostgresStore) Get(id string) (*models.Character, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var doc []byte
+	err := s.pool.QueryRow(ctx, "SELECT doc FROM characters WHERE id = $1", id).Scan(&doc)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("character not found")
+		}
+		return nil, err
+	}
+
+	var character models.Character
+	if err := json.Unmarshal(doc, &character); err != nil {
+		return nil, err
+	}
+	return &character, nil
+}
+
+// postgresQuery collects the arguments of a statement as its SQL is built
+type postgresQuery struct {
+	args []any
+}
+
+// arg adds an argument and returns its placeholder
+func (q *postgresQuery) arg(value any) string {
+	q.args = append(q.args, value)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+// sortColumns maps the fields sortSpec allows to their columns and types
+var sortColumns = map[string]struct{ name, typ string }{
+	"characterName": {"character_name", "text"},
+	"level":         {"level", "integer"},
+	"race":          {"race", "text"},
+	"class":         {"class", "text"},
+	"createdAt":     {"created_at", "timestamptz"},
+}
+
+// List retrieves characters with pagination, sorting, and search
+func (s *PostgresStore) List(opts ListOptions) ([]models.Character, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	q := &postgresQuery{}
+	where := listWhere(q, opts)
+
+	// Get total count with filter, unless the caller doesn't need it
+	total := -1
+	if !opts.SkipCount {
+		if err := s.pool.QueryRow(ctx, "SELECT count(*) FROM characters WHERE "+where, q.args...).Scan(&total); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// Sort by the requested field, breaking ties by ID so that cursors have a stable order
+	field, direction := sortSpec(opts.SortBy, opts.SortOrder)
+	column := sortColumns[field]
+	order, op := "ASC", ">"
+	if direction < 0 {
+		order, op = "DESC", "<"
+	}
+
+	// Continue after the cursor with a keyset condition, or skip to the page
+	offset := 0
+	if opts.After != nil {
+		where = fmt.Sprintf("%s AND (%s, id) %s (%s::%s, %s)", where, column.name, op,
+			q.arg(opts.After.Value), column.typ, q.arg(opts.After.ID))
+	} else {
+		offset = (opts.Page - 1) * opts.Limit
+	}
+
+	sql := fmt.Sprintf("SELECT doc FROM characters WHERE %s ORDER BY %s %s, id %s LIMIT %s OFFSET %s",
+		where, column.name, order, order, q.arg(opts.fetch()), q.arg(offset))
+	rows, err := s.pool.Query(ctx, sql, q.args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	characters, err := collectCharacters(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(opts.Fields) > 0 {
+		for i := range characters {
+			projectCharacter(&characters[i], opts.Fields)
+		}
+	}
+	return characters, total, nil
+}
+
+// collectCharacters decodes the documents of rows selecting doc
+func collectCharacters(rows pgx.Rows) ([]models.Character, error) {
+	characters := []models.Character{}
+	var doc []byte
+	_, err := pgx.ForEachRow(rows, []any{&doc}, func() error {
+		var character models.Character
+		if err := json.Unmarshal(doc, &character); err != nil {
+			return err
+		}
+		characters = append(characters, character)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return characters, nil
+}
+
+// listWhere builds the condition matching a listing's search, scope, filter and visibility
+func listWhere(q *postgresQuery, opts ListOptions) string {
+	conditions := []string{"TRUE"}
+	if opts.Search != "" {
+		// Case-insensitive substring search on characterName, race, and class
+		search := q.arg(opts.Search)
+		conditions = append(conditions, fmt.Sprintf(
+			"(strpos(lower(character_name), lower(%[1]s::text)) > 0 OR strpos(lower(race), lower(%[1]s::text)) > 0 OR strpos(lower(class), lower(%[1]s::text)) > 0)",
+			search))
+	}
+
+	// Restrict to a campaign or party when scoped
+	if opts.CampaignID != "" {
+		conditions = append(conditions, "campaign_id = "+q.arg(opts.CampaignID))
+	}
+	if len(opts.CampaignIDs) > 0 {
+		conditions = append(conditions, "campaign_id = ANY("+q.arg(opts.CampaignIDs)+")")
+	}
+	if opts.PartyID != "" {
+		conditions = append(conditions, "party_id = "+q.arg(opts.PartyID))
+	}
+	if opts.OwnerID != "" {
+		conditions = append(conditions, "owner_id = "+q.arg(opts.OwnerID))
+	}
+
+	if opts.Filter != nil {
+		conditions = append(conditions, filterToSQL(q, opts.Filter))
+	}
+
+	// Restrict to characters the caller may read
+	if v := opts.Visibility; v != nil {
+		user := q.arg(v.UserID)
+		clauses := []string{
+			"owner_id = " + user,
+			fmt.Sprintf("(party_id <> '' AND party_id IN (SELECT party_id FROM characters WHERE owner_id = %s AND party_id <> ''))", user),
+		}
+		if len(v.CampaignIDs) > 0 {
+			clauses = append(clauses, "campaign_id = ANY("+q.arg(v.CampaignIDs)+")")
+		}
+		conditions = append(conditions, "("+strings.Join(clauses, " OR ")+")")
+		if len(v.WithinCampaigns) > 0 {
+			conditions = append(conditions, "campaign_id = ANY("+q.arg(v.WithinCampaigns)+")")
+		}
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+// All retrieves every character, oldest first
+func (s *PostgresStore) All() ([]models.Character, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, "SELECT doc FROM characters ORDER BY created_at, id")
+	if err != nil {
+		return nil, err
+	}
+	return collectCharacters(rows)
+}
+
+// Restore stores a character as it is, keeping its ID and timestamps
+func (s *PostgresStore) Restore(character *models.Character) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	row, err := newCharacterRow(character)
+	if err != nil {
+		return err
+	}
+	tag, err := s.pool.Exec(ctx, insertCharacterSQL+" ON CONFLICT (id) DO NOTHING", row.args()...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrExists
+	}
+	return nil
+}
+
+// Update modifies an existing character
+func (s *PostgresStore) Update(id string, character *models.Character) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.update(ctx, s.pool, id, character)
+}
+
+// update modifies an existing character on conn, which may be a transaction
+func (s *PostgresStore) update(ctx context.Context, conn postgresConn, id string, character *models.Character) error {
+	// Preserve original ID, update timestamp; the creation time is kept by the statement
+	character.ID = id
+	character.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	row, err := newCharacterRow(character)
+	if err != nil {
+		return err
+	}
+	c := &row.character
+	err = conn.QueryRow(ctx, `UPDATE characters SET
+		doc = $2::jsonb || jsonb_build_object('createdAt', doc->'createdAt'),
+		character_name = $3, race = $4, class = $5, level = $6,
+		owner_id = $7, campaign_id = $8, party_id = $9, terms = $10, phrases = $11::text::tsvector
+		WHERE id = $1
+		RETURNING created_at`,
+		id, row.doc, c.CharacterName, c.Race, c.Class, c.Level, c.OwnerID, c.CampaignID, c.PartyID, row.terms, row.phrases).Scan(&character.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("character not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete removes a character
+func (s *PostgresStore) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.delete(ctx, s.pool, id)
+}
+
+// delete removes a character on conn, which may be a transaction
+func (s *PostgresStore) delete(ctx context.Context, conn postgresConn, id string) error {
+	tag, err := conn.Exec(ctx, "DELETE FROM characters WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("character not found")
+	}
+	return nil
+}
+
+// Bulk applies a batch of writes in a transaction. When a write fails the
+// transaction is rolled back and a *BulkError is returned.
+func (s *PostgresStore) Bulk(writes []BulkWrite) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		for i, w := range writes {
+			var err error
+			switch w.Op {
+			case BulkCreate:
+				err = s.create(ctx, tx, w.Character)
+			case BulkUpdate:
+				err = s.update(ctx, tx, w.ID, w.Character)
+			case BulkDelete:
+				err = s.delete(ctx, tx, w.ID)
+			default:
+				err = fmt.Errorf("unknown bulk operation %q", w.Op)
+			}
+			if err != nil {
+				return &BulkError{Index: i, Err: err}
+			}
+		}
+		return nil
+	})
+}
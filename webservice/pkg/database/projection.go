@@ -0,0 +1,83 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"player-character/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// characterField maps a character field's JSON name to its struct index and BSON name
+type characterField struct {
+	index int
+	bson  string
+}
+
+// characterFields lists every top-level character field by JSON name
+var characterFields = func() map[string]characterField {
+	fields := map[string]characterField{}
+	t := reflect.TypeOf(models.Character{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := tagName(field.Tag.Get("json"))
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = characterField{index: i, bson: tagName(field.Tag.Get("bson"))}
+	}
+	return fields
+}()
+
+func tagName(tag string) string {
+	name, _, _ := strings.Cut(tag, ",")
+	return name
+}
+
+// ParseFields parses a comma-separated list of character fields, as used for
+// sparse fieldsets. An empty list returns nil, meaning every field.
+func ParseFields(list string) ([]string, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := characterFields[name]; !ok {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			fields = append(fields, name)
+		}
+	}
+	return fields, nil
+}
+
+// projectCharacter clears every field of the character not listed. Unknown names are ignored.
+func projectCharacter(character *models.Character, fields []string) {
+	projected := models.Character{}
+	src := reflect.ValueOf(character).Elem()
+	dst := reflect.ValueOf(&projected).Elem()
+	for _, name := range fields {
+		if field, ok := characterFields[name]; ok {
+			dst.Field(field.index).Set(src.Field(field.index))
+		}
+	}
+	*character = projected
+}
+
+// projectionDoc builds the MongoDB projection returning only the listed fields
+func projectionDoc(fields []string) bson.M {
+	projection := bson.M{"_id": 0}
+	for _, name := range fields {
+		if field, ok := characterFields[name]; ok {
+			projection[field.bson] = 1
+		}
+	}
+	return projection
+}
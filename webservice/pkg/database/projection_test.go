@@ -0,0 +1,69 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"player-character/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields(" characterName, level,characterName ")
+	if err != nil {
+		t.Fatalf("ParseFields: %v", err)
+	}
+	if want := []string{"characterName", "level"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("Expected %v, got %v", want, fields)
+	}
+
+	if fields, err := ParseFields(""); err != nil || fields != nil {
+		t.Errorf("Expected no fields for an empty list, got %v, %v", fields, err)
+	}
+	for _, list := range []string{"level,", "hitPoints", "abilityScores.strength"} {
+		if _, err := ParseFields(list); err == nil {
+			t.Errorf("Expected an error for %q", list)
+		}
+	}
+}
+
+func TestProjectionDoc(t *testing.T) {
+	got := projectionDoc([]string{"id", "characterName", "abilityScores"})
+	want := bson.M{"_id": 0, "id": 1, "characterName": 1, "abilityScores": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestMemoryStoreListFields(t *testing.T) {
+	store := NewMemoryStore()
+	character := models.Character{
+		CharacterName: "Lyra",
+		Race:          "Elf",
+		Class:         "Wizard",
+		Level:         4,
+		Features:      []string{"Darkvision"},
+	}
+	if err := store.Create(&character); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	characters, _, err := store.List(ListOptions{Page: 1, Limit: 10, Fields: []string{"id", "level"}})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	want := models.Character{ID: character.ID, Level: 4}
+	if len(characters) != 1 || !reflect.DeepEqual(characters[0], want) {
+		t.Errorf("Expected %+v, got %+v", want, characters)
+	}
+
+	// Projection must not touch the stored character
+	stored, err := store.Get(character.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if stored.CharacterName != "Lyra" || len(stored.Features) != 1 {
+		t.Errorf("Stored character was modified: %+v", stored)
+	}
+}
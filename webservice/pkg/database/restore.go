@@ -0,0 +1,7 @@
+package database
+
+import "errors"
+
+// ErrExists is returned by a store's Restore when it already holds a record
+// with the same ID, or for users, the same username
+var ErrExists = errors.New("record already exists")
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// findAll decodes every document in a collection into records, oldest first
+func findAll(ctx context.Context, collection *mongo.Collection, records interface{}) error {
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	return cursor.All(ctx, records)
+}
+
+// insertIfAbsent inserts doc unless a document matches filter, in which case
+// it returns ErrExists. The check and the insert are a single upsert, so
+// concurrent restores cannot both insert the same record; when they race,
+// the unique index makes the loser's upsert fail with a duplicate key.
+func insertIfAbsent(ctx context.Context, collection *mongo.Collection, filter bson.M, doc interface{}) error {
+	result, err := collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": doc}, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrExists
+	}
+	if err != nil {
+		return err
+	}
+	if result.UpsertedCount == 0 {
+		return ErrExists
+	}
+	return nil
+}
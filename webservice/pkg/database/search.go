@@ -0,0 +1,240 @@
+package database
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"player-character/internal/models"
+)
+
+// textField is a character field covered by full-text search. Hidden fields
+// (secret backstory and DM notes) are deliberately not searchable.
+type textField struct {
+	name   string
+	weight int
+	values func(c *models.Character) []string
+}
+
+// textFields lists the searchable fields with their relevance weights
+var textFields = []textField{
+	{"characterName", 10, func(c *models.Character) []string { return []string{c.CharacterName} }},
+	{"playerName", 5, func(c *models.Character) []string { return []string{c.PlayerName} }},
+	{"features", 3, func(c *models.Character) []string { return c.Features }},
+	{"personality", 2, func(c *models.Character) []string { return []string{c.Personality} }},
+	{"backstory", 1, func(c *models.Character) []string { return []string{c.Backstory} }},
+	{"notes", 1, func(c *models.Character) []string { return []string{c.Notes} }},
+}
+
+// token is a lower-cased word and its byte offsets in the original text
+type token struct {
+	text       string
+	start, end int
+}
+
+// tokenize splits text into lower-cased words at anything that is not a letter
+// or digit, like a MongoDB text index with no language
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if word && start < 0 {
+			start = i
+		} else if !word && start >= 0 {
+			tokens = append(tokens, token{strings.ToLower(text[start:i]), start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{strings.ToLower(text[start:]), start, len(text)})
+	}
+	return tokens
+}
+
+// words returns just the words of text
+func words(text string) []string {
+	tokens := tokenize(text)
+	result := make([]string, len(tokens))
+	for i, t := range tokens {
+		result[i] = t.text
+	}
+	return result
+}
+
+// textQuery is a parsed search string, following MongoDB $text syntax: words
+// match any, "quoted phrases" must all appear, and -words must not appear
+type textQuery struct {
+	terms    []string // every positive word, including those in phrases
+	phrases  [][]string
+	excluded []string
+}
+
+func parseTextQuery(query string) textQuery {
+	var q textQuery
+	seen := map[string]bool{}
+	addTerms := func(ws []string) {
+		for _, w := range ws {
+			if !seen[w] {
+				seen[w] = true
+				q.terms = append(q.terms, w)
+			}
+		}
+	}
+
+	for query != "" {
+		query = strings.TrimLeftFunc(query, unicode.IsSpace)
+		switch {
+		case query == "":
+		case query[0] == '"':
+			end := strings.IndexByte(query[1:], '"')
+			if end < 0 {
+				end = len(query) - 1
+			}
+			if phrase := words(query[1 : end+1]); len(phrase) > 0 {
+				q.phrases = append(q.phrases, phrase)
+				addTerms(phrase)
+			}
+			query = query[min(end+2, len(query)):]
+		default:
+			end := strings.IndexFunc(query, unicode.IsSpace)
+			if end < 0 {
+				end = len(query)
+			}
+			word := query[:end]
+			if strings.HasPrefix(word, "-") {
+				q.excluded = append(q.excluded, words(word[1:])...)
+			} else {
+				addTerms(words(word))
+			}
+			query = query[end:]
+		}
+	}
+	return q
+}
+
+// empty reports whether the query has nothing to search for
+func (q textQuery) empty() bool {
+	return len(q.terms) == 0
+}
+
+// matchesPhrases reports whether every phrase appears within a single value of some field
+func (q textQuery) matchesPhrases(c *models.Character) bool {
+	for _, phrase := range q.phrases {
+		found := false
+		for _, field := range textFields {
+			for _, value := range field.values(c) {
+				if containsPhrase(words(value), phrase) {
+					found = true
+					break
+				}
+			}
+			if found {
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func containsPhrase(ws, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(ws); i++ {
+		match := true
+		for j := range phrase {
+			if ws[i+j] != phrase[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	snippetBefore = 60  // bytes of context before the first match
+	snippetLength = 200 // bytes of text in a snippet
+)
+
+// highlights returns a snippet for each field that contains a query term,
+// with the terms wrapped in <mark> tags
+func highlights(c *models.Character, q textQuery) []models.Highlight {
+	terms := map[string]bool{}
+	for _, t := range q.terms {
+		terms[t] = true
+	}
+
+	result := []models.Highlight{}
+	for _, field := range textFields {
+		for _, value := range field.values(c) {
+			if snippet, ok := snippet(value, terms); ok {
+				result = append(result, models.Highlight{Field: field.name, Snippet: snippet})
+				break
+			}
+		}
+	}
+	return result
+}
+
+// snippet excerpts text around its first matching term
+func snippet(text string, terms map[string]bool) (string, bool) {
+	tokens := tokenize(text)
+	first := -1
+	for i, t := range tokens {
+		if terms[t.text] {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	// Start at a word boundary shortly before the match, and end at one after the window
+	start := 0
+	if tokens[first].start > snippetBefore {
+		for i := first; i >= 0 && tokens[first].start-tokens[i].start <= snippetBefore; i-- {
+			start = tokens[i].start
+		}
+	}
+	end := len(text)
+	if end-start > snippetLength {
+		end = start + snippetLength
+		for end > start && !utf8.RuneStart(text[end]) {
+			end--
+		}
+		for _, t := range tokens {
+			if t.start < end && t.end > end {
+				end = t.start
+				break
+			}
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, t := range tokens {
+		if t.start < start || t.end > end || !terms[t.text] {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:t.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[t.start:t.end]))
+		b.WriteString("</mark>")
+		pos = t.end
+	}
+	b.WriteString(html.EscapeString(strings.TrimRightFunc(text[pos:end], unicode.IsSpace)))
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"player-character/internal/models"
+)
+
+// textIndex is an inverted index from words to the characters containing them
+type textIndex struct {
+	postings map[string]map[string]float64 // word -> character ID -> weighted occurrences
+	words    map[string][]string           // character ID -> indexed words, for removal
+}
+
+func newTextIndex() *textIndex {
+	return &textIndex{
+		postings: make(map[string]map[string]float64),
+		words:    make(map[string][]string),
+	}
+}
+
+// add indexes a character's searchable fields, replacing any earlier entry
+func (x *textIndex) add(c *models.Character) {
+	x.remove(c.ID)
+
+	counts := map[string]float64{}
+	for _, field := range textFields {
+		for _, value := range field.values(c) {
+			for _, word := range words(value) {
+				counts[word] += float64(field.weight)
+			}
+		}
+	}
+
+	for word, weight := range counts {
+		postings, ok := x.postings[word]
+		if !ok {
+			postings = make(map[string]float64)
+			x.postings[word] = postings
+		}
+		postings[c.ID] = weight
+		x.words[c.ID] = append(x.words[c.ID], word)
+	}
+}
+
+// remove drops a character from the index
+func (x *textIndex) remove(id string) {
+	for _, word := range x.words[id] {
+		delete(x.postings[word], id)
+		if len(x.postings[word]) == 0 {
+			delete(x.postings, word)
+		}
+	}
+	delete(x.words, id)
+}
+
+// Search runs a full-text query over the searchable fields, most relevant first.
+// Scope, visibility and filter options apply as for List; sorting and cursors do not.
+func (s *MemoryStore) Search(query string, opts ListOptions) ([]models.SearchHit, int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	q := parseTextQuery(query)
+
+	// Score every character containing a query word; rarer words count for more
+	scores := map[string]float64{}
+	for _, word := range q.terms {
+		postings := s.index.postings[word]
+		if len(postings) == 0 {
+			continue
+		}
+		idf := 1 + math.Log(float64(len(s.characters))/float64(len(postings)))
+		for id, weight := range postings {
+			scores[id] += weight * idf
+		}
+	}
+
+	ownedParties := s.ownedParties(opts)
+	hits := []models.SearchHit{}
+	for id, score := range scores {
+		char := s.characters[id]
+		if s.excludes(q, id) || !q.matchesPhrases(&char) || !s.inScope(&char, opts, ownedParties) {
+			continue
+		}
+		hits = append(hits, models.SearchHit{Character: char, Score: score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return strings.Compare(hits[i].Character.ID, hits[j].Character.ID) < 0
+	})
+
+	total := len(hits)
+	if opts.SkipCount {
+		total = -1
+	}
+
+	start := (opts.Page - 1) * opts.Limit
+	if start >= len(hits) {
+		return []models.SearchHit{}, total, nil
+	}
+	end := min(start+opts.fetch(), len(hits))
+	hits = hits[start:end]
+
+	for i := range hits {
+		hits[i].Highlights = highlights(&hits[i].Character, q)
+	}
+	return hits, total, nil
+}
+
+// excludes reports whether the character contains a word the query excludes
+func (s *MemoryStore) excludes(q textQuery, id string) bool {
+	for _, word := range q.excluded {
+		if _, ok := s.index.postings[word][id]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"player-character/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// textIndexName names the text index so it can be found and replaced
+const textIndexName = "character_text"
+
+// createTextIndex creates the text index over the searchable fields. With no
+// language, words are neither stemmed nor dropped as stop words, which keeps
+// matching the same as MemoryStore's index.
+func createTextIndex(ctx context.Context, collection *mongo.Collection) error {
+	keys := bson.D{}
+	weights := bson.D{}
+	for _, field := range textFields {
+		keys = append(keys, bson.E{Key: field.name, Value: "text"})
+		weights = append(weights, bson.E{Key: field.name, Value: field.weight})
+	}
+
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: keys,
+		Options: options.Index().
+			SetName(textIndexName).
+			SetWeights(weights).
+			SetDefaultLanguage("none"),
+	})
+	return err
+}
+
+// Search runs a full-text query over the searchable fields, most relevant first.
+// Scope, visibility and filter options apply as for List; sorting and cursors do not.
+func (s *MongoStore) Search(query string, opts ListOptions) ([]models.SearchHit, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter, err := s.listFilter(ctx, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	// $search takes the user's query as text, not a pattern, so it needs no escaping
+	filter["$text"] = bson.M{"$search": query}
+
+	total := int64(-1)
+	if !opts.SkipCount {
+		if total, err = s.collection.CountDocuments(ctx, filter); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	score := bson.M{"$meta": "textScore"}
+	findOpts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "id", Value: 1}}).
+		SetSkip(int64((opts.Page - 1) * opts.Limit)).
+		SetLimit(int64(opts.fetch()))
+
+	cursor, err := s.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		models.Character `bson:",inline"`
+		Score            float64 `bson:"score"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, 0, err
+	}
+
+	q := parseTextQuery(query)
+	hits := make([]models.SearchHit, len(docs))
+	for i, doc := range docs {
+		hits[i] = models.SearchHit{
+			Character:  doc.Character,
+			Score:      doc.Score,
+			Highlights: highlights(&doc.Character, q),
+		}
+	}
+	return hits, int(total), nil
+}
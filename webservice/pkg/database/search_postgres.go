@@ -0,0 +1,148 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"player-character/internal/models"
+)
+
+// PostgreSQL's limits on tsvector positions
+const (
+	maxPhrasePosition  = 16383
+	maxPhrasePositions = 256 // per word
+)
+
+// termWeights returns the weighted number of times each word occurs in a
+// character's searchable fields, as MemoryStore's index records it
+func termWeights(c *models.Character) map[string]float64 {
+	weights := map[string]float64{}
+	for _, field := range textFields {
+		for _, value := range field.values(c) {
+			for _, word := range words(value) {
+				weights[word] += float64(field.weight)
+			}
+		}
+	}
+	return weights
+}
+
+// phraseVector returns a tsvector of the words in a character's searchable
+// fields and their positions. Values are a position apart, so a phrase only
+// matches within one value. The words are tokenized here rather than by a
+// text search configuration, so they match exactly as in the other stores.
+func phraseVector(c *models.Character) string {
+	positions := map[string][]string{}
+	position := 1
+	for _, field := range textFields {
+		for _, value := range field.values(c) {
+			ws := words(value)
+			for _, word := range ws {
+				if len(positions[word]) < maxPhrasePositions {
+					positions[word] = append(positions[word], strconv.Itoa(min(position, maxPhrasePosition)))
+				}
+				position++
+			}
+			if len(ws) > 0 {
+				position++
+			}
+		}
+	}
+
+	lexemes := make([]string, 0, len(positions))
+	for word, at := range positions {
+		// Words are letters and digits only, so they need no escaping
+		lexemes = append(lexemes, fmt.Sprintf("'%s':%s", word, strings.Join(at, ",")))
+	}
+	sort.Strings(lexemes)
+	return strings.Join(lexemes, " ")
+}
+
+// phraseQuery returns a tsquery matching every phrase of q, with its words adjacent
+func phraseQuery(q textQuery) string {
+	phrases := make([]string, len(q.phrases))
+	for i, phrase := range q.phrases {
+		quoted := make([]string, len(phrase))
+		for j, word := range phrase {
+			quoted[j] = "'" + word + "'"
+		}
+		phrases[i] = "(" + strings.Join(quoted, " <-> ") + ")"
+	}
+	return strings.Join(phrases, " & ")
+}
+
+// Search runs a full-text query over the searchable fields, most relevant first.
+// Scope, visibility and filter options apply as for List; sorting and cursors do not.
+// Scores are computed as MemoryStore computes them: each word's weighted
+// occurrences times its inverse document frequency.
+func (s *PostgresStore) Search(query string, opts ListOptions) ([]models.SearchHit, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	parsed := parseTextQuery(query)
+	if parsed.empty() {
+		total := 0
+		if opts.SkipCount {
+			total = -1
+		}
+		return []models.SearchHit{}, total, nil
+	}
+
+	q := &postgresQuery{}
+	terms := q.arg(parsed.terms)
+	conditions := []string{"terms ?| " + terms + "::text[]"}
+	if len(parsed.excluded) > 0 {
+		conditions = append(conditions, "NOT terms ?| "+q.arg(parsed.excluded)+"::text[]")
+	}
+	if len(parsed.phrases) > 0 {
+		conditions = append(conditions, "phrases @@ "+q.arg(phraseQuery(parsed))+"::text::tsquery")
+	}
+	conditions = append(conditions, listWhere(q, opts))
+	where := strings.Join(conditions, " AND ")
+
+	total := -1
+	if !opts.SkipCount {
+		if err := s.pool.QueryRow(ctx, "SELECT count(*) FROM characters WHERE "+where, q.args...).Scan(&total); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	sql := fmt.Sprintf(`WITH idf AS (
+		SELECT t.term, 1 + ln((SELECT count(*) FROM characters)::float8 / count(*)) AS idf
+		FROM unnest(%s::text[]) AS t(term) JOIN characters c ON c.terms ? t.term
+		GROUP BY t.term
+	)
+	SELECT doc, (SELECT sum((terms ->> idf.term)::float8 * idf.idf) FROM idf WHERE terms ? idf.term) AS score
+	FROM characters WHERE %s
+	ORDER BY score DESC, id ASC LIMIT %s OFFSET %s`,
+		terms, where, q.arg(opts.fetch()), q.arg((opts.Page-1)*opts.Limit))
+	rows, err := s.pool.Query(ctx, sql, q.args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := []models.SearchHit{}
+	for rows.Next() {
+		var hit models.SearchHit
+		var doc []byte
+		if err := rows.Scan(&doc, &hit.Score); err != nil {
+			rows.Close()
+			return nil, 0, err
+		}
+		if err := json.Unmarshal(doc, &hit.Character); err != nil {
+			rows.Close()
+			return nil, 0, err
+		}
+		hit.Highlights = highlights(&hit.Character, parsed)
+		hits = append(hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return hits, total, nil
+}
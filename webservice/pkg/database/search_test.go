@@ -0,0 +1,23 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSnippetTrimsLongText(t *testing.T) {
+	text := strings.Repeat("lorem ipsum ", 20) + "Menzoberranzan" + strings.Repeat(" dolor sit", 40)
+	got, ok := snippet(text, map[string]bool{"menzoberranzan": true})
+	if !ok {
+		t.Fatal("Expected a snippet")
+	}
+	if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") {
+		t.Errorf("Expected ellipses on both ends, got %q", got)
+	}
+	if !strings.Contains(got, "<mark>Menzoberranzan</mark>") {
+		t.Errorf("Expected the match to be marked, got %q", got)
+	}
+	if len(got) > snippetLength+50 {
+		t.Errorf("Expected a short snippet, got %d bytes", len(got))
+	}
+}
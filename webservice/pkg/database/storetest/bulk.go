@@ -0,0 +1,73 @@
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+)
+
+// testBulk checks a batch is applied in full, and that a batch with a failing
+// write leaves the store as it was
+func testBulk(t *testing.T, store database.CharacterStore) {
+	t.Helper()
+	newCharacter := func(name string) *models.Character {
+		return &models.Character{CharacterName: name, Race: "Human", Class: "Fighter", Level: 1}
+	}
+
+	keep, doomed := newCharacter("Keep"), newCharacter("Doomed")
+	for _, c := range []*models.Character{keep, doomed} {
+		if err := store.Create(c); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	// A failing write part-way through undoes the writes before it
+	renamed := *keep
+	renamed.CharacterName = "Renamed"
+	err := store.Bulk([]database.BulkWrite{
+		{Op: database.BulkCreate, Character: newCharacter("Fresh")},
+		{Op: database.BulkUpdate, ID: keep.ID, Character: &renamed},
+		{Op: database.BulkDelete, ID: doomed.ID},
+		{Op: database.BulkUpdate, ID: "missing", Character: newCharacter("Missing")},
+	})
+	var bulkErr *database.BulkError
+	if !errors.As(err, &bulkErr) || bulkErr.Index != 3 {
+		t.Fatalf("Expected the write at index 3 to fail, got %v", err)
+	}
+
+	characters, total, err := store.List(database.ListOptions{Page: 1, Limit: 10, SortBy: "characterName", SortOrder: "asc"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 2 || characters[0].CharacterName != "Doomed" || characters[1].CharacterName != "Keep" {
+		t.Fatalf("Expected the batch to be rolled back, got %+v", characters)
+	}
+	if hits, _, err := store.Search("fresh", database.ListOptions{Page: 1, Limit: 10}); err != nil || len(hits) != 0 {
+		t.Errorf("Expected rolled back creates to be unsearchable, got %v, %v", hits, err)
+	}
+
+	// Without failures every write is applied
+	renamed = *keep
+	renamed.CharacterName = "Renamed"
+	fresh := newCharacter("Fresh")
+	if err := store.Bulk([]database.BulkWrite{
+		{Op: database.BulkCreate, Character: fresh},
+		{Op: database.BulkUpdate, ID: keep.ID, Character: &renamed},
+		{Op: database.BulkDelete, ID: doomed.ID},
+	}); err != nil {
+		t.Fatalf("Bulk: %v", err)
+	}
+	if fresh.ID == "" {
+		t.Error("Expected the created character to get an ID")
+	}
+
+	characters, total, err = store.List(database.ListOptions{Page: 1, Limit: 10, SortBy: "characterName", SortOrder: "asc"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 2 || characters[0].CharacterName != "Fresh" || characters[1].CharacterName != "Renamed" {
+		t.Errorf("Expected Fresh and Renamed, got %+v", characters)
+	}
+}
@@ -0,0 +1,109 @@
+package storetest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+)
+
+// concurrency is how many goroutines each concurrency check runs
+const concurrency = 16
+
+// testConcurrency checks that simultaneous writes are neither lost nor
+// allowed to break ID uniqueness
+func testConcurrency(t *testing.T, store database.CharacterStore) {
+	t.Run("creates", func(t *testing.T) {
+		ids := make([]string, concurrency)
+		errs := make([]error, concurrency)
+		parallel(func(i int) {
+			character := newCharacter(fmt.Sprintf("Parallel %02d", i))
+			errs[i] = store.Create(character)
+			ids[i] = character.ID
+		})
+
+		seen := map[string]bool{}
+		for i, err := range errs {
+			if err != nil {
+				t.Fatalf("Create %d failed: %v", i, err)
+			}
+			if seen[ids[i]] {
+				t.Fatalf("Expected unique IDs, got %s twice", ids[i])
+			}
+			seen[ids[i]] = true
+		}
+		if _, total, err := store.List(database.ListOptions{Page: 1, Limit: 1}); err != nil || total != concurrency {
+			t.Errorf("Expected %d characters, got %d, %v", concurrency, total, err)
+		}
+	})
+
+	t.Run("creates with one ID", func(t *testing.T) {
+		errs := make([]error, concurrency)
+		parallel(func(i int) {
+			character := newCharacter(fmt.Sprintf("Contender %02d", i))
+			character.ID = "contested"
+			errs[i] = store.Create(character)
+		})
+
+		succeeded := 0
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+			}
+		}
+		if succeeded != 1 {
+			t.Errorf("Expected exactly one create to succeed, got %d", succeeded)
+		}
+		if _, total, err := store.List(database.ListOptions{Page: 1, Limit: 1, Search: "Contender"}); err != nil || total != 1 {
+			t.Errorf("Expected one stored contender, got %d, %v", total, err)
+		}
+	})
+
+	t.Run("updates and reads", func(t *testing.T) {
+		character := newCharacter("Target")
+		if err := store.Create(character); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+
+		errs := make([]error, concurrency)
+		parallel(func(i int) {
+			if i%2 == 0 {
+				errs[i] = store.Update(character.ID, &models.Character{
+					CharacterName: "Target", Race: "Human", Class: "Fighter", Level: i/2 + 1})
+				return
+			}
+			_, errs[i] = store.Get(character.ID)
+		})
+		for i, err := range errs {
+			if err != nil {
+				t.Errorf("Call %d failed: %v", i, err)
+			}
+		}
+
+		stored, err := store.Get(character.ID)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if stored.Level < 1 || stored.Level > concurrency/2 || !sameTime(stored.CreatedAt, character.CreatedAt) {
+			t.Errorf("Expected one of the updates with the original creation time, got %+v", stored)
+		}
+	})
+}
+
+// parallel runs f for each goroutine index at once and waits for them all
+func parallel(f func(i int)) {
+	var start, done sync.WaitGroup
+	start.Add(1)
+	for i := 0; i < concurrency; i++ {
+		done.Add(1)
+		go func(i int) {
+			defer done.Done()
+			start.Wait()
+			f(i)
+		}(i)
+	}
+	start.Done()
+	done.Wait()
+}
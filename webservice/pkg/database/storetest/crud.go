@@ -0,0 +1,168 @@
+package storetest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+)
+
+// testCRUD checks a character's life cycle: creation fills in the ID and
+// timestamps, updates replace everything but the ID and creation time, and
+// missing characters are reported
+func testCRUD(t *testing.T, store database.CharacterStore) {
+	character := &models.Character{
+		CharacterName: "Lyra", PlayerName: "Sam", Race: "Elf", Subrace: "High", Class: "Wizard", Level: 4,
+		Multiclass:    []models.MulticlassEntry{{Class: "Cleric", Subclass: "Life", Level: 1}},
+		Features:      []string{"Darkvision", "Arcane Recovery"},
+		AbilityScores: models.AbilityScores{Intelligence: models.AbilityScore{Base: 16}},
+		CampaignID:    "campaign-1", SecretBackstory: "A noble in hiding",
+	}
+	before := time.Now()
+	if err := store.Create(character); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if character.ID == "" {
+		t.Fatal("Expected Create to assign an ID")
+	}
+	if character.CreatedAt.Before(before.Truncate(time.Millisecond)) || !character.UpdatedAt.Equal(character.CreatedAt) {
+		t.Errorf("Expected Create to set both timestamps to now, got %v and %v", character.CreatedAt, character.UpdatedAt)
+	}
+
+	stored, err := store.Get(character.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !sameTime(stored.CreatedAt, character.CreatedAt) || !sameTime(stored.UpdatedAt, character.UpdatedAt) {
+		t.Errorf("Expected the stored timestamps %v and %v, got %v and %v",
+			character.CreatedAt, character.UpdatedAt, stored.CreatedAt, stored.UpdatedAt)
+	}
+	stored.CreatedAt, stored.UpdatedAt = character.CreatedAt, character.UpdatedAt
+	if !reflect.DeepEqual(stored, character) {
+		t.Errorf("Expected Get to return the created character\n%+v, got\n%+v", character, stored)
+	}
+
+	// An update replaces the character, clearing fields it leaves out, but
+	// keeps the ID and creation time whatever the update holds
+	time.Sleep(2 * time.Millisecond)
+	update := &models.Character{ID: "ignored", CharacterName: "Lyra Brightwater", Race: "Elf", Class: "Wizard", Level: 5,
+		CreatedAt: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if err := store.Update(character.ID, update); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if update.ID != character.ID || !sameTime(update.CreatedAt, character.CreatedAt) || !update.UpdatedAt.After(character.UpdatedAt) {
+		t.Errorf("Expected Update to fill in the ID, creation time and a later update time, got %s, %v, %v",
+			update.ID, update.CreatedAt, update.UpdatedAt)
+	}
+
+	stored, err = store.Get(character.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if stored.CharacterName != "Lyra Brightwater" || stored.Level != 5 {
+		t.Errorf("Expected the update to be stored, got %+v", stored)
+	}
+	if stored.PlayerName != "" || stored.Subrace != "" || len(stored.Multiclass) != 0 || len(stored.Features) != 0 ||
+		stored.CampaignID != "" || stored.SecretBackstory != "" {
+		t.Errorf("Expected fields left out of the update to be cleared, got %+v", stored)
+	}
+	if !sameTime(stored.CreatedAt, character.CreatedAt) || !sameTime(stored.UpdatedAt, update.UpdatedAt) {
+		t.Errorf("Expected creation time %v and update time %v, got %v and %v",
+			character.CreatedAt, update.UpdatedAt, stored.CreatedAt, stored.UpdatedAt)
+	}
+
+	if err := store.Delete(character.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Get(character.ID); err == nil {
+		t.Error("Expected Get to fail after Delete")
+	}
+	if err := store.Update(character.ID, newCharacter("Ghost")); err == nil {
+		t.Error("Expected Update of a deleted character to fail")
+	}
+	if err := store.Delete(character.ID); err == nil {
+		t.Error("Expected a second Delete to fail")
+	}
+	if _, total, err := store.List(database.ListOptions{Page: 1, Limit: 10}); err != nil || total != 0 {
+		t.Errorf("Expected an empty store, got %d characters, %v", total, err)
+	}
+}
+
+// testDuplicates checks that IDs stay unique
+func testDuplicates(t *testing.T, store database.CharacterStore) {
+	original := newCharacter("Original")
+	original.ID = "fixed-id"
+	if err := store.Create(original); err != nil {
+		t.Fatalf("Create with an ID failed: %v", err)
+	}
+	if original.ID != "fixed-id" {
+		t.Errorf("Expected Create to keep the given ID, got %s", original.ID)
+	}
+
+	impostor := newCharacter("Impostor")
+	impostor.ID = "fixed-id"
+	if err := store.Create(impostor); err == nil {
+		t.Error("Expected Create with a taken ID to fail")
+	}
+	if err := store.Restore(impostor); !errors.Is(err, database.ErrExists) {
+		t.Errorf("Expected Restore with a taken ID to return ErrExists, got %v", err)
+	}
+
+	stored, err := store.Get("fixed-id")
+	if err != nil || stored.CharacterName != "Original" {
+		t.Errorf("Expected the original character to be kept, got %+v, %v", stored, err)
+	}
+	if _, total, err := store.List(database.ListOptions{Page: 1, Limit: 10}); err != nil || total != 1 {
+		t.Errorf("Expected one character, got %d, %v", total, err)
+	}
+}
+
+// testRestore checks that restored characters keep their ID and timestamps,
+// and that All returns them oldest first
+func testRestore(t *testing.T, store database.CharacterStore) {
+	older := newCharacter("Older")
+	older.ID = "b-older"
+	older.CreatedAt = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	older.UpdatedAt = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	newer := newCharacter("Newer")
+	newer.ID = "a-newer"
+	newer.CreatedAt = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	newer.UpdatedAt = newer.CreatedAt
+
+	for _, c := range []*models.Character{newer, older} {
+		if err := store.Restore(c); err != nil {
+			t.Fatalf("Restore failed: %v", err)
+		}
+	}
+	created := newCharacter("Created")
+	if err := store.Create(created); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	stored, err := store.Get("b-older")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !sameTime(stored.CreatedAt, older.CreatedAt) || !sameTime(stored.UpdatedAt, older.UpdatedAt) {
+		t.Errorf("Expected the restored timestamps to be kept, got %v and %v", stored.CreatedAt, stored.UpdatedAt)
+	}
+
+	all, err := store.All()
+	if err != nil {
+		t.Fatalf("All failed: %v", err)
+	}
+	var ids []string
+	for _, c := range all {
+		ids = append(ids, c.ID)
+	}
+	if expected := []string{"b-older", "a-newer", created.ID}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected All to return %v, got %v", expected, ids)
+	}
+
+	if hits, _, err := store.Search("older", database.ListOptions{Page: 1, Limit: 10}); err != nil || len(hits) != 1 {
+		t.Errorf("Expected the restored character to be searchable, got %d hits, %v", len(hits), err)
+	}
+}
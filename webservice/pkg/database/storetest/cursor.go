@@ -0,0 +1,75 @@
+package storetest
+
+import (
+	"fmt"
+	"testing"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+)
+
+// testCursorPagination walks a store page by page with cursors and checks the
+// result matches a single listing, for each sort and with tied sort values
+func testCursorPagination(t *testing.T, store database.CharacterStore) {
+	t.Helper()
+	races := []string{"Human", "Elf", "Dwarf"}
+	for i := 0; i < 23; i++ {
+		character := models.Character{
+			CharacterName: fmt.Sprintf("Character %02d", i),
+			Race:          races[i%len(races)],
+			Class:         "Fighter",
+			Level:         i%4 + 1, // many ties
+		}
+		if err := store.Create(&character); err != nil {
+			t.Fatalf("Failed to create character: %v", err)
+		}
+	}
+
+	for _, sortBy := range []string{"characterName", "level", "race", "class", "createdAt"} {
+		for _, sortOrder := range []string{"asc", "desc"} {
+			t.Run(sortBy+" "+sortOrder, func(t *testing.T) {
+				all, _, err := store.List(database.ListOptions{Page: 1, Limit: 100, SortBy: sortBy, SortOrder: sortOrder})
+				if err != nil {
+					t.Fatalf("List failed: %v", err)
+				}
+
+				opts := database.ListOptions{Page: 1, Limit: 5, SortBy: sortBy, SortOrder: sortOrder, SkipCount: true}
+				var walked []string
+				for pages := 0; ; pages++ {
+					if pages > len(all) {
+						t.Fatal("Cursor pagination did not terminate")
+					}
+					page, total, err := store.List(opts)
+					if err != nil {
+						t.Fatalf("List failed: %v", err)
+					}
+					if total != -1 {
+						t.Errorf("Expected total -1 when counting is skipped, got %d", total)
+					}
+					for _, c := range page {
+						walked = append(walked, c.ID)
+					}
+					if len(page) < opts.Limit {
+						break
+					}
+
+					// Round-trip the cursor as a client would
+					after, err := database.DecodeCursor(database.NewCursor(opts, &page[len(page)-1]).Encode(), sortBy, sortOrder)
+					if err != nil {
+						t.Fatalf("database.DecodeCursor failed: %v", err)
+					}
+					opts.After = after
+				}
+
+				if len(walked) != len(all) {
+					t.Fatalf("Expected %d characters, walked %d", len(all), len(walked))
+				}
+				for i := range all {
+					if walked[i] != all[i].ID {
+						t.Fatalf("Order differs from a single listing at %d", i)
+					}
+				}
+			})
+		}
+	}
+}
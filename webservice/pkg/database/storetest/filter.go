@@ -0,0 +1,103 @@
+package storetest
+
+import (
+	"sort"
+	"testing"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+	"player-character/pkg/filter"
+)
+
+// filterCases is shared by every store so that filters mean the same thing everywhere
+var filterCases = []struct {
+	filter   string
+	expected []string
+}{
+	{"level>=5", []string{"Elminster", "Gandalf", "Shadow"}},
+	{"level>=5 AND level<=10", []string{"Gandalf", "Shadow"}},
+	{"class in (Wizard,Sorcerer)", []string{"Elminster", "Gandalf", "Merla"}},
+	{"class not in (Wizard,Sorcerer)", []string{"Bruenor", "Shadow"}},
+	{"alignment=Chaotic Good", []string{"Merla", "Shadow"}},
+	{"alignment!=Chaotic Good", []string{"Bruenor", "Elminster", "Gandalf"}},
+	{`alignment=""`, []string{"Bruenor"}},
+	{"subrace<M", []string{"Elminster", "Gandalf", "Merla", "Shadow"}},
+	{"multiclass.class=Rogue", []string{"Merla", "Shadow"}},
+	{"multiclass.class!=Rogue", []string{"Bruenor", "Elminster", "Gandalf"}},
+	{"multiclass.level>=3", []string{"Shadow"}},
+	{`multiclass.subclass=""`, []string{"Merla", "Shadow"}},
+	{"multiclass.subclass!=Thief", []string{"Bruenor", "Elminster", "Gandalf", "Merla"}},
+	{"experiencePoints<100", []string{"Bruenor", "Merla"}},
+	{`characterName~"AND"`, []string{"Gandalf"}},
+	{"characterName!~e", []string{"Gandalf", "Shadow"}},
+	{"race=Human AND (class=Wizard OR multiclass.class=Rogue)", []string{"Elminster", "Gandalf", "Shadow"}},
+	{"NOT (race=Human OR race=Dwarf)", []string{"Merla"}},
+	{"abilityScores.strength>12", []string{"Bruenor", "Shadow"}},
+	{"createdAt>2000-01-01", []string{"Bruenor", "Elminster", "Gandalf", "Merla", "Shadow"}},
+	{"createdAt<2000-01-01", nil},
+	{"campaignId in (c1,c2)", []string{"Elminster", "Gandalf"}},
+	{"campaignId=''", []string{"Bruenor", "Merla", "Shadow"}},
+}
+
+func filterFixtures() []models.Character {
+	scores := func(strength int) models.AbilityScores {
+		return models.AbilityScores{
+			Strength:     models.AbilityScore{Base: strength},
+			Dexterity:    models.AbilityScore{Base: 10},
+			Constitution: models.AbilityScore{Base: 10},
+			Intelligence: models.AbilityScore{Base: 10},
+			Wisdom:       models.AbilityScore{Base: 10},
+			Charisma:     models.AbilityScore{Base: 10},
+		}
+	}
+	return []models.Character{
+		{CharacterName: "Bruenor", Race: "Dwarf", Subrace: "Mountain", Class: "Fighter", Level: 4, AbilityScores: scores(17)},
+		{CharacterName: "Elminster", Race: "Human", Class: "Wizard", Level: 20, ExperiencePoints: 355000, Alignment: "Neutral Good",
+			CampaignID: "c1", AbilityScores: scores(10)},
+		{CharacterName: "Gandalf", Race: "Human", Class: "Wizard", Level: 10, ExperiencePoints: 64000, Alignment: "Neutral Good",
+			CampaignID: "c2", AbilityScores: scores(12)},
+		{CharacterName: "Merla", Race: "Half-Elf", Subrace: "Drow", Class: "Sorcerer", Level: 3, ExperiencePoints: 50, Alignment: "Chaotic Good",
+			Multiclass: []models.MulticlassEntry{{Class: "Rogue", Level: 1}}, AbilityScores: scores(8)},
+		{CharacterName: "Shadow", Race: "Human", Class: "Fighter", Level: 5, ExperiencePoints: 6500, Alignment: "Chaotic Good",
+			Multiclass: []models.MulticlassEntry{{Class: "Rogue", Subclass: "Thief", Level: 3}, {Class: "Ranger", Level: 1}}, AbilityScores: scores(14)},
+	}
+}
+
+// testFilters runs every filter case against a store seeded with filterFixtures
+func testFilters(t *testing.T, store database.CharacterStore) {
+	t.Helper()
+	for _, c := range filterFixtures() {
+		c := c
+		if err := store.Create(&c); err != nil {
+			t.Fatalf("Failed to create %s: %v", c.CharacterName, err)
+		}
+	}
+
+	for _, tt := range filterCases {
+		t.Run(tt.filter, func(t *testing.T) {
+			expr, err := filter.Parse(tt.filter)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+
+			characters, total, err := store.List(database.ListOptions{Page: 1, Limit: 100, SortBy: "characterName", SortOrder: "asc", Filter: expr})
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+
+			var names []string
+			for _, c := range characters {
+				names = append(names, c.CharacterName)
+			}
+			sort.Strings(names)
+			if total != len(tt.expected) || len(names) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v (total %d)", tt.expected, names, total)
+			}
+			for i := range names {
+				if names[i] != tt.expected[i] {
+					t.Fatalf("Expected %v, got %v", tt.expected, names)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package storetest
+
+import (
+	"strings"
+	"testing"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+)
+
+// testSearch checks full-text search: ranking, query syntax, highlights and
+// that the index follows writes
+func testSearch(t *testing.T, store database.CharacterStore) {
+	t.Helper()
+	characters := []models.Character{
+		{CharacterName: "Drizzt Do'Urden", Race: "Elf", Class: "Ranger", Level: 5,
+			Backstory: "Fled the Underdark and the city of Menzoberranzan.", Features: []string{"Two-Weapon Fighting"}},
+		{CharacterName: "Bruenor", Race: "Dwarf", Class: "Fighter", Level: 5,
+			Backstory: "King of Mithral Hall, friend of Drizzt.", Personality: "Gruff <but> loyal"},
+		{CharacterName: "Regis", Race: "Halfling", Class: "Rogue", Level: 3,
+			Notes: "Owes money to a pasha in Calimport.", SecretBackstory: "Stole the ruby pendant"},
+	}
+	ids := map[string]string{}
+	for i := range characters {
+		if err := store.Create(&characters[i]); err != nil {
+			t.Fatalf("Failed to create character: %v", err)
+		}
+		ids[characters[i].CharacterName] = characters[i].ID
+	}
+
+	search := func(t *testing.T, query string) []models.SearchHit {
+		t.Helper()
+		hits, total, err := store.Search(query, database.ListOptions{Page: 1, Limit: 10})
+		if err != nil {
+			t.Fatalf("Search(%q) failed: %v", query, err)
+		}
+		if total != len(hits) {
+			t.Errorf("Expected total %d, got %d", len(hits), total)
+		}
+		return hits
+	}
+	names := func(hits []models.SearchHit) string {
+		var result []string
+		for _, hit := range hits {
+			result = append(result, hit.Character.CharacterName)
+		}
+		return strings.Join(result, ",")
+	}
+
+	t.Run("ranks name matches first", func(t *testing.T) {
+		hits := search(t, "drizzt")
+		if names(hits) != "Drizzt Do'Urden,Bruenor" {
+			t.Fatalf("Expected Drizzt then Bruenor, got %s", names(hits))
+		}
+		if hits[0].Score <= hits[1].Score {
+			t.Errorf("Expected a higher score for the name match, got %v and %v", hits[0].Score, hits[1].Score)
+		}
+	})
+
+	t.Run("matches any word, ignoring case", func(t *testing.T) {
+		if got := names(search(t, "CALIMPORT weapon")); got != "Drizzt Do'Urden,Regis" && got != "Regis,Drizzt Do'Urden" {
+			t.Errorf("Expected Drizzt and Regis, got %s", got)
+		}
+	})
+
+	t.Run("phrases and exclusions", func(t *testing.T) {
+		if got := names(search(t, `"mithral hall"`)); got != "Bruenor" {
+			t.Errorf("Expected Bruenor for the phrase, got %s", got)
+		}
+		if got := names(search(t, `"hall mithral"`)); got != "" {
+			t.Errorf("Expected no match for words out of order, got %s", got)
+		}
+		if got := names(search(t, "drizzt -king")); got != "Drizzt Do'Urden" {
+			t.Errorf("Expected Bruenor excluded, got %s", got)
+		}
+	})
+
+	t.Run("highlights matches", func(t *testing.T) {
+		hits := search(t, "loyal")
+		if len(hits) != 1 || len(hits[0].Highlights) != 1 {
+			t.Fatalf("Expected one hit with one highlight, got %+v", hits)
+		}
+		highlight := hits[0].Highlights[0]
+		if highlight.Field != "personality" || highlight.Snippet != "Gruff &lt;but&gt; <mark>loyal</mark>" {
+			t.Errorf("Unexpected highlight %+v", highlight)
+		}
+	})
+
+	t.Run("punctuation is plain text", func(t *testing.T) {
+		if got := names(search(t, "(regis")); got != "Regis" {
+			t.Errorf("Expected Regis, got %s", got)
+		}
+	})
+
+	t.Run("hidden fields are not searchable", func(t *testing.T) {
+		if got := names(search(t, "ruby")); got != "" {
+			t.Errorf("Expected no match in the secret backstory, got %s", got)
+		}
+	})
+
+	t.Run("scoping applies", func(t *testing.T) {
+		hits, _, err := store.Search("drizzt", database.ListOptions{Page: 1, Limit: 10, OwnerID: "nobody"})
+		if err != nil || len(hits) != 0 {
+			t.Errorf("Expected no hits outside the scope, got %d, %v", len(hits), err)
+		}
+	})
+
+	t.Run("follows updates and deletes", func(t *testing.T) {
+		regis := characters[2]
+		regis.Notes = "Retired to Icewind Dale."
+		if err := store.Update(regis.ID, &regis); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if got := names(search(t, "calimport")); got != "" {
+			t.Errorf("Expected the old notes to be gone, got %s", got)
+		}
+		if got := names(search(t, "icewind")); got != "Regis" {
+			t.Errorf("Expected the new notes to match, got %s", got)
+		}
+
+		if err := store.Delete(ids["Bruenor"]); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		if got := names(search(t, "mithral")); got != "" {
+			t.Errorf("Expected a deleted character not to match, got %s", got)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package storetest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+)
+
+// testSort checks every sort field in both directions, with ties breaking
+// by ID in the sort's direction. Text compares byte by byte in every
+// backend, so upper case sorts before lower case and accented letters last.
+func testSort(t *testing.T, store database.CharacterStore) {
+	characters := []models.Character{
+		{ID: "1", CharacterName: "aelar", Race: "Elf", Class: "Wizard", Level: 3},
+		{ID: "2", CharacterName: "Zed", Race: "Human", Class: "Rogue", Level: 7},
+		{ID: "3", CharacterName: "Bruenor", Race: "Dwarf", Class: "Fighter", Level: 3},
+		{ID: "4", CharacterName: "Éowyn", Race: "Human", Class: "Fighter", Level: 5},
+	}
+	for i := range characters {
+		if err := store.Create(&characters[i]); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		// Creation times must differ at the millisecond precision stores keep
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	tests := []struct {
+		sortBy, sortOrder string
+		expected          []string
+	}{
+		// Bruenor, Zed, aelar, Éowyn
+		{"characterName", "asc", []string{"3", "2", "1", "4"}},
+		{"characterName", "desc", []string{"4", "1", "2", "3"}},
+		{"level", "asc", []string{"1", "3", "4", "2"}},
+		{"level", "desc", []string{"2", "4", "3", "1"}},
+		{"race", "asc", []string{"3", "1", "2", "4"}},
+		{"race", "desc", []string{"4", "2", "1", "3"}},
+		{"class", "asc", []string{"3", "4", "2", "1"}},
+		{"class", "desc", []string{"1", "2", "4", "3"}},
+		{"createdAt", "asc", []string{"1", "2", "3", "4"}},
+		{"createdAt", "desc", []string{"4", "3", "2", "1"}},
+		// Anything else sorts newest first
+		{"", "", []string{"4", "3", "2", "1"}},
+		{"secretBackstory", "asc", []string{"4", "3", "2", "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sortBy+" "+tt.sortOrder, func(t *testing.T) {
+			list, _, err := store.List(database.ListOptions{Page: 1, Limit: 10, SortBy: tt.sortBy, SortOrder: tt.sortOrder})
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			ids := characterIDs(list)
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+// testPagination checks page arithmetic at the edges: partial and empty
+// pages, uncounted listings, the lookahead and sparse fieldsets
+func testPagination(t *testing.T, store database.CharacterStore) {
+	for i := 0; i < 5; i++ {
+		character := models.Character{ID: fmt.Sprintf("%d", i), CharacterName: fmt.Sprintf("Character %d", i),
+			Race: "Human", Class: "Fighter", Level: i + 1, Features: []string{"Second Wind"}}
+		if err := store.Create(&character); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	list := func(t *testing.T, opts database.ListOptions) ([]models.Character, int) {
+		t.Helper()
+		opts.SortBy, opts.SortOrder = "characterName", "asc"
+		characters, total, err := store.List(opts)
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if characters == nil {
+			t.Fatal("Expected an empty slice rather than nil")
+		}
+		return characters, total
+	}
+
+	tests := []struct {
+		name     string
+		opts     database.ListOptions
+		expected []string
+		total    int
+	}{
+		{"first page", database.ListOptions{Page: 1, Limit: 2}, []string{"0", "1"}, 5},
+		{"middle page", database.ListOptions{Page: 2, Limit: 2}, []string{"2", "3"}, 5},
+		{"partial last page", database.ListOptions{Page: 3, Limit: 2}, []string{"4"}, 5},
+		{"past the end", database.ListOptions{Page: 4, Limit: 2}, []string{}, 5},
+		{"limit beyond the total", database.ListOptions{Page: 1, Limit: 100}, []string{"0", "1", "2", "3", "4"}, 5},
+		{"uncounted", database.ListOptions{Page: 2, Limit: 2, SkipCount: true}, []string{"2", "3"}, -1},
+		{"lookahead keeps the page offset", database.ListOptions{Page: 2, Limit: 2, Lookahead: true}, []string{"2", "3", "4"}, 5},
+		{"lookahead on the last page", database.ListOptions{Page: 3, Limit: 2, Lookahead: true}, []string{"4"}, 5},
+		{"scoped to nothing", database.ListOptions{Page: 1, Limit: 2, OwnerID: "nobody"}, []string{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			characters, total := list(t, tt.opts)
+			if ids := characterIDs(characters); !reflect.DeepEqual(ids, tt.expected) || total != tt.total {
+				t.Errorf("Expected %v of %d, got %v of %d", tt.expected, tt.total, ids, total)
+			}
+		})
+	}
+
+	t.Run("fields", func(t *testing.T) {
+		characters, _ := list(t, database.ListOptions{Page: 1, Limit: 1, Fields: []string{"id", "level"}})
+		expected := models.Character{ID: "0", Level: 1}
+		if len(characters) != 1 || !reflect.DeepEqual(characters[0], expected) {
+			t.Errorf("Expected only the ID and level, got %+v", characters)
+		}
+		if stored, err := store.Get("0"); err != nil || stored.CharacterName != "Character 0" || len(stored.Features) != 1 {
+			t.Errorf("Expected the stored character to be whole, got %+v, %v", stored, err)
+		}
+	})
+}
+
+// characterIDs returns the IDs of characters in order, never nil
+func characterIDs(characters []models.Character) []string {
+	ids := []string{}
+	for _, c := range characters {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
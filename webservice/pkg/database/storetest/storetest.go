@@ -0,0 +1,56 @@
+// Package storetest is the conformance suite for database.CharacterStore.
+// Every implementation runs it, so that handlers see the same behavior
+// whichever backend is configured:
+//
+//	func TestMyStore(t *testing.T) {
+//		storetest.Run(t, func(t *testing.T) database.CharacterStore {
+//			return newMyStore(t)
+//		})
+//	}
+//
+// Where backends store times differently, the suite compares them to the
+// millisecond, the precision MongoDB keeps.
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"player-character/internal/models"
+	"player-character/pkg/database"
+)
+
+// Run runs the suite, calling newStore for an empty store in each subtest.
+// newStore may skip the test when its backend is unavailable.
+func Run(t *testing.T, newStore func(t *testing.T) database.CharacterStore) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, store database.CharacterStore)
+	}{
+		{"CRUD", testCRUD},
+		{"Duplicates", testDuplicates},
+		{"Restore", testRestore},
+		{"Sort", testSort},
+		{"Pagination", testPagination},
+		{"Cursors", testCursorPagination},
+		{"Filters", testFilters},
+		{"Search", testSearch},
+		{"Bulk", testBulk},
+		{"Concurrency", testConcurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStore(t))
+		})
+	}
+}
+
+// newCharacter returns a valid character that has not been stored
+func newCharacter(name string) *models.Character {
+	return &models.Character{CharacterName: name, Race: "Human", Class: "Fighter", Level: 1}
+}
+
+// sameTime reports whether two times are equal to the millisecond
+func sameTime(a, b time.Time) bool {
+	return a.Truncate(time.Millisecond).Equal(b.Truncate(time.Millisecond))
+}
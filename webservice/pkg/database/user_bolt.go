@@ -0,0 +1,48 @@
+package database
+
+import (
+	"player-character/internal/models"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltUserStore implements user account storage in a bbolt database file,
+// keeping users in memory as MemoryUserStore does and writing every change
+// through to the file
+type BoltUserStore struct {
+	*MemoryUserStore
+	table *boltTable[models.User]
+}
+
+// NewBoltUserStore creates a user store in db, loading the users it already holds
+func NewBoltUserStore(db *bolt.DB) (*BoltUserStore, error) {
+	memory := NewMemoryUserStore()
+	get, set := mapAccess(&memory.mutex, memory.users)
+	table, err := newBoltTable(db, "users", get, set)
+	if err != nil {
+		return nil, err
+	}
+	return &BoltUserStore{MemoryUserStore: memory, table: table}, nil
+}
+
+// Create stores a new user, rejecting duplicate usernames
+func (s *BoltUserStore) Create(user *models.User) error {
+	assignID(&user.ID)
+	return s.table.write([]string{user.ID}, func() error {
+		return s.MemoryUserStore.Create(user)
+	})
+}
+
+// Restore stores a user as it is, keeping its ID and timestamps
+func (s *BoltUserStore) Restore(user *models.User) error {
+	return s.table.write([]string{user.ID}, func() error {
+		return s.MemoryUserStore.Restore(user)
+	})
+}
+
+// SetRole changes a user's role
+func (s *BoltUserStore) SetRole(id, role string) error {
+	return s.table.write([]string{id}, func() error {
+		return s.MemoryUserStore.SetRole(id, role)
+	})
+}
@@ -0,0 +1,147 @@
+package database
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryUserStore implements an in-memory user storage
+type MemoryUserStore struct {
+	users map[string]models.User
+	mutex sync.RWMutex
+}
+
+// NewMemoryUserStore creates a new in-memory user store
+func NewMemoryUserStore() *MemoryUserStore {
+	return &MemoryUserStore{
+		users: make(map[string]models.User),
+	}
+}
+
+// Create stores a new user, rejecting duplicate usernames
+func (s *MemoryUserStore) Create(user *models.User) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Generate ID if not provided
+	if user.ID == "" {
+		user.ID = uuid.New().String()
+	}
+
+	if _, exists := s.users[user.ID]; exists {
+		return errors.New("user with this ID already exists")
+	}
+	for _, existing := range s.users {
+		if strings.EqualFold(existing.Username, user.Username) {
+			return errors.New("username already taken")
+		}
+	}
+
+	// Set timestamps
+	now := time.Now()
+	user.CreatedAt = now
+	user.UpdatedAt = now
+
+	s.users[user.ID] = *user
+	return nil
+}
+
+// Get retrieves a user by ID
+func (s *MemoryUserStore) Get(id string) (*models.User, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	user, exists := s.users[id]
+	if !exists {
+		return nil, errors.New("user not found")
+	}
+
+	return &user, nil
+}
+
+// GetByUsername retrieves a user by username, ignoring case
+func (s *MemoryUserStore) GetByUsername(username string) (*models.User, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, user := range s.users {
+		if strings.EqualFold(user.Username, username) {
+			return &user, nil
+		}
+	}
+
+	return nil, errors.New("user not found")
+}
+
+// All retrieves every user, oldest first
+func (s *MemoryUserStore) All() ([]models.User, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	users := make([]models.User, 0, len(s.users))
+	for _, user := range s.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].CreatedAt.Before(users[j].CreatedAt)
+		}
+		return users[i].ID < users[j].ID
+	})
+
+	return users, nil
+}
+
+// Restore stores a user as it is, keeping its ID and timestamps
+func (s *MemoryUserStore) Restore(user *models.User) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.users[user.ID]; exists {
+		return ErrExists
+	}
+	for _, existing := range s.users {
+		if strings.EqualFold(existing.Username, user.Username) {
+			return ErrExists
+		}
+	}
+
+	s.users[user.ID] = *user
+	return nil
+}
+
+// SetRole changes a user's role
+func (s *MemoryUserStore) SetRole(id, role string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	user, exists := s.users[id]
+	if !exists {
+		return errors.New("user not found")
+	}
+
+	user.Role = role
+	user.UpdatedAt = time.Now()
+	s.users[id] = user
+	return nil
+}
+
+// UserStore interface defines the contract for user account storage
+type UserStore interface {
+	Create(user *models.User) error
+	Get(id string) (*models.User, error)
+	GetByUsername(username string) (*models.User, error)
+	// SetRole changes a user's role; sessions carry the role they were issued with until they expire
+	SetRole(id, role string) error
+	// All retrieves every user, oldest first, for backups
+	All() ([]models.User, error)
+	// Restore stores a user from a backup as it is, returning ErrExists if its ID or username is taken
+	Restore(user *models.User) error
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MongoUserStore implements MongoDB-based user storage
+type MongoUserStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoUserStore creates a user store backed by the given database
+func NewMongoUserStore(database *mongo.Database, collectionName string) *MongoUserStore {
+	return &MongoUserStore{
+		collection: database.Collection(collectionName),
+	}
+}
+
+// Create stores a new user, rejecting duplicate usernames
+func (s *MongoUserStore) Create(user *models.User) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := s.GetByUsername(user.Username); err == nil {
+		return errors.New("username already taken")
+	}
+
+	// Generate ID if not provided
+	if user.ID == "" {
+		user.ID = uuid.New().String()
+	}
+
+	// Set timestamps
+	now := time.Now()
+	user.CreatedAt = now
+	user.UpdatedAt = now
+
+	// Usernames are matched case-insensitively through a lowercased copy
+	doc := struct {
+		models.User   `bson:",inline"`
+		UsernameLower string `bson:"usernameLower"`
+	}{*user, strings.ToLower(user.Username)}
+
+	// The unique index catches a name registered since the check above
+	if _, err := s.collection.InsertOne(ctx, doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.New("username already taken")
+		}
+		return err
+	}
+	return nil
+}
+
+// Get retrieves a user by ID
+func (s *MongoUserStore) Get(id string) (*models.User, error) {
+	return s.findOne(bson.M{"id": id})
+}
+
+// GetByUsername retrieves a user by username, ignoring case
+func (s *MongoUserStore) GetByUsername(username string) (*models.User, error) {
+	return s.findOne(bson.M{"usernameLower": strings.ToLower(username)})
+}
+
+// SetRole changes a user's role
+func (s *MongoUserStore) SetRole(id, role string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := s.collection.UpdateOne(ctx, bson.M{"id": id}, bson.M{"$set": bson.M{"role": role, "updatedAt": time.Now()}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// findOne decodes the first user matching filter
+func (s *MongoUserStore) findOne(filter bson.M) (*models.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var user models.User
+	err := s.collection.FindOne(ctx, filter).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// All retrieves every user, oldest first
+func (s *MongoUserStore) All() ([]models.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	users := []models.User{}
+	if err := findAll(ctx, s.collection, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// Restore stores an user as it is, keeping its ID and timestamps
+func (s *MongoUserStore) Restore(user *models.User) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	doc := struct {
+		models.User   `bson:",inline"`
+		UsernameLower string `bson:"usernameLower"`
+	}{*user, strings.ToLower(user.Username)}
+
+	filter := bson.M{"$or": bson.A{bson.M{"id": user.ID}, bson.M{"usernameLower": doc.UsernameLower}}}
+	return insertIfAbsent(ctx, s.collection, filter, doc)
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"time"
+
+	"player-character/internal/models"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltWebhookStore implements webhook and delivery log storage in a bbolt
+// database file, keeping both in memory as MemoryWebhookStore does and
+// writing every change through to the file
+type BoltWebhookStore struct {
+	*MemoryWebhookStore
+	webhooks   *boltTable[models.Webhook]
+	deliveries *boltTable[models.WebhookDelivery]
+}
+
+// NewBoltWebhookStore creates a webhook store in db, loading the webhooks and deliveries it already holds
+func NewBoltWebhookStore(db *bolt.DB) (*BoltWebhookStore, error) {
+	memory := NewMemoryWebhookStore()
+	get, set := mapAccess(&memory.mutex, memory.webhooks)
+	webhooks, err := newBoltTable(db, "webhooks", get, set)
+	if err != nil {
+		return nil, err
+	}
+	getDelivery, setDeliveries := mapAccess(&memory.mutex, memory.deliveries)
+	deliveries, err := newBoltTable(db, "webhook_deliveries", getDelivery, setDeliveries)
+	if err != nil {
+		return nil, err
+	}
+	return &BoltWebhookStore{MemoryWebhookStore: memory, webhooks: webhooks, deliveries: deliveries}, nil
+}
+
+// Create stores a new webhook
+func (s *BoltWebhookStore) Create(webhook *models.Webhook) error {
+	assignID(&webhook.ID)
+	return s.webhooks.write([]string{webhook.ID}, func() error {
+		return s.MemoryWebhookStore.Create(webhook)
+	})
+}
+
+// Delete removes a webhook; its delivery log is kept
+func (s *BoltWebhookStore) Delete(id string) error {
+	return s.webhooks.write([]string{id}, func() error {
+		return s.MemoryWebhookStore.Delete(id)
+	})
+}
+
+// Restore stores a webhook as it is, keeping its ID and timestamps
+func (s *BoltWebhookStore) Restore(webhook *models.Webhook) error {
+	return s.webhooks.write([]string{webhook.ID}, func() error {
+		return s.MemoryWebhookStore.Restore(webhook)
+	})
+}
+
+// CreateDelivery stores a new delivery record
+func (s *BoltWebhookStore) CreateDelivery(delivery *models.WebhookDelivery) error {
+	assignID(&delivery.ID)
+	return s.deliveries.write([]string{delivery.ID}, func() error {
+		return s.MemoryWebhookStore.CreateDelivery(delivery)
+	})
+}
+
+// UpdateDelivery replaces a delivery record
+func (s *BoltWebhookStore) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	return s.deliveries.write([]string{delivery.ID}, func() error {
+		return s.MemoryWebhookStore.UpdateDelivery(delivery)
+	})
+}
+
+// ClaimDueDeliveries returns up to limit pending deliveries due at now, pushing
+// each one's next attempt back by lease so no other worker picks it up meanwhile
+func (s *BoltWebhookStore) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	s.deliveries.mutex.Lock()
+	defer s.deliveries.mutex.Unlock()
+
+	claimed, err := s.MemoryWebhookStore.ClaimDueDeliveries(now, lease, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claims are not put back when saving fails: in memory they only hold
+	// the deliveries back until the lease runs out
+	records := make(map[string]*models.WebhookDelivery, len(claimed))
+	for i := range claimed {
+		records[claimed[i].ID] = &claimed[i]
+	}
+	if err := s.deliveries.save(records); err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
@@ -0,0 +1,246 @@
+package database
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryWebhookStore implements an in-memory webhook and delivery log storage
+type MemoryWebhookStore struct {
+	webhooks   map[string]models.Webhook
+	deliveries map[string]models.WebhookDelivery
+	mutex      sync.RWMutex
+}
+
+// NewMemoryWebhookStore creates a new in-memory webhook store
+func NewMemoryWebhookStore() *MemoryWebhookStore {
+	return &MemoryWebhookStore{
+		webhooks:   make(map[string]models.Webhook),
+		deliveries: make(map[string]models.WebhookDelivery),
+	}
+}
+
+// Create stores a new webhook
+func (s *MemoryWebhookStore) Create(webhook *models.Webhook) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Generate ID if not provided
+	if webhook.ID == "" {
+		webhook.ID = uuid.New().String()
+	}
+
+	if _, exists := s.webhooks[webhook.ID]; exists {
+		return errors.New("webhook with this ID already exists")
+	}
+
+	webhook.CreatedAt = time.Now()
+
+	s.webhooks[webhook.ID] = *webhook
+	return nil
+}
+
+// Get retrieves a webhook by ID
+func (s *MemoryWebhookStore) Get(id string) (*models.Webhook, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	webhook, exists := s.webhooks[id]
+	if !exists {
+		return nil, errors.New("webhook not found")
+	}
+
+	return &webhook, nil
+}
+
+// ListByOwner retrieves every webhook registered by the user, newest first
+func (s *MemoryWebhookStore) ListByOwner(ownerID string) ([]models.Webhook, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	webhooks := []models.Webhook{}
+	for _, webhook := range s.webhooks {
+		if webhook.OwnerID == ownerID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.After(webhooks[j].CreatedAt)
+	})
+
+	return webhooks, nil
+}
+
+// ListForCampaign retrieves active webhooks for the campaign plus all active global webhooks
+func (s *MemoryWebhookStore) ListForCampaign(campaignID string) ([]models.Webhook, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	webhooks := []models.Webhook{}
+	for _, webhook := range s.webhooks {
+		if webhook.Active && (webhook.CampaignID == "" || webhook.CampaignID == campaignID) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return webhooks, nil
+}
+
+// Delete removes a webhook; its delivery log is kept
+func (s *MemoryWebhookStore) Delete(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.webhooks[id]; !exists {
+		return errors.New("webhook not found")
+	}
+
+	delete(s.webhooks, id)
+	return nil
+}
+
+// CreateDelivery stores a new delivery record
+func (s *MemoryWebhookStore) CreateDelivery(delivery *models.WebhookDelivery) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if delivery.ID == "" {
+		delivery.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+
+	s.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+// GetDelivery retrieves a delivery record by ID
+func (s *MemoryWebhookStore) GetDelivery(id string) (*models.WebhookDelivery, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	delivery, exists := s.deliveries[id]
+	if !exists {
+		return nil, errors.New("delivery not found")
+	}
+
+	return &delivery, nil
+}
+
+// UpdateDelivery replaces a delivery record
+func (s *MemoryWebhookStore) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.deliveries[delivery.ID]; !exists {
+		return errors.New("delivery not found")
+	}
+
+	delivery.UpdatedAt = time.Now()
+	s.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+// ListDeliveries retrieves up to limit deliveries for a webhook, newest first
+func (s *MemoryWebhookStore) ListDeliveries(webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	deliveries := []models.WebhookDelivery{}
+	for _, delivery := range s.deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// ClaimDueDeliveries returns up to limit pending deliveries due at now, pushing
+// each one's next attempt back by lease so no other worker picks it up meanwhile
+func (s *MemoryWebhookStore) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	claimed := []models.WebhookDelivery{}
+	for id, delivery := range s.deliveries {
+		if len(claimed) >= limit {
+			break
+		}
+		if delivery.Status != models.DeliveryPending || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		delivery.NextAttemptAt = now.Add(lease)
+		s.deliveries[id] = delivery
+		claimed = append(claimed, delivery)
+	}
+
+	return claimed, nil
+}
+
+// All retrieves every webhook, oldest first
+func (s *MemoryWebhookStore) All() ([]models.Webhook, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	webhooks := make([]models.Webhook, 0, len(s.webhooks))
+	for _, webhook := range s.webhooks {
+		webhooks = append(webhooks, webhook)
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		if !webhooks[i].CreatedAt.Equal(webhooks[j].CreatedAt) {
+			return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+		}
+		return webhooks[i].ID < webhooks[j].ID
+	})
+
+	return webhooks, nil
+}
+
+// Restore stores a webhook as it is, keeping its ID and timestamps
+func (s *MemoryWebhookStore) Restore(webhook *models.Webhook) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.webhooks[webhook.ID]; exists {
+		return ErrExists
+	}
+
+	s.webhooks[webhook.ID] = *webhook
+	return nil
+}
+
+// WebhookStore interface defines the contract for webhook and delivery log storage
+type WebhookStore interface {
+	Create(webhook *models.Webhook) error
+	Get(id string) (*models.Webhook, error)
+	ListByOwner(ownerID string) ([]models.Webhook, error)
+	ListForCampaign(campaignID string) ([]models.Webhook, error)
+	Delete(id string) error
+	// All retrieves every webhook, oldest first, for backups
+	All() ([]models.Webhook, error)
+	// Restore stores a webhook from a backup as it is, returning ErrExists if its ID is taken
+	Restore(webhook *models.Webhook) error
+
+	CreateDelivery(delivery *models.WebhookDelivery) error
+	GetDelivery(id string) (*models.WebhookDelivery, error)
+	UpdateDelivery(delivery *models.WebhookDelivery) error
+	ListDeliveries(webhookID string, limit int) ([]models.WebhookDelivery, error)
+	ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error)
+}
@@ -0,0 +1,240 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"player-character/internal/models"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoWebhookStore implements MongoDB-based webhook and delivery log storage
+type MongoWebhookStore struct {
+	webhooks   *mongo.Collection
+	deliveries *mongo.Collection
+}
+
+// NewMongoWebhookStore creates a webhook store backed by the given database
+func NewMongoWebhookStore(database *mongo.Database, webhookCollection, deliveryCollection string) *MongoWebhookStore {
+	return &MongoWebhookStore{
+		webhooks:   database.Collection(webhookCollection),
+		deliveries: database.Collection(deliveryCollection),
+	}
+}
+
+// Create stores a new webhook
+func (s *MongoWebhookStore) Create(webhook *models.Webhook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Generate ID if not provided
+	if webhook.ID == "" {
+		webhook.ID = uuid.New().String()
+	}
+
+	webhook.CreatedAt = time.Now()
+
+	_, err := s.webhooks.InsertOne(ctx, webhook)
+	return err
+}
+
+// Get retrieves a webhook by ID
+func (s *MongoWebhookStore) Get(id string) (*models.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var webhook models.Webhook
+	err := s.webhooks.FindOne(ctx, bson.M{"id": id}).Decode(&webhook)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("webhook not found")
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// ListByOwner retrieves every webhook registered by the user, newest first
+func (s *MongoWebhookStore) ListByOwner(ownerID string) ([]models.Webhook, error) {
+	return s.findWebhooks(bson.M{"ownerId": ownerID})
+}
+
+// ListForCampaign retrieves active webhooks for the campaign plus all active global webhooks
+func (s *MongoWebhookStore) ListForCampaign(campaignID string) ([]models.Webhook, error) {
+	return s.findWebhooks(bson.M{
+		"active": true,
+		"$or": []bson.M{
+			{"campaignId": bson.M{"$exists": false}},
+			{"campaignId": ""},
+			{"campaignId": campaignID},
+		},
+	})
+}
+
+// findWebhooks decodes every webhook matching filter, newest first
+func (s *MongoWebhookStore) findWebhooks(filter bson.M) ([]models.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := s.webhooks.Find(ctx, filter, options.Find().SetSort(bson.M{"createdAt": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := []models.Webhook{}
+	if err = cursor.All(ctx, &webhooks); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// All retrieves every webhook, oldest first
+func (s *MongoWebhookStore) All() ([]models.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	webhooks := []models.Webhook{}
+	if err := findAll(ctx, s.webhooks, &webhooks); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// Restore stores a webhook as it is, keeping its ID and timestamps
+func (s *MongoWebhookStore) Restore(webhook *models.Webhook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return insertIfAbsent(ctx, s.webhooks, bson.M{"id": webhook.ID}, webhook)
+}
+
+// Delete removes a webhook; its delivery log is kept
+func (s *MongoWebhookStore) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := s.webhooks.DeleteOne(ctx, bson.M{"id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("webhook not found")
+	}
+
+	return nil
+}
+
+// CreateDelivery stores a new delivery record
+func (s *MongoWebhookStore) CreateDelivery(delivery *models.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if delivery.ID == "" {
+		delivery.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+
+	_, err := s.deliveries.InsertOne(ctx, delivery)
+	return err
+}
+
+// GetDelivery retrieves a delivery record by ID
+func (s *MongoWebhookStore) GetDelivery(id string) (*models.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var delivery models.WebhookDelivery
+	err := s.deliveries.FindOne(ctx, bson.M{"id": id}).Decode(&delivery)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("delivery not found")
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// UpdateDelivery replaces a delivery record
+func (s *MongoWebhookStore) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	delivery.UpdatedAt = time.Now()
+
+	result, err := s.deliveries.ReplaceOne(ctx, bson.M{"id": delivery.ID}, delivery)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("delivery not found")
+	}
+
+	return nil
+}
+
+// ListDeliveries retrieves up to limit deliveries for a webhook, newest first
+func (s *MongoWebhookStore) ListDeliveries(webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.M{"createdAt": -1}).SetLimit(int64(limit))
+	cursor, err := s.deliveries.Find(ctx, bson.M{"webhookId": webhookID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []models.WebhookDelivery{}
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDueDeliveries returns up to limit pending deliveries due at now, pushing
+// each one's next attempt back by lease so no other replica picks it up meanwhile.
+// Each claim is a single atomic update, so concurrent workers never share a delivery.
+func (s *MongoWebhookStore) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"status":        models.DeliveryPending,
+		"nextAttemptAt": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"nextAttemptAt": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.M{"nextAttemptAt": 1}).
+		SetReturnDocument(options.After)
+
+	claimed := []models.WebhookDelivery{}
+	for len(claimed) < limit {
+		var delivery models.WebhookDelivery
+		err := s.deliveries.FindOneAndUpdate(ctx, filter, update, opts).Decode(&delivery)
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			return claimed, err
+		}
+		claimed = append(claimed, delivery)
+	}
+
+	return claimed, nil
+}
@@ -0,0 +1,228 @@
+// Package filter parses the character filter language into an expression tree
+// that each store evaluates or translates in its own way.
+//
+// A filter is one or more comparisons combined with AND, OR, NOT and parentheses:
+//
+//	level>=5 AND class in (Wizard,Sorcerer)
+//	alignment=Chaotic Good OR (multiclass.class=Rogue AND NOT race=Elf)
+//	createdAt>2026-01-01
+//
+// The operators are = != > >= < <= (compare), ~ !~ (contains, ignoring case)
+// and in / not in (one of a list). Values may be quoted to include commas,
+// parentheses or the words AND and OR. Comparisons on multiclass fields match
+// when any multiclass entry matches; their negations match when none does.
+package filter
+
+import (
+	"strings"
+	"time"
+
+	"player-character/internal/models"
+)
+
+// Type is the kind of value a field holds
+type Type int
+
+const (
+	String Type = iota
+	Int
+	Time
+)
+
+// Op is a comparison operator. Negated operators are represented with Not.
+type Op int
+
+const (
+	Eq Op = iota
+	Gt
+	Gte
+	Lt
+	Lte
+	In
+	Contains
+)
+
+// Field describes a filterable character field
+type Field struct {
+	Name string // name used in filters, matching the JSON field
+	Type Type
+	// Path is the BSON path of the field. For fields of multiclass entries it
+	// is relative to the entry, and Array holds the path of the entries.
+	Path  string
+	Array string
+	// Optional fields are omitted from documents when they hold the zero value
+	Optional bool
+	// values returns the field's values for a character; array fields return one per entry
+	values func(c *models.Character) []interface{}
+}
+
+// Values returns the field's values for a character, one per entry for multiclass fields
+func (f *Field) Values(c *models.Character) []interface{} {
+	return f.values(c)
+}
+
+// Zero returns the zero value of the field's type
+func (f *Field) Zero() interface{} {
+	switch f.Type {
+	case Int:
+		return 0
+	case Time:
+		return time.Time{}
+	default:
+		return ""
+	}
+}
+
+func stringField(name string, optional bool, get func(c *models.Character) string) *Field {
+	return &Field{Name: name, Type: String, Path: name, Optional: optional, values: func(c *models.Character) []interface{} {
+		return []interface{}{get(c)}
+	}}
+}
+
+func intField(name, path string, optional bool, get func(c *models.Character) int) *Field {
+	return &Field{Name: name, Type: Int, Path: path, Optional: optional, values: func(c *models.Character) []interface{} {
+		return []interface{}{get(c)}
+	}}
+}
+
+func timeField(name string, get func(c *models.Character) time.Time) *Field {
+	return &Field{Name: name, Type: Time, Path: name, values: func(c *models.Character) []interface{} {
+		return []interface{}{truncate(get(c))}
+	}}
+}
+
+func multiclassField(name, path string, typ Type, optional bool, get func(mc *models.MulticlassEntry) interface{}) *Field {
+	return &Field{Name: name, Type: typ, Path: path, Array: "multiclass", Optional: optional, values: func(c *models.Character) []interface{} {
+		values := make([]interface{}, len(c.Multiclass))
+		for i := range c.Multiclass {
+			values[i] = get(&c.Multiclass[i])
+		}
+		return values
+	}}
+}
+
+// Fields lists the filterable fields by name. Hidden fields (secret backstory
+// and DM notes) are deliberately absent so filters cannot reveal them.
+var Fields = map[string]*Field{}
+
+func init() {
+	for _, f := range []*Field{
+		stringField("id", false, func(c *models.Character) string { return c.ID }),
+		stringField("characterName", false, func(c *models.Character) string { return c.CharacterName }),
+		stringField("playerName", true, func(c *models.Character) string { return c.PlayerName }),
+		stringField("ownerId", true, func(c *models.Character) string { return c.OwnerID }),
+		stringField("race", false, func(c *models.Character) string { return c.Race }),
+		stringField("subrace", true, func(c *models.Character) string { return c.Subrace }),
+		stringField("class", false, func(c *models.Character) string { return c.Class }),
+		stringField("subclass", true, func(c *models.Character) string { return c.Subclass }),
+		stringField("background", true, func(c *models.Character) string { return c.Background }),
+		stringField("alignment", true, func(c *models.Character) string { return c.Alignment }),
+		stringField("campaignId", true, func(c *models.Character) string { return c.CampaignID }),
+		stringField("partyId", true, func(c *models.Character) string { return c.PartyID }),
+		intField("level", "level", false, func(c *models.Character) int { return c.Level }),
+		intField("experiencePoints", "experiencePoints", true, func(c *models.Character) int { return c.ExperiencePoints }),
+		intField("abilityScores.strength", "abilityScores.strength.base", false, func(c *models.Character) int { return c.AbilityScores.Strength.Base }),
+		intField("abilityScores.dexterity", "abilityScores.dexterity.base", false, func(c *models.Character) int { return c.AbilityScores.Dexterity.Base }),
+		intField("abilityScores.constitution", "abilityScores.constitution.base", false, func(c *models.Character) int { return c.AbilityScores.Constitution.Base }),
+		intField("abilityScores.intelligence", "abilityScores.intelligence.base", false, func(c *models.Character) int { return c.AbilityScores.Intelligence.Base }),
+		intField("abilityScores.wisdom", "abilityScores.wisdom.base", false, func(c *models.Character) int { return c.AbilityScores.Wisdom.Base }),
+		intField("abilityScores.charisma", "abilityScores.charisma.base", false, func(c *models.Character) int { return c.AbilityScores.Charisma.Base }),
+		multiclassField("multiclass.class", "class", String, false, func(mc *models.MulticlassEntry) interface{} { return mc.Class }),
+		multiclassField("multiclass.subclass", "subclass", String, true, func(mc *models.MulticlassEntry) interface{} { return mc.Subclass }),
+		multiclassField("multiclass.level", "level", Int, false, func(mc *models.MulticlassEntry) interface{} { return mc.Level }),
+		timeField("createdAt", func(c *models.Character) time.Time { return c.CreatedAt }),
+		timeField("updatedAt", func(c *models.Character) time.Time { return c.UpdatedAt }),
+	} {
+		Fields[f.Name] = f
+	}
+}
+
+// truncate drops sub-millisecond precision, which MongoDB does not store
+func truncate(t time.Time) time.Time {
+	return t.Truncate(time.Millisecond)
+}
+
+// Expr is a node of a parsed filter: *And, *Or, *Not or *Comparison
+type Expr interface {
+	expr()
+}
+
+// And matches when every operand matches
+type And struct {
+	Operands []Expr
+}
+
+// Or matches when any operand matches
+type Or struct {
+	Operands []Expr
+}
+
+// Not matches when its operand does not
+type Not struct {
+	Operand Expr
+}
+
+// Comparison tests a field against one value, or several for In. Values hold
+// the field's Go type: string, int or time.Time.
+type Comparison struct {
+	Field  *Field
+	Op     Op
+	Values []interface{}
+}
+
+func (*And) expr()        {}
+func (*Or) expr()         {}
+func (*Not) expr()        {}
+func (*Comparison) expr() {}
+
+// Matches reports whether a single field value satisfies the comparison
+func (c *Comparison) Matches(value interface{}) bool {
+	switch c.Op {
+	case In:
+		for _, v := range c.Values {
+			if compare(value, v) == 0 {
+				return true
+			}
+		}
+		return false
+	case Contains:
+		s, _ := value.(string)
+		sub, _ := c.Values[0].(string)
+		return strings.Contains(strings.ToLower(s), strings.ToLower(sub))
+	}
+
+	cmp := compare(value, c.Values[0])
+	switch c.Op {
+	case Eq:
+		return cmp == 0
+	case Gt:
+		return cmp > 0
+	case Gte:
+		return cmp >= 0
+	case Lt:
+		return cmp < 0
+	case Lte:
+		return cmp <= 0
+	}
+	return false
+}
+
+// compare orders two values of the same field type
+func compare(a, b interface{}) int {
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case int:
+		b := b.(int)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	}
+	return 0
+}
@@ -0,0 +1,388 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// MaxLength is the longest filter accepted, in bytes
+	MaxLength = 2000
+	// maxDepth bounds nesting of parentheses and NOT
+	maxDepth = 32
+)
+
+// Error describes why a filter could not be parsed
+type Error struct {
+	Pos     int // byte offset into the filter
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid filter at position %d: %s", e.Pos+1, e.Message)
+}
+
+// Parse parses a filter. An empty or blank filter returns a nil Expr.
+func Parse(input string) (Expr, error) {
+	if len(input) > MaxLength {
+		return nil, &Error{Pos: MaxLength, Message: fmt.Sprintf("filter is longer than %d characters", MaxLength)}
+	}
+
+	p := &parser{input: input}
+	p.skipSpace()
+	if p.done() {
+		return nil, nil
+	}
+
+	expr, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.done() {
+		return nil, p.errorf("unexpected %q", p.rest())
+	}
+	return expr, nil
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) rest() string {
+	rest := p.input[p.pos:]
+	if len(rest) > 20 {
+		rest = rest[:20] + "..."
+	}
+	return rest
+}
+
+func (p *parser) errorf(format string, args ...interface{}) *Error {
+	return &Error{Pos: p.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpace() {
+	for !p.done() && unicode.IsSpace(rune(p.peek())) {
+		p.pos++
+	}
+}
+
+// keyword consumes the next word if it equals kw, ignoring case
+func (p *parser) keyword(kw string) bool {
+	p.skipSpace()
+	end := p.pos + len(kw)
+	if end > len(p.input) || !strings.EqualFold(p.input[p.pos:end], kw) {
+		return false
+	}
+	if end < len(p.input) && isWordByte(p.input[end]) {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+// atKeyword reports whether the next word is one of the keywords, without consuming it
+func (p *parser) atKeyword(kws ...string) bool {
+	start := p.pos
+	defer func() { p.pos = start }()
+	for _, kw := range kws {
+		if p.keyword(kw) {
+			return true
+		}
+		p.pos = start
+	}
+	return false
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b == '.' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+func (p *parser) parseOr(depth int) (Expr, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	operands := []Expr{left}
+	for p.keyword("OR") {
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return &Or{Operands: operands}, nil
+}
+
+func (p *parser) parseAnd(depth int) (Expr, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	operands := []Expr{left}
+	for p.keyword("AND") {
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return &And{Operands: operands}, nil
+}
+
+func (p *parser) parseUnary(depth int) (Expr, error) {
+	if depth >= maxDepth {
+		return nil, p.errorf("filter is nested too deeply")
+	}
+
+	if p.keyword("NOT") {
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Operand: operand}, nil
+	}
+
+	p.skipSpace()
+	if p.peek() == '(' {
+		p.pos++
+		expr, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, p.errorf("expected )")
+		}
+		p.pos++
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+// operators maps comparison symbols to their operator and whether they are negated.
+// Longer symbols come first so that >= is not read as >.
+var operators = []struct {
+	symbol  string
+	op      Op
+	negated bool
+}{
+	{">=", Gte, false},
+	{"<=", Lte, false},
+	{"!=", Eq, true},
+	{"!~", Contains, true},
+	{"=", Eq, false},
+	{">", Gt, false},
+	{"<", Lt, false},
+	{"~", Contains, false},
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	p.skipSpace()
+	start := p.pos
+	for !p.done() && isWordByte(p.peek()) {
+		p.pos++
+	}
+	if p.pos == start {
+		if p.done() {
+			return nil, p.errorf("expected a field name")
+		}
+		return nil, p.errorf("expected a field name, got %q", p.rest())
+	}
+
+	name := p.input[start:p.pos]
+	field, ok := Fields[name]
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("unknown field %q", name)
+	}
+
+	comparison := &Comparison{Field: field}
+	negated := false
+
+	p.skipSpace()
+	switch {
+	case p.keyword("IN"):
+		comparison.Op = In
+	case p.keyword("NOT"):
+		if !p.keyword("IN") {
+			return nil, p.errorf("expected IN after NOT")
+		}
+		comparison.Op = In
+		negated = true
+	default:
+		found := false
+		for _, o := range operators {
+			if strings.HasPrefix(p.input[p.pos:], o.symbol) {
+				p.pos += len(o.symbol)
+				comparison.Op, negated, found = o.op, o.negated, true
+				break
+			}
+		}
+		if !found {
+			return nil, p.errorf("expected an operator after %s", name)
+		}
+	}
+
+	if comparison.Op == Contains && field.Type != String {
+		return nil, p.errorf("%s is not text, so ~ cannot be used", name)
+	}
+
+	if comparison.Op == In {
+		values, err := p.parseList(field)
+		if err != nil {
+			return nil, err
+		}
+		comparison.Values = values
+	} else {
+		value, err := p.parseValue(field, false)
+		if err != nil {
+			return nil, err
+		}
+		comparison.Values = []interface{}{value}
+	}
+
+	if negated {
+		return &Not{Operand: comparison}, nil
+	}
+	return comparison, nil
+}
+
+func (p *parser) parseList(field *Field) ([]interface{}, error) {
+	p.skipSpace()
+	if p.peek() != '(' {
+		return nil, p.errorf("expected ( after IN")
+	}
+	p.pos++
+
+	var values []interface{}
+	for {
+		value, err := p.parseValue(field, true)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return values, nil
+		default:
+			return nil, p.errorf("expected , or ) in list")
+		}
+	}
+}
+
+// parseValue reads a quoted or bare value and converts it to the field's type.
+// A bare value runs up to the next AND or OR, closing parenthesis or, inside
+// a list, comma, and may contain spaces.
+func (p *parser) parseValue(field *Field, inList bool) (interface{}, error) {
+	p.skipSpace()
+	start := p.pos
+
+	var raw string
+	if q := p.peek(); q == '"' || q == '\'' {
+		s, err := p.parseQuoted(q)
+		if err != nil {
+			return nil, err
+		}
+		raw = s
+	} else {
+		end := p.pos
+		for !p.done() {
+			p.skipSpace()
+			if p.done() || p.atKeyword("AND", "OR") {
+				break
+			}
+			if c := p.peek(); c == ')' || c == '(' || inList && c == ',' {
+				break
+			}
+			for !p.done() && !unicode.IsSpace(rune(p.peek())) && p.peek() != ')' && p.peek() != '(' && !(inList && p.peek() == ',') {
+				p.pos++
+			}
+			end = p.pos
+		}
+		raw = p.input[start:end]
+		p.pos = end
+		if raw == "" {
+			return nil, p.errorf("expected a value for %s", field.Name)
+		}
+	}
+
+	value, err := convert(field, raw)
+	if err != nil {
+		return nil, &Error{Pos: start, Message: err.Error()}
+	}
+	return value, nil
+}
+
+func (p *parser) parseQuoted(quote byte) (string, error) {
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for !p.done() {
+		c := p.peek()
+		p.pos++
+		switch c {
+		case quote:
+			return b.String(), nil
+		case '\\':
+			if p.done() {
+				break
+			}
+			b.WriteByte(p.peek())
+			p.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", &Error{Pos: start, Message: "unterminated quoted value"}
+}
+
+// convert parses a raw value into the field's Go type
+func convert(field *Field, raw string) (interface{}, error) {
+	switch field.Type {
+	case Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects a whole number, got %q", field.Name, raw)
+		}
+		return n, nil
+	case Time:
+		if t, err := time.Parse("2006-01-02", raw); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects a date (2006-01-02) or RFC 3339 time, got %q", field.Name, raw)
+		}
+		return truncate(t), nil
+	default:
+		return raw, nil
+	}
+}
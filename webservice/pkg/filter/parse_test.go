@@ -0,0 +1,129 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseStructure(t *testing.T) {
+	expr, err := Parse("level>=5 AND class in (Wizard, Sorcerer) OR NOT alignment=Chaotic Good")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	or, ok := expr.(*Or)
+	if !ok || len(or.Operands) != 2 {
+		t.Fatalf("Expected OR of two operands, got %#v", expr)
+	}
+
+	and, ok := or.Operands[0].(*And)
+	if !ok || len(and.Operands) != 2 {
+		t.Fatalf("Expected AND to bind tighter than OR, got %#v", or.Operands[0])
+	}
+	level := and.Operands[0].(*Comparison)
+	if level.Field.Name != "level" || level.Op != Gte || level.Values[0] != 5 {
+		t.Errorf("Unexpected level comparison: %+v", level)
+	}
+	class := and.Operands[1].(*Comparison)
+	if class.Op != In || len(class.Values) != 2 || class.Values[0] != "Wizard" || class.Values[1] != "Sorcerer" {
+		t.Errorf("Unexpected class comparison: %+v", class)
+	}
+
+	not, ok := or.Operands[1].(*Not)
+	if !ok {
+		t.Fatalf("Expected NOT, got %#v", or.Operands[1])
+	}
+	alignment := not.Operand.(*Comparison)
+	if alignment.Values[0] != "Chaotic Good" {
+		t.Errorf("Expected a bare value with a space, got %q", alignment.Values[0])
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	tests := []struct {
+		filter string
+		field  string
+		op     Op
+		value  interface{}
+		negate bool
+	}{
+		{"alignment = Lawful Good", "alignment", Eq, "Lawful Good", false},
+		{`background="Far Traveler, Sage"`, "background", Eq, "Far Traveler, Sage", false},
+		{`characterName='Tom \'Lucky\' Hill'`, "characterName", Eq, "Tom 'Lucky' Hill", false},
+		{"race != Elf", "race", Eq, "Elf", true},
+		{"characterName ~ gan", "characterName", Contains, "gan", false},
+		{"characterName !~ gan", "characterName", Contains, "gan", true},
+		{"multiclass.class=Rogue", "multiclass.class", Eq, "Rogue", false},
+		{"abilityScores.strength<10", "abilityScores.strength", Lt, 10, false},
+		{"createdAt>2026-01-01", "createdAt", Gt, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"updatedAt<=2026-03-04T05:06:07Z", "updatedAt", Lte, time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC), false},
+		{"class not in (Wizard)", "class", In, "Wizard", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			expr, err := Parse(tt.filter)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if not, ok := expr.(*Not); ok != tt.negate {
+				t.Fatalf("Expected negated=%v, got %#v", tt.negate, expr)
+			} else if ok {
+				expr = not.Operand
+			}
+			c := expr.(*Comparison)
+			if c.Field.Name != tt.field || c.Op != tt.op {
+				t.Errorf("Expected %s with op %d, got %s with op %d", tt.field, tt.op, c.Field.Name, c.Op)
+			}
+			if got := c.Values[0]; got != tt.value {
+				if tm, ok := got.(time.Time); !ok || !tm.Equal(tt.value.(time.Time)) {
+					t.Errorf("Expected value %v, got %v", tt.value, got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	for _, input := range []string{"", "   "} {
+		expr, err := Parse(input)
+		if expr != nil || err != nil {
+			t.Errorf("Parse(%q) = %v, %v; expected nil, nil", input, expr, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"secretBackstory~dragon":   "unknown field",
+		"level>=high":              "whole number",
+		"createdAt>yesterday":      "date",
+		"level~5":                  "not text",
+		"level 5":                  "operator",
+		"race=":                    "expected a value",
+		"race=Elf AND":             "field name",
+		"(race=Elf":                "expected )",
+		"class in Wizard":          "expected (",
+		"class in (Wizard":         "expected , or )",
+		`race="Elf`:                "unterminated",
+		"race=Elf) AND level=1":    "unexpected",
+		"class not Wizard":         "IN after NOT",
+		strings.Repeat("(", 40):    "nested too deeply",
+		strings.Repeat("x", 2001):  "longer than",
+		"race=Elf OR OR level=1":   "unknown field",
+		"level>=1 AND NOT":         "field name",
+		"multiclass.level in (1,)": "expected a value",
+	}
+
+	for input, expected := range tests {
+		_, err := Parse(input)
+		if err == nil {
+			t.Errorf("Parse(%.30q) succeeded; expected an error containing %q", input, expected)
+			continue
+		}
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Parse(%.30q) error %q does not contain %q", input, err, expected)
+		}
+	}
+}